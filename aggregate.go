@@ -0,0 +1,118 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/krostar/test/testingt"
+)
+
+// failureReporter is implemented by TestingT wrappers that report assertions failures themselves.
+// They are given the options of the failed assertion along with its failure.
+type failureReporter interface {
+	reportFailure(opts options, failure Failure)
+}
+
+// AggregateFailures returns a TestingT grouping the failures of the assertions made on it.
+//
+// When many goroutines fail nearly simultaneously, the first failure is often the cause of the others.
+// The first failure of a group is logged as usual, and the failures happening within `window` after it
+// are summarized once the window is over (or when the test completes), pointing to the first one as the probable root cause.
+// A failure happening after the window starts a new group.
+//
+// Example:
+//
+//	func Test_Cluster(t *testing.T) {
+//		tt := test.AggregateFailures(t, 100*time.Millisecond)
+//		for _, node := range nodes {
+//			go func() { test.Assert(tt, node.Healthy()) }()
+//		}
+//	}
+func AggregateFailures(t TestingT, window time.Duration) TestingT {
+	a := &aggregatedT{TestingT: t, window: window, afterFunc: afterFunc}
+	t.Cleanup(a.close)
+	return a
+}
+
+// aggregatedT wraps a TestingT to group failures, see AggregateFailures.
+type aggregatedT struct {
+	TestingT
+
+	window    time.Duration
+	afterFunc func(d time.Duration, f func()) (stop func() bool) // schedules the end of groups, see afterFunc
+
+	m          sync.Mutex
+	closed     bool
+	groupStart time.Time
+	stopTimer  func() bool // stops the timer ending the current group, if any
+	followers  []string    // failures following the first failure of the current group
+}
+
+// afterFunc calls f in its own goroutine after d, unless the returned function is called before.
+func afterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// reportFailure logs the first failure of a group, and delays the logging of the following ones.
+func (a *aggregatedT) reportFailure(opts options, failure Failure) {
+	a.Helper()
+
+	msg := colorizeMessage(opts.color, failure.Message)
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	now := time.Now()
+
+	if a.stopTimer != nil && !a.closed {
+		a.followers = append(a.followers, fmt.Sprintf("+%s: %s", now.Sub(a.groupStart).String(), msg))
+		return
+	}
+
+	a.Logf("Error: %s", msg)
+
+	if a.closed {
+		return
+	}
+
+	a.groupStart = now
+	a.stopTimer = a.afterFunc(a.window, a.flush)
+}
+
+// Unwrap returns the wrapped TestingT, see testingt.Unwrap.
+func (a *aggregatedT) Unwrap() testingt.TestingT { return a.TestingT }
+
+// flush ends the current group, and logs the summary of the failures that followed the first one.
+func (a *aggregatedT) flush() {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.flushLocked()
+}
+
+func (a *aggregatedT) flushLocked() {
+	if a.stopTimer != nil {
+		a.stopTimer()
+		a.stopTimer = nil
+	}
+
+	if len(a.followers) == 0 {
+		return
+	}
+
+	a.Logf("Error: %d more failures happened within %s after the failure at %s, which is their probable root cause:\n  - %s",
+		len(a.followers), a.window.String(), a.groupStart.Format(time.TimeOnly+".000"), strings.Join(a.followers, "\n  - "),
+	)
+	a.followers = nil
+}
+
+// close flushes the current group, and stops grouping failures as the test completed.
+func (a *aggregatedT) close() {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.flushLocked()
+	a.closed = true
+}
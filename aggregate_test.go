@@ -0,0 +1,57 @@
+package test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_AggregateFailures(t *testing.T) {
+	t.Run("failures within the window are grouped", func(t *testing.T) {
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))
+		tt := AggregateFailures(spiedT, time.Hour)
+
+		Assert(tt, false, "first")
+
+		var wg sync.WaitGroup
+		for range 3 {
+			wg.Go(func() { Assert(tt, false, "follower") })
+		}
+		wg.Wait()
+
+		Assert(tt, true)
+
+		spiedT.ExpectLogsToContain(t, "Error: ", "[first]")
+
+		cleanup()
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: 3 more failures happened within 1h0m0s after the failure at ", "which is their probable root cause:\n  - +", "[follower]")
+
+		Assert(tt, false, "after test completion")
+		spiedT.ExpectLogsToContain(t, "[after test completion]")
+	})
+
+	t.Run("failures after the window start a new group", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		tt := AggregateFailures(spiedT, time.Millisecond)
+
+		var endWindow func()
+		tt.(*aggregatedT).afterFunc = func(_ time.Duration, f func()) func() bool {
+			endWindow = f
+			return func() bool { return true }
+		}
+
+		Assert(tt, false, "first")
+		endWindow()
+		Assert(tt, false, "second")
+
+		spiedT.ExpectLogsToContain(t, "[first]", "[second]")
+
+		tt.(*aggregatedT).close()
+	})
+}
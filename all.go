@@ -0,0 +1,128 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/krostar/test/internal/message"
+)
+
+// AssertAll checks every provided condition, and fails the test once if any of them is false.
+//
+// Unlike a sequence of Assert calls, where the first failure usually hides the following ones as the test
+// is often stopped or its output becomes noisy, every condition is evaluated and each failing one is reported
+// with its own message, built like Assert builds its message.
+//
+// AssertAll returns true if all the conditions are true.
+//
+// Example:
+//
+//	test.AssertAll(t,
+//		user.Name == "Bob",
+//		user.Age == 42,
+//		len(user.Roles) > 0,
+//	)
+//
+// -> Error: user.Name is not equal to "Bob"
+// -> Error: len(user.Roles) is not greater than 0
+func AssertAll(t TestingT, conds ...bool) bool {
+	t.Helper()
+
+	passed := true
+	opts := optionsOf(t)
+
+	for i, cond := range conds {
+		result := AssertionResult{Passed: cond, Case: caseName(t)}
+
+		if !cond || opts.successMessages {
+			if opts.sourceAnalysis {
+				var err error
+
+				result.Description, err = message.FromBoolArg(1, i+1, cond)
+				if err != nil {
+					t.Logf("krostar/test internal failure: unable to get assertion message: %v", err)
+				}
+				if result.Description == "" {
+					result.Description = fmt.Sprintf("condition #%d: %s", i+1, genericDescription(cond))
+				}
+
+				result.Expression, _ = message.ExpressionArg(1, i+1)
+			} else {
+				result.Description = fmt.Sprintf("condition #%d: %s", i+1, genericDescription(cond))
+			}
+
+			msg := formatResult(t, opts, result)
+
+			if cond {
+				logSuccess(t, msg)
+			} else {
+				recordFailure(t, opts, 1, i+1, msg, nil)
+			}
+		}
+
+		passed = passed && cond
+	}
+
+	if !passed {
+		failAssertion(t, nil)
+	}
+
+	return passed
+}
+
+// AssertAllChecks evaluates every provided check, and fails the test once if any of them fails.
+//
+// It is the equivalent of AssertAll for checks, like the ones of the check package:
+// each failing check is reported with its position and its message.
+//
+// AssertAllChecks returns true if all the checks passed.
+// Checks taking too long can be aborted, see WithCheckTimeout.
+//
+// Example:
+//
+//	test.AssertAllChecks(t,
+//		func(t test.TestingT) (test.TestingT, bool, string) { return check.Compare(t, got.Name, "bob") },
+//		func(t test.TestingT) (test.TestingT, bool, string) { return check.ZeroValue(t, got.Age) },
+//	)
+func AssertAllChecks(t TestingT, checks ...func(t TestingT) (TestingT, bool, string)) bool {
+	t.Helper()
+
+	passed := true
+	opts := optionsOf(t)
+
+	for i, check := range checks {
+		name := caseName(t)
+
+		result, msg := runCheck(t, opts, check)
+		if msg == "" {
+			msg = "<no message>"
+		}
+
+		if !result || opts.successMessages {
+			var expression string
+			if opts.sourceAnalysis {
+				expression, _ = message.ExpressionArg(1, i+1)
+			}
+
+			msg = formatResult(t, opts, AssertionResult{
+				Passed:      result,
+				Case:        name,
+				Expression:  expression,
+				Description: fmt.Sprintf("check #%d: %s", i+1, msg),
+			})
+
+			if result {
+				logSuccess(t, msg)
+			} else {
+				recordFailure(t, opts, 1, i+1, msg, nil)
+			}
+		}
+
+		passed = passed && result
+	}
+
+	if !passed {
+		failAssertion(t, nil)
+	}
+
+	return passed
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_AssertAll(t *testing.T) {
+	t.Run("all true", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 42, 42
+
+		if result := AssertAll(spiedT, got == want, got > 0); !result {
+			t.Error("AssertAll should return true when all conditions are true")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("helper chain", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake(), double.SpyWithHelperChainRecording())
+		AssertAll(spiedT, true, false)
+		spiedT.ExpectHelperChain(t, 4)
+	})
+
+	t.Run("some false", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 42, 21
+
+		if result := AssertAll(spiedT, got == want, got > 0, got < want); result {
+			t.Error("AssertAll should return false when a condition is false")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false,
+			double.SpyTestingTRecord{Method: "Logf", Inputs: []any{"Error: %s", []any{"got is not equal to want"}}},
+			double.SpyTestingTRecord{Method: "Logf", Inputs: []any{"Error: %s", []any{"got is not lower than want"}}},
+			double.SpyTestingTRecord{Method: "Fail"},
+		)
+	})
+}
+
+func Test_AssertAllChecks(t *testing.T) {
+	passing := func(t TestingT) (TestingT, bool, string) { return t, true, "passed" }
+	failing := func(t TestingT) (TestingT, bool, string) { return t, false, "boom" }
+
+	t.Run("all passed", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if result := AssertAllChecks(spiedT, passing, passing); !result {
+			t.Error("AssertAllChecks should return true when all checks passed")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("some failed", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if result := AssertAllChecks(spiedT, failing, passing, failing); result {
+			t.Error("AssertAllChecks should return false when a check failed")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false,
+			double.SpyTestingTRecord{Method: "Logf", Inputs: []any{"Error: %s", []any{"check #1: boom"}}},
+			double.SpyTestingTRecord{Method: "Logf", Inputs: []any{"Error: %s", []any{"check #3: boom"}}},
+			double.SpyTestingTRecord{Method: "Fail"},
+		)
+	})
+}
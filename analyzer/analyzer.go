@@ -0,0 +1,18 @@
+// Package analyzer provides static analysis rules for code using krostar/test.
+//
+// Rules are exposed as analysis.Analyzer, which makes them usable with
+// any driver of the golang.org/x/tools/go/analysis framework,
+// like the krostar-test-analyzer command, or go vet -vettool.
+package analyzer
+
+import (
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzers returns all the analyzers provided by this package reporting issues.
+// CaptureValues is not part of them, as it is a code generation step.
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		ReflectDeepEqualBan,
+	}
+}
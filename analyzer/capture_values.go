@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// CaptureValues reports assertions whose compared operands are not attached with test.Values,
+// and suggests a fix attaching them.
+//
+// The message engine describes failed expressions from their source, but cannot know the runtime values
+// of their operands. Once attached, values are displayed alongside the message, and written to replay files.
+// Only operands that can safely be evaluated twice, like variables, fields, indexes, and lengths, are captured.
+// As values are evaluated before the assertion, whatever the result of the condition, the operands guarded by
+// the left operand of a && or || operator, like u.Age in u != nil && u.Age == 42, are not captured.
+//
+//	test.Assert(t, user.Age == 42)                          // reported
+//	test.Assert(t, user.Age == 42, test.Values(user.Age))   // suggested fix
+//
+// As it reports most assertions, it is not part of Analyzers: it is meant to be run with its fixes applied,
+// as a code generation step, see the krostar-test-capture command.
+var CaptureValues = &analysis.Analyzer{ //nolint:gochecknoglobals // analyzers are meant to be global
+	Name: "capturevalues",
+	Doc:  "reports assertions not attaching the values of their operands, and suggests to attach them with test.Values",
+	URL:  "https://pkg.go.dev/github.com/krostar/test/analyzer#CaptureValues",
+	Run:  runCaptureValues,
+}
+
+func runCaptureValues(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || !isFunc(pass, call, testPackagePath, "Assert", "Require", "Warn", "Refute", "Check") || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+				return true
+			}
+
+			for _, arg := range call.Args[2:] {
+				if values, ok := ast.Unparen(arg).(*ast.CallExpr); ok && isFunc(pass, values, testPackagePath, "Values") {
+					return true
+				}
+			}
+
+			operands := capturableOperands(pass, call.Args[1], nil)
+			if len(operands) == 0 {
+				return true
+			}
+
+			last := call.Args[len(call.Args)-1]
+			pass.Report(analysis.Diagnostic{
+				Pos:     call.Args[1].Pos(),
+				End:     call.Args[1].End(),
+				Message: "values of " + strings.Join(operands, ", ") + " are not attached to the assertion",
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message: "Attach the values with test.Values",
+					TextEdits: []analysis.TextEdit{{
+						Pos:     last.End(),
+						End:     last.End(),
+						NewText: []byte(", " + valuesFuncName(call) + "(" + strings.Join(operands, ", ") + ")"),
+					}},
+				}},
+			})
+
+			return true
+		})
+	}
+
+	return nil, nil //nolint:nilnil // analyzer has no result
+}
+
+// capturableOperands appends to `operands` the source of the capturable operands of the comparisons of the condition,
+// looking through negations and the left operand of boolean operators, the right one being only evaluated
+// depending on the left one. Operands already listed are not appended again.
+func capturableOperands(pass *analysis.Pass, cond ast.Expr, operands []string) []string {
+	switch expr := ast.Unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		if expr.Op == token.NOT {
+			return capturableOperands(pass, expr.X, operands)
+		}
+	case *ast.BinaryExpr:
+		switch expr.Op { //nolint:exhaustive // other operators do not produce booleans
+		case token.LAND, token.LOR:
+			return capturableOperands(pass, expr.X, operands)
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			for _, operand := range []ast.Expr{expr.X, expr.Y} {
+				if !isCapturable(pass, operand) || isConstant(pass, operand) {
+					continue
+				}
+
+				var buf bytes.Buffer
+				if err := format.Node(&buf, pass.Fset, ast.Unparen(operand)); err == nil && !slices.Contains(operands, buf.String()) {
+					operands = append(operands, buf.String())
+				}
+			}
+		}
+	}
+
+	return operands
+}
+
+// isCapturable returns whether the expression can be evaluated again without side effects:
+// variables, fields, indexes and dereferences of such expressions, and their lengths and capacities.
+func isCapturable(pass *analysis.Pass, expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		_, ok := pass.TypesInfo.Uses[expr].(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		selection, ok := pass.TypesInfo.Selections[expr]
+		return ok && selection.Kind() == types.FieldVal && isCapturable(pass, expr.X)
+	case *ast.IndexExpr:
+		return isCapturable(pass, expr.X) && (isConstant(pass, expr.Index) || isCapturable(pass, expr.Index))
+	case *ast.StarExpr:
+		return isCapturable(pass, expr.X)
+	case *ast.CallExpr:
+		fun, ok := ast.Unparen(expr.Fun).(*ast.Ident)
+		if !ok || (fun.Name != "len" && fun.Name != "cap") || len(expr.Args) != 1 {
+			return false
+		}
+		_, isBuiltin := pass.TypesInfo.Uses[fun].(*types.Builtin)
+		return isBuiltin && isCapturable(pass, expr.Args[0])
+	default:
+		return false
+	}
+}
+
+// isConstant returns whether the expression is a constant, or nil, whose value is already known from its source.
+func isConstant(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && (tv.Value != nil || tv.IsNil())
+}
+
+// valuesFuncName returns how to refer to the Values function from the assertion call,
+// using the same qualifier as the assertion function.
+func valuesFuncName(call *ast.CallExpr) string {
+	fun := call.Fun
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+
+	if sel, ok := fun.(*ast.SelectorExpr); ok {
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			return pkg.Name + ".Values"
+		}
+	}
+
+	return "Values"
+}
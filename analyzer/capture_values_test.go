@@ -0,0 +1,11 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test_CaptureValues(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), CaptureValues, "c")
+}
@@ -0,0 +1,225 @@
+package analyzer
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	testPackagePath  = "github.com/krostar/test"
+	checkPackagePath = "github.com/krostar/test/check"
+)
+
+// ReflectDeepEqualBan reports reflect.DeepEqual calls used as the result of an assertion.
+//
+// reflect.DeepEqual has surprising semantics (a nil slice is not equal to an empty slice, unexported fields
+// are compared, ...) that lead to subtle false results, and as it returns a boolean, the assertion message
+// cannot explain what differs. check.Compare should be used instead, and a fix is suggested when possible.
+//
+//	test.Assert(t, reflect.DeepEqual(got, want))  // reported
+//	test.Assert(check.Compare(t, got, want))      // suggested fix
+var ReflectDeepEqualBan = &analysis.Analyzer{ //nolint:gochecknoglobals // analyzers are meant to be global
+	Name: "reflectdeepequalban",
+	Doc:  "reports reflect.DeepEqual used in assertions, and suggests to use check.Compare instead",
+	URL:  "https://pkg.go.dev/github.com/krostar/test/analyzer#ReflectDeepEqualBan",
+	Run:  runReflectDeepEqualBan,
+}
+
+func runReflectDeepEqualBan(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || !isFunc(pass, call, testPackagePath, "Assert", "Require", "Warn") || len(call.Args) < 2 {
+				return true
+			}
+
+			deepEqual, ok := ast.Unparen(call.Args[1]).(*ast.CallExpr)
+			if !ok || !isFunc(pass, deepEqual, "reflect", "DeepEqual") || len(deepEqual.Args) != 2 {
+				return true
+			}
+
+			diagnostic := analysis.Diagnostic{
+				Pos:     deepEqual.Pos(),
+				End:     deepEqual.End(),
+				Message: "reflect.DeepEqual should not be used in assertions as its failures cannot be explained, use check.Compare instead",
+			}
+
+			if fix, ok := compareSuggestedFix(pass, file, call, deepEqual); ok {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+
+			pass.Report(diagnostic)
+
+			return true
+		})
+	}
+
+	return nil, nil //nolint:nilnil // analyzer has no result
+}
+
+// compareSuggestedFix returns the fix replacing `assert(t, reflect.DeepEqual(a, b))` by `assert(check.Compare(t, a, b))`.
+// The fix is only possible if the assertion has no message, and if both compared values have the same type.
+func compareSuggestedFix(pass *analysis.Pass, file *ast.File, assert, deepEqual *ast.CallExpr) (analysis.SuggestedFix, bool) {
+	if len(assert.Args) != 2 {
+		return analysis.SuggestedFix{}, false
+	}
+
+	if a, b := pass.TypesInfo.TypeOf(deepEqual.Args[0]), pass.TypesInfo.TypeOf(deepEqual.Args[1]); a == nil || b == nil || !types.Identical(a, b) {
+		return analysis.SuggestedFix{}, false
+	}
+
+	checkName, importEdits := importCheckPackage(file)
+	importEdits = append(importEdits, removeSoleImportUse(pass, file, deepEqual)...)
+
+	var buf bytes.Buffer
+	buf.WriteString(checkName + ".Compare(")
+	for i, arg := range []ast.Expr{assert.Args[0], deepEqual.Args[0], deepEqual.Args[1]} {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if err := format.Node(&buf, pass.Fset, arg); err != nil {
+			return analysis.SuggestedFix{}, false
+		}
+	}
+	buf.WriteString(")")
+
+	return analysis.SuggestedFix{
+		Message: "Replace reflect.DeepEqual by check.Compare",
+		TextEdits: append(importEdits, analysis.TextEdit{
+			Pos:     assert.Args[0].Pos(),
+			End:     assert.Args[1].End(),
+			NewText: buf.Bytes(),
+		}),
+	}, true
+}
+
+// importCheckPackage returns the name to use to refer to the check package in the file,
+// and the edits required to import it if it is not already imported.
+func importCheckPackage(file *ast.File) (string, []analysis.TextEdit) {
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err != nil || path != checkPackagePath {
+			continue
+		}
+
+		switch {
+		case spec.Name == nil:
+			return "check", nil
+		case spec.Name.Name != "_" && spec.Name.Name != ".":
+			return spec.Name.Name, nil
+		}
+	}
+
+	importLine := strconv.Quote(checkPackagePath)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() {
+			continue
+		}
+
+		if len(gen.Specs) == 0 {
+			return "check", []analysis.TextEdit{{Pos: gen.Lparen + 1, End: gen.Lparen + 1, NewText: []byte("\n\t" + importLine)}}
+		}
+
+		// the package is imported after the last third-party import, or in a new group after the standard library ones
+		pos, prefix := gen.Specs[len(gen.Specs)-1].End(), "\n\n\t"
+		for _, spec := range gen.Specs {
+			if path, err := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value); err == nil && !isStandardImportPath(path) {
+				pos, prefix = spec.End(), "\n\t"
+			}
+		}
+
+		return "check", []analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte(prefix + importLine)}}
+	}
+
+	return "check", []analysis.TextEdit{{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + importLine)}}
+}
+
+// removeSoleImportUse returns the edits removing the import of the package of the called function,
+// if the call is its only use in the file, as the import becomes unused once the call is replaced.
+func removeSoleImportUse(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) []analysis.TextEdit {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	qualifier, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	pkgName, ok := pass.TypesInfo.Uses[qualifier].(*types.PkgName)
+	if !ok {
+		return nil
+	}
+
+	uses := 0
+	for _, obj := range pass.TypesInfo.Uses {
+		if obj == pkgName && obj.Pos().IsValid() {
+			uses++
+		}
+	}
+	if uses != 1 {
+		return nil
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			if pass.TypesInfo.PkgNameOf(spec.(*ast.ImportSpec)) != pkgName {
+				continue
+			}
+
+			if !gen.Lparen.IsValid() {
+				return []analysis.TextEdit{{Pos: gen.Pos(), End: gen.End()}}
+			}
+
+			// the whole line of the import is removed, not to leave an empty line in the group
+			tokFile := pass.Fset.File(spec.Pos())
+			start := tokFile.LineStart(tokFile.Line(spec.Pos()))
+			end := spec.End()
+			if line := tokFile.Line(end); line < tokFile.LineCount() {
+				end = tokFile.LineStart(line + 1)
+			}
+
+			return []analysis.TextEdit{{Pos: start, End: end}}
+		}
+	}
+
+	return nil
+}
+
+// isStandardImportPath returns whether the import path is the one of a package of the standard library,
+// whose first path element, unlike the ones of other packages, does not contain a dot.
+func isStandardImportPath(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// isFunc returns whether the call is a call to one of the provided functions of the package.
+func isFunc(pass *analysis.Pass, call *ast.CallExpr, pkgPath string, names ...string) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath {
+		return false
+	}
+
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
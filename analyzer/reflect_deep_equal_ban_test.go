@@ -0,0 +1,61 @@
+package analyzer
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test_ReflectDeepEqualBan(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), ReflectDeepEqualBan, "a", "b", "d")
+
+	// RunWithSuggestedFixes removes unused imports before comparing the fixed files with the golden ones,
+	// fixes are applied as is here, for the golden files to also ensure the fixes remove the imports they make unused
+	t.Run("imports", func(t *testing.T) {
+		for _, pkg := range []string{"b", "d"} {
+			results := analysistest.Run(t, analysistest.TestData(), ReflectDeepEqualBan, pkg)
+
+			for _, result := range results {
+				var edits []analysis.TextEdit
+				for _, diagnostic := range result.Diagnostics {
+					for _, fix := range diagnostic.SuggestedFixes {
+						edits = append(edits, fix.TextEdits...)
+					}
+				}
+
+				filename := filepath.Join(analysistest.TestData(), "src", pkg, pkg+".go")
+				tokFile := result.Pass.Fset.File(result.Pass.Files[0].Pos())
+
+				fixed, err := os.ReadFile(filename)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				slices.SortFunc(edits, func(a, b analysis.TextEdit) int { return int(b.Pos - a.Pos) })
+				for _, edit := range edits {
+					start, end := tokFile.Offset(edit.Pos), tokFile.Offset(edit.End)
+					fixed = slices.Concat(fixed[:start], edit.NewText, fixed[end:])
+				}
+
+				if fixed, err = format.Source(fixed); err != nil {
+					t.Fatalf("%s: unable to format fixed source: %v", pkg, err)
+				}
+
+				golden, err := os.ReadFile(filename + ".golden")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(fixed, golden) {
+					t.Errorf("%s: fixed source differs from golden file:\n%s", pkg, fixed)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package a
+
+import (
+	"reflect"
+
+	"github.com/krostar/test"
+)
+
+func assertions(t test.TestingT, got, want []string, other []int) {
+	test.Assert(t, reflect.DeepEqual(got, want))     // want "reflect.DeepEqual should not be used in assertions"
+	test.Require(t, (reflect.DeepEqual(got, want)))  // want "reflect.DeepEqual should not be used in assertions"
+	test.Warn(t, reflect.DeepEqual(got, want), "hi") // want "reflect.DeepEqual should not be used in assertions"
+	test.Assert(t, reflect.DeepEqual(got, other))    // want "reflect.DeepEqual should not be used in assertions"
+	test.Assert(t, !reflect.DeepEqual(got, want))
+	_ = reflect.DeepEqual(got, want)
+}
@@ -0,0 +1,14 @@
+package b
+
+import (
+	"reflect"
+
+	"github.com/krostar/test"
+	assertcheck "github.com/krostar/test/check"
+)
+
+var _ = assertcheck.Compare[int]
+
+func assertions(t test.TestingT, got, want map[string]int) {
+	test.Assert(t, reflect.DeepEqual(got, want)) // want "reflect.DeepEqual should not be used in assertions"
+}
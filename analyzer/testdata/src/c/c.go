@@ -0,0 +1,30 @@
+package c
+
+import (
+	"github.com/krostar/test"
+)
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func (u user) Adult() bool { return u.Age >= 18 }
+
+const majority = 18
+
+func assertions(t test.TestingT, u *user, got, want []int, err error) {
+	test.Assert(t, u.Age == 42)                                 // want "values of u.Age are not attached to the assertion"
+	test.Require(t, got[0] != want[0] && (len(got) == 1), "hi") // want "values of got\\[0\\], want\\[0\\] are not attached to the assertion"
+	test.Refute(t, !(u.Age < majority) || u.Age > 99)           // want "values of u.Age are not attached to the assertion"
+	test.Check(t, *u == user{Name: "bob"},                      // want "values of \\*u are not attached to the assertion"
+		"multiline",
+	)
+	test.Assert(t, u.Age == 42, test.Values(u.Age))
+	test.Assert(t, err == nil) // want "values of err are not attached to the assertion"
+	test.Assert(t, u.Adult())
+	test.Assert(t, len(got) == 2)               // want "values of len\\(got\\) are not attached to the assertion"
+	test.Warn(t, u.Name == "bob")               // want "values of u.Name are not attached to the assertion"
+	test.Assert(t, u != nil && u.Age == 42)     // want "values of u are not attached to the assertion"
+	test.Assert(t, len(got) > 0 && got[0] == 1) // want "values of len\\(got\\) are not attached to the assertion"
+}
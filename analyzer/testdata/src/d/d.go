@@ -0,0 +1,13 @@
+package d
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/krostar/test"
+)
+
+func assertions(t test.TestingT, got, want []string) {
+	test.Assert(t, reflect.DeepEqual(got, want)) // want "reflect.DeepEqual should not be used in assertions"
+	test.Assert(t, strings.Join(got, "") != "")
+}
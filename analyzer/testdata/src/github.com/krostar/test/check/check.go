@@ -0,0 +1,5 @@
+package check
+
+import "github.com/krostar/test"
+
+func Compare[T any](t test.TestingT, got, want T) (test.TestingT, bool, string) { return t, true, "" }
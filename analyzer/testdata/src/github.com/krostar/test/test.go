@@ -0,0 +1,17 @@
+package test
+
+type TestingT interface{}
+
+func Assert(t TestingT, result bool, msgAndArgs ...any) bool { return result }
+
+func Require(t TestingT, result bool, msgAndArgs ...any) {}
+
+func Warn(t TestingT, result bool, msgAndArgs ...any) bool { return result }
+
+func Refute(t TestingT, result bool, msgAndArgs ...any) bool { return !result }
+
+func Check(t TestingT, result bool, msgAndArgs ...any) (bool, string) { return result, "" }
+
+type RuntimeValues struct{}
+
+func Values(values ...any) RuntimeValues { return RuntimeValues{} }
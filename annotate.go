@@ -0,0 +1,72 @@
+package test
+
+import (
+	"fmt"
+	"slices"
+)
+
+// annotation is a context registered with Annotate.
+type annotation struct {
+	id   uint
+	text string
+}
+
+// Annotate registers a context appended to the messages of the assertions made on t, until the returned function is called.
+//
+// It is meant to be used by helpers calling user-provided functions, so that assertions made inside those functions
+// carry the context of the helper, like the name of the case or the attempt number, which is otherwise lost
+// as messages only refer to the line of the assertion.
+// Annotations are appended in the order they were registered.
+//
+// Example:
+//
+//	func RunCases[C any](t *testing.T, cases map[string]C, body func(t *testing.T, c C)) {
+//		for name, c := range cases {
+//			remove := test.Annotate(t, "case %q", name)
+//			body(t, c)
+//			remove()
+//		}
+//	}
+//
+// -> Error: got is not equal to want (case "empty input")
+func Annotate(t TestingT, format string, args ...any) func() {
+	t.Helper()
+
+	state := stateOf(t)
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	state.annotationNextID++
+	id := state.annotationNextID
+	state.annotations = append(state.annotations, annotation{id: id, text: fmt.Sprintf(format, args...)})
+
+	return func() {
+		state.m.Lock()
+		defer state.m.Unlock()
+
+		state.annotations = slices.DeleteFunc(state.annotations, func(a annotation) bool { return a.id == id })
+	}
+}
+
+// annotationTexts returns the texts of the annotations registered on t, in the order they were registered.
+func annotationTexts(t TestingT) []string {
+	state, ok := lookupState(t)
+	if !ok {
+		return nil
+	}
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	if len(state.annotations) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(state.annotations))
+	for i, a := range state.annotations {
+		texts[i] = a.text
+	}
+
+	return texts
+}
@@ -0,0 +1,34 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Annotate(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+
+	removeCase := Annotate(spiedT, "case %q", "empty")
+	removeAttempt := Annotate(spiedT, "attempt %d", 3)
+
+	Assert(spiedT, false)
+	spiedT.ExpectLogsToContain(t, `(case "empty", attempt 3)`)
+
+	removeCase()
+	Assert(spiedT, false, "hello")
+	spiedT.ExpectLogsToContain(t, "[hello] (attempt 3)")
+
+	removeAttempt()
+	removeAttempt() // removing twice is harmless
+
+	if a := annotationTexts(spiedT); a != nil {
+		t.Errorf("expected no more annotations, got %v", a)
+	}
+}
+
+func Test_annotationTexts(t *testing.T) {
+	if a := annotationTexts(double.NewFake()); a != nil {
+		t.Errorf("expected no annotations for test without state, got %v", a)
+	}
+}
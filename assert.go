@@ -6,7 +6,7 @@ package test
 
 import (
 	"flag"
-	"fmt"
+	"reflect"
 
 	"github.com/krostar/test/internal"
 	"github.com/krostar/test/internal/message"
@@ -16,16 +16,27 @@ import (
 // It mimics the standard library's *testing.T.
 type TestingT internal.TestingT
 
+// Result is what Assert and Require check: either a boolean,
+// or a check evaluated by the assertion itself, returning its result and the message describing it.
+type Result interface {
+	~bool | func(t TestingT) (bool, string)
+}
+
 // Assert checks the provided boolean `result`.
 //
 // If `result` is false, it logs a detailed error message based on source code parsing
 // and fails the test. The error message includes the expression that evaluated to false.
 //
+// `result` can also be a check, a function returning a result along with its message, which is only evaluated,
+// and only builds its message, when Assert runs it; the message is used as the description of the assertion.
+// Checks taking too long can be aborted, see WithCheckTimeout.
+//
 // Optionally, `msgAndArgs` can be provided to add custom messages to the error output.
 //
 // If check.SuccessMessageEnabled is true, it will log a success message even if `result` is true.
+// Options, like WithSuccessMessages, can be provided along with `msgAndArgs` to configure this assertion only.
 //
-// Assert returns the same value as `result`.
+// Assert returns the same value as `result`, or the result of the check.
 //
 // Example usage:
 //
@@ -33,83 +44,335 @@ type TestingT internal.TestingT
 //		user, err := GetUser(context.Background(), "bob@example.com")
 //		test.Require(t, err == nil && user != nil)
 //		test.Assert(t, user.Name == "Bob" && user.Age == 42)
+//		test.Assert(t, func(t test.TestingT) (bool, string) {
+//			return user.Active(), "user should be active"
+//		})
 //	}
 //
 // -> Error: user.Name is not equal to "Bob", or user.Age is not equal to 42.
-func Assert(t TestingT, result bool, msgAndArgs ...any) bool {
+func Assert[R Result](t TestingT, result R, msgAndArgs ...any) bool {
 	t.Helper()
 
-	logResult(t, result, 1, msgAndArgs...)
+	passed := logAssertion(t, result, false, msgAndArgs)
 
-	if !result {
-		t.Fail()
+	if !passed {
+		failAssertion(t, msgAndArgs)
 	}
 
-	return result
+	return passed
 }
 
 // Require stops the test execution immediately if `result` is false.
 // Otherwise, it behaves the same as Assert.
-func Require(t TestingT, result bool, msgAndArgs ...any) {
+func Require[R Result](t TestingT, result R, msgAndArgs ...any) {
 	t.Helper()
 
-	logResult(t, result, 1, msgAndArgs...)
-
-	if !result {
+	if !logAssertion(t, result, false, msgAndArgs) {
 		t.FailNow()
 	}
 }
 
+// Refute is the negated counterpart of Assert: it fails the test if `result` is true.
+//
+// Unlike Assert(t, !cond), whose messages can turn into double negatives, the message of a failing Refute
+// describes what `result` unexpectedly is, and states that the opposite was expected.
+//
+// `result` can also be a check, like with Assert, in which case the test fails if the check passes.
+//
+// Refute returns true if `result` is false.
+//
+// Example:
+//
+//	test.Refute(t, strings.Contains(body, "password"))
+//
+// -> Error: body contains "password", while it was expected not to
+func Refute[R Result](t TestingT, result R, msgAndArgs ...any) bool {
+	t.Helper()
+
+	passed := logAssertion(t, result, true, msgAndArgs)
+
+	if !passed {
+		failAssertion(t, msgAndArgs)
+	}
+
+	return passed
+}
+
+// logAssertion evaluates the result of the Assert-like call of the caller, logs it, and returns whether it passed.
+// Results that are checks are evaluated, and described by their own message.
+// If `refuted` is true, the assertion passes if the result is false, like for Refute.
+func logAssertion[R Result](t TestingT, result R, refuted bool, msgAndArgs []any) bool {
+	t.Helper()
+
+	check, ok := any(result).(func(t TestingT) (bool, string))
+	if !ok {
+		value := reflect.ValueOf(result).Bool()
+		if !refuted {
+			logResult(t, value, 2, -1, msgAndArgs...)
+			return value
+		}
+
+		passed := !value
+		opts := optionsOf(t, callOptions(msgAndArgs)...)
+
+		logDescribedResult(t, opts, passed, 2, 1, func(func(int, string) string) string {
+			if !opts.sourceAnalysis {
+				return genericDescription(passed)
+			}
+
+			msg, err := message.FromBoolArg(4, 1, value)
+			if err != nil {
+				t.Logf("krostar/test internal failure: unable to get assertion message: %v", err)
+			}
+			if msg == "" {
+				return genericDescription(passed)
+			}
+			return refutedDescription(msg, passed)
+		}, 2, msgAndArgs...)
+
+		return passed
+	}
+
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+
+	passed, msg := runCheck(t, opts, func(t TestingT) (TestingT, bool, string) {
+		passed, msg := check(t)
+		return t, passed, msg
+	})
+	if msg == "" {
+		msg = "<no message>"
+	}
+	if refuted {
+		passed = !passed
+		msg = refutedDescription(msg, passed)
+	}
+
+	logDescribedResult(t, opts, passed, 2, 1, func(func(int, string) string) string { return msg }, 2, msgAndArgs...)
+
+	return passed
+}
+
+// refutedDescription returns the description of a refuted result, made from the description of the result itself.
+func refutedDescription(description string, passed bool) string {
+	if passed {
+		return description
+	}
+	return description + ", while it was expected not to"
+}
+
 //nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
 var (
 	// SuccessMessageEnabled controls whether to enable success messages logging in assert functions.
+	//
+	// Deprecated: use Configure with WithSuccessMessages, or the KROSTAR_TEST_SUCCESS_MESSAGES environment variable.
 	SuccessMessageEnabled     = false
 	_flagEnableSuccessMessage = flag.Bool("check.display-success-messages", false, "Whether to print messages in passing tests")
 )
 
+// Warn behaves like Assert, but never fails the test.
+//
+// If `result` is false, it logs a warning message built the same way Assert builds its error message.
+// It is useful to gradually introduce new invariants into existing test suites without breaking them,
+// before replacing the Warn call by an Assert call.
+//
+// Warn returns the same value as `result`.
+func Warn(t TestingT, result bool, msgAndArgs ...any) bool {
+	t.Helper()
+
+	msg, _ := resultMessage(t, result, 1, -1, msgAndArgs...)
+
+	switch {
+	case !result:
+		t.Logf("Warning: %s", colorizeMessage(optionsOf(t, callOptions(msgAndArgs)...).color, msg))
+	case msg != "":
+		t.Logf("Success: %s", msg)
+	}
+
+	return result
+}
+
+// failAssertion marks the test as failed, and stops it if the assertion fails fast, see WithFailFast.
+func failAssertion(t TestingT, msgAndArgs []any) {
+	t.Helper()
+
+	failAssertionWith(t, optionsOf(t, callOptions(msgAndArgs)...))
+}
+
+// failAssertionWith behaves like failAssertion, for assertions whose options are already resolved.
+func failAssertionWith(t TestingT, opts options) {
+	t.Helper()
+
+	if opts.failFast {
+		t.FailNow()
+		return
+	}
+
+	t.Fail()
+}
+
+// Check evaluates the provided boolean `result` like Assert does, but never logs nor fails the test.
+//
+// It returns `result` along with the message Assert would have logged, whether `result` is true or false.
+// It is useful for code making its own decision about the result, like retry loops or diagnostics.
+//
+// Example:
+//
+//	for range 3 {
+//		if ok, msg := test.Check(t, isReady(srv)); !ok {
+//			t.Logf("not ready yet: %s", msg)
+//			continue
+//		}
+//	}
+func Check(t TestingT, result bool, msgAndArgs ...any) (bool, string) {
+	t.Helper()
+
+	// messages are always built, as the caller asked for it
+	msg, _ := resultMessage(t, result, 1, -1, append(msgAndArgs, WithSuccessMessages())...)
+	return result, msg
+}
+
 // logResult handles the logging of test results, with details about the assertion.
 // It's used internally by Assert and Require functions.
+// It logs the message produced by resultMessage as either a success or error message,
+// and records the assertion and its failure, see recordAssertion and recordFailure.
+// `argIndex` is the position of the argument of the assertion call holding the result,
+// or is negative for the asserted argument of Assert-like calls.
+func logResult(t TestingT, result bool, callerStackIndex, argIndex int, msgAndArgs ...any) {
+	t.Helper()
+
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+	recordAssertion(t, opts, callerStackIndex+1, result)
+
+	msg, values := resultMessage(t, result, callerStackIndex+1, argIndex, msgAndArgs...)
+
+	if result {
+		logSuccess(t, msg)
+	} else {
+		recordFailure(t, opts, callerStackIndex+1, argIndex, msg, values)
+	}
+}
+
+// logDescribedResult logs the result of an assertion made by the caller, like Equal, whose description is built by `describe`.
+// `argIndex` is the position of the asserted argument of the assertion call.
+// `describe` is given a function returning the source of the argument at the provided position of the assertion call,
+// or the provided fallback if it is not available.
+// `msgAndArgs` are the custom message and values provided to the assertion, starting at position `msgArgIndex` of the call,
+// and `opts` are the options of the assertion, resolved by the caller, see optionsOf.
+func logDescribedResult(t TestingT, opts options, passed bool, callerStackIndex, argIndex int, describe func(argExpr func(int, string) string) string, msgArgIndex int, msgAndArgs ...any) {
+	t.Helper()
+
+	recordAssertion(t, opts, callerStackIndex+1, passed)
+	message.RecordCallSite(callerStackIndex + 1)
+
+	result := AssertionResult{Passed: passed, Case: caseName(t)}
+
+	if passed && !opts.successMessages {
+		return
+	}
+
+	result.Description = describe(func(argIndex int, fallback string) string {
+		if !opts.sourceAnalysis {
+			return fallback
+		}
+
+		expr, err := message.ExpressionArg(callerStackIndex+3, argIndex)
+		if err != nil {
+			return fallback
+		}
+		return expr
+	})
+	if opts.sourceAnalysis {
+		result.Expression, _ = message.ExpressionArg(callerStackIndex+1, argIndex)
+	}
+	msgAndArgs, result.Values = extractValues(callerStackIndex+1, msgArgIndex, msgAndArgs, opts.sourceAnalysis)
+	result.Message = customMessage(msgAndArgs)
+
+	msg := formatResult(t, opts, result)
+
+	if passed {
+		logSuccess(t, msg)
+	} else {
+		recordFailure(t, opts, callerStackIndex+1, argIndex, msg, result.Values)
+	}
+}
+
+// logSuccess logs the message of a passing assertion, if not empty.
+// Messages of failed assertions are logged by recordFailure.
+func logSuccess(t TestingT, msg string) {
+	t.Helper()
+
+	if msg != "" {
+		t.Logf("Success: %s", msg)
+	}
+}
+
+// resultMessage builds the message describing the assertion result, and returns it along with the rendered values
+// attached to the assertion. It returns an empty string if the assertion passed and success messages are disabled.
 //
 // The function performs several tasks:
 //   - Retrieves the source code expression that was evaluated from the caller's location
 //   - Formats an appropriate message explaining what passed or failed
-//   - Adds any custom messages provided by the caller
-//   - Logs the resulting message as either a success or error message
-func logResult(t TestingT, result bool, callerStackIndex int, msgAndArgs ...any) {
+//   - Adds the values attached with Values, and any custom messages provided by the caller
+//   - Adds the name of the subtest and the index of the assertion, for subtests (see caseName)
+//   - Adds the annotations registered with Annotate
+//   - Renders all of it with the formatter of the assertion, see optionsOf
+func resultMessage(t TestingT, passed bool, callerStackIndex, argIndex int, msgAndArgs ...any) (string, []NamedValue) {
 	t.Helper()
 
+	// position of the first element of msgAndArgs in the arguments of the assertion call
+	msgArgIndex := argIndex + 1
+	if argIndex < 0 {
+		msgArgIndex = 2
+	}
+
 	// function that perform checks can return empty strings, don't display them
 	if len(msgAndArgs) > 0 && msgAndArgs[0] == "" {
 		msgAndArgs = msgAndArgs[1:]
+		msgArgIndex++
 	}
 
-	var msg string
+	message.RecordCallSite(callerStackIndex + 1)
+
+	result := AssertionResult{Passed: passed, Case: caseName(t)}
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+
+	if passed && !opts.successMessages {
+		return "", nil
+	}
+
+	msgAndArgs, result.Values = extractValues(callerStackIndex+1, msgArgIndex, msgAndArgs, opts.sourceAnalysis)
+	result.Message = customMessage(msgAndArgs)
+
+	if opts.sourceAnalysis {
+		// values named after expressions of the asserted argument make its description more precise
+		values := make(map[string]any, len(result.Values))
+		for _, value := range result.Values {
+			values[value.Name] = value.Value
+		}
 
-	if (result && (SuccessMessageEnabled || *_flagEnableSuccessMessage)) || !result {
 		var err error
 
-		msg, err = message.FromBool(callerStackIndex+1, result)
+		result.Description, err = message.FromBoolArgWithValues(callerStackIndex+1, argIndex, passed, values)
 		if err != nil {
 			t.Logf("krostar/test internal failure: unable to get assertion message: %v", err)
 		}
-
-		switch l := len(msgAndArgs); {
-		case l == 1:
-			msg = fmt.Sprintf("%s [%v]", msg, msgAndArgs[0])
-		case l > 1:
-			if format, ok := msgAndArgs[0].(string); ok {
-				msg = fmt.Sprintf("%s [%s]", msg, fmt.Sprintf(format, msgAndArgs[1:]...))
-			} else {
-				msg = fmt.Sprintf("%s %v", msg, msgAndArgs)
-			}
+		if result.Description == "" {
+			result.Description = genericDescription(passed)
 		}
+
+		result.Expression, _ = message.ExpressionArg(callerStackIndex+1, argIndex)
+	} else {
+		result.Description = genericDescription(passed)
 	}
 
-	if msg != "" {
-		if result {
-			t.Logf("Success: %s", msg)
-		} else {
-			t.Logf("Error: %s", msg)
-		}
+	// values are rendered in place by formatResult
+	return formatResult(t, opts, result), result.Values
+}
+
+// genericDescription returns the description of an assertion whose source is not analyzed, see WithSourceAnalysis.
+func genericDescription(passed bool) string {
+	if passed {
+		return "assertion passed"
 	}
+	return "assertion failed"
 }
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/krostar/test/double"
@@ -43,6 +44,83 @@ func Test_Assert(t *testing.T) {
 		spiedT.ExpectTestToFail(t)
 		spiedT.ExpectLogsToContain(t, "Error:", "[hello from Test_Assert/assertion_false]")
 	})
+
+	t.Run("lazy check", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		var evaluated int
+		check := func(result bool) func(TestingT) (bool, string) {
+			return func(TestingT) (bool, string) {
+				evaluated++
+				return result, "the check failed"
+			}
+		}
+
+		if !Assert(spiedT, check(true)) || Assert(spiedT, check(false), "hello") {
+			t.Error("Assert should return the result of the check")
+		}
+		Require(spiedT, check(false))
+
+		if evaluated != 3 {
+			t.Errorf("expected each check to be evaluated once, got %d evaluations", evaluated)
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+		spiedT.ExpectLogsToContain(t, "Error: the check failed [hello]")
+	})
+
+	t.Run("helper chain", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake(), double.SpyWithHelperChainRecording())
+		Assert(spiedT, false)
+		spiedT.ExpectHelperChain(t, 6)
+	})
+
+	t.Run("several assertions on one line", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 1, 2
+
+		_, _ = Warn(spiedT, got == want), Assert(spiedT, got > want)
+		Assert(spiedT, Warn(spiedT, got >= want))
+
+		spiedT.ExpectLogsToContain(t, "Warning: got is not equal to want")
+		spiedT.ExpectLogsToContain(t, "Error: got is less than or equal to want")
+		spiedT.ExpectLogsToContain(t, "Warning: got is less than want")
+		spiedT.ExpectLogsToContain(t, "Error: function Warn(spiedT, got >= want) returned false")
+	})
+
+	t.Run("identical assertions on one line", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a, b := 1, 2
+
+		func() { Assert(spiedT, a == 1); Assert(spiedT, b == 1) }()
+		func() { Assert(spiedT, b == 2); Assert(spiedT, a == 2) }()
+
+		spiedT.ExpectLogsToContain(t, "Error: b is not equal to 1", "Error: a is not equal to 2")
+	})
+
+	t.Run("identical assertions on one line, the first one not executed", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a, b, skip := 1, 2, t.Name() != "" // not a constant, for the first call not to be compiled out
+
+		func() { _ = skip || Assert(spiedT, a == 1); Assert(spiedT, b == 1) }()
+
+		spiedT.ExpectLogsToContain(t, "Error: b is not equal to 1")
+	})
+
+	t.Run("subtest case prefix", func(t *testing.T) {
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(
+			double.FakeWithName("Test_Foo/case_a"),
+			double.FakeWithRegisterCleanup(func(f func()) { cleanup = f }),
+		))
+		Assert(spiedT, true)
+		Assert(spiedT, false)
+		cleanup()
+
+		spiedT.ExpectLogsToContain(t, "Error: [case_a #2] ")
+	})
 }
 
 func Test_Require(t *testing.T) {
@@ -76,6 +154,47 @@ func Test_Require(t *testing.T) {
 	})
 }
 
+func Test_Refute(t *testing.T) {
+	t.Run("assertion true", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		body := "hello"
+		if !Refute(spiedT, strings.Contains(body, "password"), "body of %s", "bob", WithSuccessMessages()) {
+			t.Error("Refute should return true when result is false")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectLogsToContain(t, `Success: body does not contain "password" [body of bob]`)
+	})
+
+	t.Run("assertion false", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		got, want := 1, 1
+		if Refute(spiedT, got == want, "hello") {
+			t.Error("Refute should return false when result is true")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: got is equal to want, while it was expected not to [hello]")
+	})
+
+	t.Run("check", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if Refute(spiedT, func(TestingT) (bool, string) { return true, "user is active" }) {
+			t.Error("Refute should return false when the check passes")
+		}
+
+		if !Refute(spiedT, func(TestingT) (bool, string) { return false, "user is not active" }, WithSuccessMessages()) {
+			t.Error("Refute should return true when the check fails")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: user is active, while it was expected not to", "Success: user is not active")
+	})
+}
+
 func Test_logResult(t *testing.T) {
 	t.Run("success without message", func(t *testing.T) {
 		originalSuccessMessageEnabled := SuccessMessageEnabled
@@ -84,7 +203,7 @@ func Test_logResult(t *testing.T) {
 		SuccessMessageEnabled = false
 
 		spiedT := double.NewSpy(double.NewFake())
-		logResult(spiedT, true, 0)
+		logResult(spiedT, true, 0, -1)
 		spiedT.ExpectNoLogs(t)
 	})
 
@@ -95,25 +214,75 @@ func Test_logResult(t *testing.T) {
 		SuccessMessageEnabled = true
 
 		spiedT := double.NewSpy(double.NewFake())
-		logResult(spiedT, true, 0, "custom %s with %d values", "message", 42)
+		logResult(spiedT, true, 0, -1, "custom %s with %d values", "message", 42)
 		spiedT.ExpectLogsToContain(t, "Success:", "custom message with 42 values")
 	})
 
 	t.Run("error with message", func(t *testing.T) {
 		spiedT := double.NewSpy(double.NewFake())
-		logResult(spiedT, false, 0, "failure reason")
+		logResult(spiedT, false, 0, -1, "failure reason")
 		spiedT.ExpectLogsToContain(t, "Error:", "failure reason")
 	})
 
 	t.Run("empty message is skipped", func(t *testing.T) {
 		spiedT := double.NewSpy(double.NewFake())
-		logResult(spiedT, false, 0, "", "%s", "hello")
+		logResult(spiedT, false, 0, -1, "", "%s", "hello")
 		spiedT.ExpectLogsToContain(t, "Error: literal false [hello]")
 	})
 
 	t.Run("first message is not a string", func(t *testing.T) {
 		spiedT := double.NewSpy(double.NewFake())
-		logResult(spiedT, false, 0, 42, "hello")
+		logResult(spiedT, false, 0, -1, 42, "hello")
 		spiedT.ExpectLogsToContain(t, "Error: literal false [42 hello]")
 	})
 }
+
+func Test_Warn(t *testing.T) {
+	t.Run("assertion true", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		if result := Warn(spiedT, true, "hello from %s", t.Name()); !result {
+			t.Error("Warn should return true when result is true")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("with success message enabled", func(t *testing.T) {
+		originalSuccessMessageEnabled := SuccessMessageEnabled
+		t.Cleanup(func() { SuccessMessageEnabled = originalSuccessMessageEnabled })
+
+		SuccessMessageEnabled = true
+
+		spiedT := double.NewSpy(double.NewFake())
+		Warn(spiedT, true, "hello from %s", t.Name())
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectLogsToContain(t, "Success:", "[hello from Test_Warn/with_success_message_enabled]")
+	})
+
+	t.Run("assertion false", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		if result := Warn(spiedT, false, "hello from %s", t.Name()); result {
+			t.Error("Warn should return false when result is false")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectLogsToContain(t, "Warning: literal false [hello from Test_Warn/assertion_false]")
+	})
+}
+
+func Test_Check(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+	got, want := 1, 2
+
+	if ok, msg := Check(spiedT, got == want, "attempt %d", 1); ok || msg != "got is not equal to want [attempt 1]" {
+		t.Errorf("unexpected result %t with message %q", ok, msg)
+	}
+
+	if ok, msg := Check(spiedT, got != want, Values(got)); !ok || msg != "got is not equal to want; got=1" {
+		t.Errorf("unexpected result %t with message %q", ok, msg)
+	}
+
+	spiedT.ExpectTestToPass(t)
+	spiedT.ExpectNoLogs(t)
+}
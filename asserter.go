@@ -0,0 +1,86 @@
+package test
+
+import "github.com/krostar/test/testingt"
+
+// Asserter is a TestingT bound to assertion options, on which assertions can be made without passing t around.
+// It is created with New.
+type Asserter struct {
+	TestingT
+
+	opts []Option
+}
+
+// New returns an Asserter making assertions on t with the provided options,
+// which override the global settings like SuccessMessageEnabled and SetFormatter for this asserter only.
+//
+// It is meant for tests, like parallel subtests, needing different settings without mutating package globals.
+// The asserter being a TestingT, it can also be given to any function expecting one, like checks,
+// in which case the options apply to the assertions made on it.
+//
+// Example:
+//
+//	func Test_Something(t *testing.T) {
+//		a := test.New(t, test.WithSuccessMessages(), test.WithFailFast())
+//		a.Assert(got == want)
+//		a.Check(check.Compare(a, got, want))
+//	}
+func New(t TestingT, opts ...Option) *Asserter {
+	return &Asserter{TestingT: t, opts: opts}
+}
+
+// Assert behaves like the Assert function made on the asserter.
+func (a *Asserter) Assert(result bool, msgAndArgs ...any) bool {
+	a.Helper()
+
+	logResult(a, result, 1, 0, msgAndArgs...)
+
+	if !result {
+		failAssertion(a, msgAndArgs)
+	}
+
+	return result
+}
+
+// Require behaves like the Require function made on the asserter.
+func (a *Asserter) Require(result bool, msgAndArgs ...any) {
+	a.Helper()
+
+	logResult(a, result, 1, 0, msgAndArgs...)
+
+	if !result {
+		a.FailNow()
+	}
+}
+
+// Check behaves like Assert, for checks like the ones of the check package:
+//
+//	a.Check(check.Compare(a, got, want))
+func (a *Asserter) Check(_ TestingT, result bool, msg string) bool {
+	a.Helper()
+
+	logResult(a, result, 1, -1, msg)
+
+	if !result {
+		a.Fail()
+	}
+
+	return result
+}
+
+// Fail marks the test as failed, and stops it if the asserter fails fast, see WithFailFast.
+func (a *Asserter) Fail() {
+	a.Helper()
+
+	if optionsOf(a).failFast {
+		a.TestingT.FailNow()
+		return
+	}
+
+	a.TestingT.Fail()
+}
+
+// assertionOptions implements the optionsHolder interface.
+func (a *Asserter) assertionOptions() []Option { return a.opts }
+
+// Unwrap returns the TestingT the asserter makes assertions on, see testingt.Unwrap.
+func (a *Asserter) Unwrap() testingt.TestingT { return a.TestingT }
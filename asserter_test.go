@@ -0,0 +1,80 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_New(t *testing.T) {
+	t.Run("default options", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithName("Test_Something/case_a")))
+		a := New(spiedT)
+
+		got, want := 1, 2
+		a.Assert(got != want)
+		a.Assert(got == want, "first")
+		Assert(a, got > want)
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: [case_a #2] got is not equal to want [first]")
+		spiedT.ExpectLogsToContain(t, "Error: [case_a #3] got is less than or equal to want")
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "Fail"})
+	})
+
+	t.Run("require", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a := New(spiedT)
+
+		a.Require(true)
+		spiedT.ExpectTestToPass(t)
+
+		a.Require(false)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+	})
+
+	t.Run("check", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a := New(spiedT)
+
+		succeed := func(t TestingT) (TestingT, bool, string) { return t, true, "" }
+		fail := func(t TestingT) (TestingT, bool, string) { return t, false, "it failed" }
+
+		if !a.Check(succeed(a)) {
+			t.Error("expected check to pass")
+		}
+
+		if a.Check(fail(a)) {
+			t.Error("expected check to fail")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: function fail(a) returned false [it failed]")
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a := New(spiedT,
+			WithSuccessMessages(),
+			WithFailFast(),
+			WithFormatter(FormatterFunc(func(r AssertionResult) string { return "custom: " + r.Description })),
+		)
+
+		got, want := 1, 2
+		a.Assert(got != want)
+		Assert(a, got == want)
+
+		spiedT.ExpectLogsToContain(t, "Success: custom: got is not equal to want")
+		spiedT.ExpectLogsToContain(t, "Error: custom: got is not equal to want")
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+	})
+
+	t.Run("options do not leak", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		_ = New(spiedT, WithSuccessMessages())
+
+		Assert(spiedT, true)
+		spiedT.ExpectNoLogs(t)
+	})
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"github.com/krostar/test/internal/message"
+)
+
+// CallRenderer describes the result of a call to a function returning a boolean, see RegisterCallRenderer.
+// `args` are the sources of the arguments of the call, preceded by the source of the receiver for methods,
+// and `result` is the result of the assertion the call is part of.
+type CallRenderer = message.CallRenderer
+
+// RegisterCallRenderer registers how assertions describe calls to the function `name` of the package `pkgPath`,
+// or to the method `name` if it is formatted like "Type.Method".
+//
+// Without renderer, calls to project-specific predicates are described generically, like
+// "function user.IsValid(u) returned false"; a renderer gives them a domain-specific phrasing.
+// Renderers take precedence over the descriptions of well-known functions, like errors.Is.
+//
+// It returns a function restoring the previously registered renderer, if any.
+// It is meant to be called before running tests, typically from TestMain.
+//
+// Example:
+//
+//	test.RegisterCallRenderer("example.com/app/user", "IsValid", func(args []string, result bool) string {
+//		if result {
+//			return args[0] + " is a valid user"
+//		}
+//		return args[0] + " is not a valid user"
+//	})
+//
+//	test.Assert(t, user.IsValid(bob))
+//
+// -> Error: bob is not a valid user
+func RegisterCallRenderer(pkgPath, name string, render CallRenderer) func() {
+	return message.RegisterCallRenderer(pkgPath, name, render)
+}
@@ -0,0 +1,24 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func isEven(i int) bool { return i%2 == 0 }
+
+func Test_RegisterCallRenderer(t *testing.T) {
+	restore := RegisterCallRenderer("github.com/krostar/test", "isEven", func(args []string, result bool) string {
+		if result {
+			return args[0] + " is even"
+		}
+		return args[0] + " is odd"
+	})
+	defer restore()
+
+	spiedT := double.NewSpy(double.NewFake())
+	count := 3
+	Assert(spiedT, isEven(count))
+	spiedT.ExpectLogsToContain(t, "Error: count is odd")
+}
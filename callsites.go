@@ -0,0 +1,21 @@
+package test
+
+import (
+	"github.com/krostar/test/internal/message"
+)
+
+// CallSite describes an assertion found in the source code, see CallSites.
+type CallSite = message.CallSite
+
+// CallSites lists the assertions (calls to Assert, Require and Warn) of the package located at pkgDir,
+// test files included, along with the message each of them would log on failure.
+//
+// It is meant to be used by tools, like editor plugins showing failure previews next to assertions,
+// or providing navigation between assertions. The krostar-test-callsites command dumps them as JSON.
+func CallSites(pkgDir string) ([]CallSite, error) {
+	return message.CallSites(pkgDir,
+		"github.com/krostar/test.Assert",
+		"github.com/krostar/test.Require",
+		"github.com/krostar/test.Warn",
+	)
+}
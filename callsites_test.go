@@ -0,0 +1,20 @@
+package test
+
+import (
+	"testing"
+)
+
+func Test_CallSites(t *testing.T) {
+	callSites, err := CallSites("internal/message/testdata/callsites")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(callSites) != 3 {
+		t.Fatalf("expected 3 call sites, got %d: %v", len(callSites), callSites)
+	}
+
+	if callSites[0].Function != "test.Assert" || callSites[0].FailureMessage != "got is not equal to want" {
+		t.Errorf("unexpected first call site %+v", callSites[0])
+	}
+}
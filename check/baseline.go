@@ -0,0 +1,57 @@
+package check
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/krostar/test"
+)
+
+// NoDiffSince checks that a computed value did not get worse than the baseline stored at `baselinePath`,
+// according to `worse`, which returns whether `got` is worse than `baseline`.
+//
+// It is useful to guard against regressions of metrics like binary sizes, allocations, or complexity,
+// without failing when the metric improves. Baselines are stored as JSON.
+//
+// When tests are run with the -check.update-golden-files flag, or if UpdateGoldenFiles is true,
+// the baseline is written with `got` instead, which allows to ratchet baselines once a metric improved.
+// This is usually used like test.Assert(check.NoDiffSince(t, binarySize, "testdata/binary-size.json", func(baseline, got int64) bool { return got > baseline })).
+func NoDiffSince[T any](t test.TestingT, got T, baselinePath string, worse func(baseline, got T) bool) (test.TestingT, bool, string) {
+	if UpdateGoldenFiles || *_flagUpdateGoldenFiles {
+		raw, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			return t, false, fmt.Sprintf("unable to encode value %v: %v", got, err)
+		}
+
+		if err := writeGoldenFile(baselinePath, string(raw)+"\n"); err != nil {
+			return t, false, err.Error()
+		}
+
+		return t, true, fmt.Sprintf("baseline %s updated with %v", baselinePath, got)
+	}
+
+	raw, err := os.ReadFile(baselinePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return t, false, fmt.Sprintf("baseline %s does not exist, run tests with -check.update-golden-files to create it", baselinePath)
+		}
+		return t, false, fmt.Sprintf("unable to read baseline %s: %v", baselinePath, err)
+	}
+
+	var baseline T
+	if err := json.Unmarshal(raw, &baseline); err != nil {
+		return t, false, fmt.Sprintf("unable to decode baseline %s: %v", baselinePath, err)
+	}
+
+	switch {
+	case worse(baseline, got):
+		return t, false, fmt.Sprintf("value %v is worse than baseline %v stored in %s", got, baseline, baselinePath)
+	case worse(got, baseline):
+		return t, true, fmt.Sprintf("value %v is better than baseline %v stored in %s, run tests with -check.update-golden-files to ratchet it", got, baseline, baselinePath)
+	default:
+		return t, true, fmt.Sprintf("value %v is not worse than baseline %v stored in %s", got, baseline, baselinePath)
+	}
+}
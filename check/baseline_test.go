@@ -0,0 +1,52 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_NoDiffSince(t *testing.T) {
+	greater := func(baseline, got int) bool { return got > baseline }
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NoDiffSince(t, 1024, "testdata/baseline.json", greater)
+		assertCheck(t, tt, result, true, msg, "value 1024 is not worse than baseline 1024 stored in testdata/baseline.json")
+
+		tt, result, msg = NoDiffSince(t, 512, "testdata/baseline.json", greater)
+		assertCheck(t, tt, result, true, msg, "value 512 is better than baseline 1024 stored in testdata/baseline.json, run tests with -check.update-golden-files to ratchet it")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NoDiffSince(t, 2048, "testdata/baseline.json", greater)
+		assertCheck(t, tt, result, false, msg, "value 2048 is worse than baseline 1024 stored in testdata/baseline.json")
+
+		tt, result, msg = NoDiffSince(t, 2048, "testdata/notexisting.json", greater)
+		assertCheck(t, tt, result, false, msg, "baseline testdata/notexisting.json does not exist, run tests with -check.update-golden-files to create it")
+
+		tt, result, msg = NoDiffSince(t, 2048, "testdata", greater)
+		assertCheck(t, tt, result, false, msg, "unable to read baseline testdata")
+
+		tt, result, msg = NoDiffSince(t, 2048, "testdata/baseline_invalid.json", greater)
+		assertCheck(t, tt, result, false, msg, "unable to decode baseline testdata/baseline_invalid.json")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		originalUpdateGoldenFiles := UpdateGoldenFiles
+		t.Cleanup(func() { UpdateGoldenFiles = originalUpdateGoldenFiles })
+
+		UpdateGoldenFiles = true
+
+		path := filepath.Join(t.TempDir(), "baseline.json")
+
+		tt, result, msg := NoDiffSince(t, 512, path, greater)
+		assertCheck(t, tt, result, true, msg, "baseline "+path+" updated with 512")
+
+		if raw, err := os.ReadFile(path); err != nil || string(raw) != "512\n" {
+			t.Errorf("expected baseline to be updated, got %q: %v", raw, err)
+		}
+
+		tt, result, msg = NoDiffSince(t, func() {}, path, func(func(), func()) bool { return false })
+		assertCheck(t, tt, result, false, msg, "unable to encode value")
+	})
+}
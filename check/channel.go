@@ -0,0 +1,64 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/krostar/test"
+)
+
+// Receives checks that a value is received from the channel before the context expires.
+// The `match` argument is an optional function that can be used to assert on the received value,
+// if it returns an error, Receives returns false without waiting for other values.
+// This is usually used like test.Assert(check.Receives(ctx, t, ch, nil)).
+func Receives[T any](ctx context.Context, t test.TestingT, ch <-chan T, match func(T) error) (test.TestingT, bool, string) {
+	startedAt := time.Now()
+
+	select {
+	case <-ctx.Done():
+		return t, false, fmt.Sprintf("no value received after %s and now context is expired", time.Since(startedAt).String())
+
+	case v, ok := <-ch:
+		if !ok {
+			return t, false, "channel was closed before a value was received"
+		}
+
+		if match != nil {
+			if err := match(v); err != nil {
+				return t, false, fmt.Sprintf("received %v, but value assertion failed: %v", v, err)
+			}
+		}
+
+		return t, true, fmt.Sprintf("received %v after %s", v, time.Since(startedAt).String())
+	}
+}
+
+// ReceivesWithin checks that a value is received from the channel before the timeout expires.
+// It behaves like Receives, using a context derived from t.Context() expiring after `timeout`.
+// This is usually used like test.Assert(check.ReceivesWithin(t, ch, time.Second, nil)).
+func ReceivesWithin[T any](t test.TestingT, ch <-chan T, timeout time.Duration, match func(T) error) (test.TestingT, bool, string) {
+	ctx, cancel := context.WithTimeout(t.Context(), timeout)
+	defer cancel()
+
+	return Receives(ctx, t, ch, match)
+}
+
+// Closed checks that the channel is closed before the timeout expires.
+// Receiving a value from the channel makes the check fail, the channel is expected to be closed and drained.
+// This is usually used like test.Assert(check.Closed(t, ch, time.Second)).
+func Closed[T any](t test.TestingT, ch <-chan T, timeout time.Duration) (test.TestingT, bool, string) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return t, false, fmt.Sprintf("channel is still open after %s", timeout.String())
+
+	case v, ok := <-ch:
+		if ok {
+			return t, false, fmt.Sprintf("expected channel to be closed, but received %v", v)
+		}
+		return t, true, "channel is closed"
+	}
+}
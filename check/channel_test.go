@@ -0,0 +1,75 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_Receives(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ch := make(chan int, 1)
+		ch <- 42
+
+		tt, result, msg := Receives(t.Context(), t, ch, nil)
+		assertCheck(t, tt, result, true, msg, "received 42 after")
+
+		go func() {
+			time.Sleep(time.Millisecond * 10)
+			ch <- 21
+		}()
+
+		tt, result, msg = Receives(t.Context(), t, ch, func(v int) error {
+			if v != 21 {
+				return errors.New("not 21")
+			}
+			return nil
+		})
+		assertCheck(t, tt, result, true, msg, "received 21 after")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		ch := make(chan int, 1)
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond*10)
+		defer cancel()
+
+		tt, result, msg := Receives(ctx, t, ch, nil)
+		assertCheck(t, tt, result, false, msg, "no value received after", "and now context is expired")
+
+		ch <- 42
+		tt, result, msg = Receives(t.Context(), t, ch, func(int) error { return errors.New("boom") })
+		assertCheck(t, tt, result, false, msg, "received 42, but value assertion failed: boom")
+
+		close(ch)
+		tt, result, msg = Receives(t.Context(), t, ch, nil)
+		assertCheck(t, tt, result, false, msg, "channel was closed before a value was received")
+	})
+}
+
+func Test_ReceivesWithin(t *testing.T) {
+	ch := make(chan string, 1)
+
+	tt, result, msg := ReceivesWithin(t, ch, time.Millisecond*10, nil)
+	assertCheck(t, tt, result, false, msg, "no value received after")
+
+	ch <- "hello"
+	tt, result, msg = ReceivesWithin(t, ch, time.Millisecond*10, nil)
+	assertCheck(t, tt, result, true, msg, "received hello after")
+}
+
+func Test_Closed(t *testing.T) {
+	ch := make(chan int, 1)
+
+	tt, result, msg := Closed(t, ch, time.Millisecond*10)
+	assertCheck(t, tt, result, false, msg, "channel is still open after 10ms")
+
+	ch <- 42
+	tt, result, msg = Closed(t, ch, time.Millisecond*10)
+	assertCheck(t, tt, result, false, msg, "expected channel to be closed, but received 42")
+
+	close(ch)
+	tt, result, msg = Closed(t, ch, time.Millisecond*10)
+	assertCheck(t, tt, result, true, msg, "channel is closed")
+}
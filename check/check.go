@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
 	"time"
 
 	gocmp "github.com/google/go-cmp/cmp"
@@ -12,10 +16,13 @@ import (
 )
 
 // Compare checks if two values are equal using go-cmp.
+// Options registered with RegisterCompareOptions are applied before the provided ones.
+// The diff, expensive to build for large values, is only computed when values differ.
 // This is usually used like test.Assert(check.Compare(t, got, want)).
 func Compare[T any](t test.TestingT, got, want T, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
-	if diff := gocmp.Diff(got, want, gocmpOpts...); diff != "" {
-		return t, false, "comparison differs: \n" + diff
+	opts := compareOptions(gocmpOpts)
+	if !gocmp.Equal(got, want, opts...) {
+		return t, false, "comparison differs: \n" + gocmp.Diff(got, want, opts...)
 	}
 	return t, true, "no differences"
 }
@@ -32,28 +39,55 @@ func Compare[T any](t test.TestingT, got, want T, gocmpOpts ...gocmp.Option) (te
 //		// ...
 //	}, time.Millisecond*100))
 func Eventually(ctx context.Context, t test.TestingT, check func(context.Context) error, timeBetweenRetries time.Duration) (test.TestingT, bool, string) {
+	result := eventually(ctx, check, timeBetweenRetries)
+	if !result.passed {
+		return t, false, fmt.Sprintf("%s, last two errors: %s", result.failure(), errors.Join(result.errs[0], result.errs[1]))
+	}
+
+	return t, true, result.success()
+}
+
+// eventuallyResult is the outcome of eventually, from which the messages of Eventually-like checks are built.
+type eventuallyResult struct {
+	passed  bool
+	elapsed time.Duration
+	retries uint
+	errs    [2]error // last two errors returned by the check
+}
+
+// success describes the success of the check.
+func (r eventuallyResult) success() string {
+	return fmt.Sprintf("check passed in %s with %d retries", r.elapsed.String(), r.retries)
+}
+
+// failure describes the failure of the check, without its errors.
+func (r eventuallyResult) failure() string {
+	return fmt.Sprintf("check did not pass in %s with %d retries and now context is expired", r.elapsed.String(), r.retries)
+}
+
+// eventually repeatedly executes the check function until it succeeds or the context expires, see Eventually.
+func eventually(ctx context.Context, check func(context.Context) error, timeBetweenRetries time.Duration) eventuallyResult {
 	startedAt := time.Now()
 	ticker := time.NewTimer(0)
 	tryC := make(chan struct{}, 1)
 
-	var (
-		errs    [2]error
-		retries uint
-	)
+	var result eventuallyResult
 
 	for {
 		select {
 		case <-ctx.Done():
-			return t, false, fmt.Sprintf("check did not pass in %s with %d retries and now context is expired, last two errors: %s", time.Since(startedAt).String(), retries, errors.Join(errs[0], errs[1]))
+			result.elapsed = time.Since(startedAt)
+			return result
 
 		case <-tryC:
 			if err := check(ctx); err != nil {
-				errs[retries%2] = err
+				result.errs[result.retries%2] = err
 			} else {
-				return t, true, fmt.Sprintf("check passed in %s with %d retries", time.Since(startedAt).String(), retries)
+				result.passed, result.elapsed = true, time.Since(startedAt)
+				return result
 			}
 
-			retries++
+			result.retries++
 
 			ticker.Reset(timeBetweenRetries)
 
@@ -66,6 +100,133 @@ func Eventually(ctx context.Context, t test.TestingT, check func(context.Context
 	}
 }
 
+// EventuallyKOfN repeatedly executes a check function until at least `k` of the last `n` attempts passed,
+// or the context expires.
+//
+// It is meant for inherently lossy systems, like sampled metrics or eventually-consistent reads,
+// where a single passing probe proves nothing, and a single failing probe is expected from time to time.
+// On failure, the message contains the history of the pass ratio over the last `n` attempts, and the last error.
+//
+//	Example: test.Assert(check.EventuallyKOfN(ctx, t, 8, 10, func(ctx context.Context) error {
+//		// ...
+//	}, time.Millisecond*100))
+func EventuallyKOfN(ctx context.Context, t test.TestingT, k, n int, check func(context.Context) error, timeBetweenRetries time.Duration) (test.TestingT, bool, string) {
+	const maxHistory = 20
+
+	if k < 1 || n < k {
+		return t, false, fmt.Sprintf("k must be positive and lower or equal to n, got k=%d and n=%d", k, n)
+	}
+
+	startedAt := time.Now()
+
+	var (
+		window  []bool // outcomes of the last n attempts
+		passed  int    // number of passing attempts in window
+		history []string
+		lastErr error
+	)
+
+	for attempt := 1; ; attempt++ {
+		err := check(ctx)
+		if err != nil {
+			lastErr = err
+		}
+
+		window = append(window, err == nil)
+		if err == nil {
+			passed++
+		}
+		if len(window) > n {
+			if window[0] {
+				passed--
+			}
+			window = window[1:]
+		}
+
+		if passed >= k {
+			return t, true, fmt.Sprintf("%d of the last %d attempts passed in %s with %d attempts", passed, len(window), time.Since(startedAt).String(), attempt)
+		}
+
+		if history = append(history, fmt.Sprintf("%d/%d", passed, len(window))); len(history) > maxHistory {
+			history = history[1:]
+		}
+
+		select {
+		case <-ctx.Done():
+			if attempt > maxHistory {
+				history = append([]string{"..."}, history...)
+			}
+			return t, false, fmt.Sprintf("less than %d of the last %d attempts passed in %s with %d attempts and now context is expired, pass ratio history: %s, last error: %v",
+				k, n, time.Since(startedAt).String(), attempt, strings.Join(history, " "), lastErr,
+			)
+		case <-time.After(timeBetweenRetries):
+		}
+	}
+}
+
+// EventuallyCompare repeatedly calls `produce` until the produced value is equal to `want`, using Compare,
+// or the context expires, see Eventually.
+// On failure, the message contains the last error returned by `produce`, or the diff of the last produced value.
+//
+//	Example: test.Assert(check.EventuallyCompare(ctx, t, func(ctx context.Context) (Status, error) {
+//		return client.Status(ctx)
+//	}, StatusReady, time.Millisecond*100))
+func EventuallyCompare[T any](ctx context.Context, t test.TestingT, produce func(context.Context) (T, error), want T, timeBetweenRetries time.Duration, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	var last string
+
+	result := eventually(ctx, func(ctx context.Context) error {
+		got, err := produce(ctx)
+		if err != nil {
+			last = fmt.Sprintf("last produced error: %v", err)
+			return err
+		}
+
+		if _, result, msg := Compare(t, got, want, gocmpOpts...); !result {
+			last = "last produced value " + msg
+			return errors.New("comparison differs")
+		}
+
+		return nil
+	}, timeBetweenRetries)
+	if !result.passed {
+		return t, false, result.failure() + "\n" + last
+	}
+
+	return t, true, result.success()
+}
+
+// Attempt executes a function up to `n` times, waiting `delay` between attempts, until it succeeds.
+//
+// It is a simpler alternative to Eventually for bounded retries where wiring a context is overkill.
+// On failure, the error of every attempt is reported.
+//
+//	Example: test.Assert(check.Attempt(t, 3, time.Millisecond*100, func() error {
+//		// ...
+//	}))
+func Attempt(t test.TestingT, n int, delay time.Duration, fn func() error) (test.TestingT, bool, string) {
+	if n < 1 {
+		return t, false, fmt.Sprintf("number of attempts must be positive, got %d", n)
+	}
+
+	startedAt := time.Now()
+	errs := make([]string, 0, n)
+
+	for attempt := 1; attempt <= n; attempt++ {
+		if attempt > 1 {
+			time.Sleep(delay)
+		}
+
+		err := fn()
+		if err == nil {
+			return t, true, fmt.Sprintf("attempt %d of %d passed in %s", attempt, n, time.Since(startedAt).String())
+		}
+
+		errs = append(errs, fmt.Sprintf("attempt %d: %v", attempt, err))
+	}
+
+	return t, false, fmt.Sprintf("all %d attempts failed in %s:\n  %s", n, time.Since(startedAt).String(), strings.Join(errs, "\n  "))
+}
+
 // Not inverts the result of a boolean test check.
 //
 // This function is typically used with other check functions to negate their results.
@@ -73,7 +234,7 @@ func Eventually(ctx context.Context, t test.TestingT, check func(context.Context
 //
 // Example:
 //
-//	test.Assert(check.Not(check.Panics(t, func() { /* code that should not panic */ }, nil)))
+//	test.Assert(check.Not(check.ZeroValue(t, count)))
 func Not(t test.TestingT, result bool, msgAndArgs ...any) (test.TestingT, bool, string) {
 	t.Helper()
 
@@ -95,37 +256,107 @@ func Not(t test.TestingT, result bool, msgAndArgs ...any) (test.TestingT, bool,
 
 // Panics checks if a function panics.
 // The `f` argument is the function to be tested for panic, `assertReason` is an optional function that can be used to assert on the recovered panic value.
-// If `f` panics, and `assertReason` is provided and returns an error, Panics will return false and the error message.
+// If `f` panics, and `assertReason` is provided and returns an error, Panics will return false and the error message
+// followed by the stack of the panicking code.
 // This is usually used like test.Assert(check.Panics(t, func(){panic("boom")}, nil)).
-func Panics(t test.TestingT, f func(), assertReason func(reason any) error) (tt test.TestingT, result bool, msg string) { //nolint:nonamedreturns // by design of how panics works named return are required
+func Panics(t test.TestingT, f func(), assertReason func(reason any) error) (test.TestingT, bool, string) {
 	if f == nil {
 		return t, false, "function to test for panic must not be nil"
 	}
 
-	tt = t
+	reason, stack := catchPanic(f)
+	if reason == nil {
+		return t, false, "expected function to panic"
+	}
 
-	defer func() {
-		reason := recover()
+	if assertReason != nil {
+		if reasonErr := assertReason(reason); reasonErr != nil {
+			return t, false, fmt.Sprintf("function panicked like expected, but reason assertion failed: %v\n%s", reasonErr, stack)
+		}
+	}
 
-		if reason == nil {
-			msg = "expected function to panic"
-			return
+	return t, true, "function panicked like expected"
+}
+
+// PanicsWithError checks if a function panics with an error matching `target`.
+// If `target` is an error, the recovered value must have it in its error tree (see errors.Is),
+// otherwise `target` must be a non-nil pointer the recovered value can be assigned to (see errors.As).
+// This is usually used like test.Assert(check.PanicsWithError(t, func(){panic(io.EOF)}, io.EOF)).
+func PanicsWithError(t test.TestingT, f func(), target any) (test.TestingT, bool, string) {
+	return Panics(t, f, func(reason any) error {
+		err, ok := reason.(error)
+		if !ok {
+			return fmt.Errorf("recovered value %#v of type %T is not an error", reason, reason)
 		}
 
-		if assertReason != nil {
-			if reasonErr := assertReason(reason); reasonErr != nil {
-				msg = fmt.Sprintf("function panicked like expected, but reason assertion failed: %v", reasonErr)
-				return
+		if targetErr, ok := target.(error); ok {
+			if !errors.Is(err, targetErr) {
+				return fmt.Errorf("%v is not in the error tree of recovered error %q", targetErr, err)
 			}
+			return nil
 		}
 
-		result = true
-		msg = "function panicked like expected"
-	}()
+		if v := reflect.ValueOf(target); v.Kind() != reflect.Pointer || v.IsNil() ||
+			(v.Elem().Kind() != reflect.Interface && !v.Type().Elem().Implements(reflect.TypeFor[error]())) {
+			return fmt.Errorf("target must be an error or a non-nil pointer to an error type, got %T", target)
+		}
 
-	f()
+		if !errors.As(err, target) {
+			return fmt.Errorf("recovered error %q cannot be defined as %T", err, target)
+		}
+
+		return nil
+	})
+}
+
+// NotPanics checks that a function does not panic.
+// If `f` panics, the message contains the recovered value followed by the stack of the panicking code,
+// which are lost when using Not with Panics.
+// This is usually used like test.Assert(check.NotPanics(t, func(){ parse(input) })).
+func NotPanics(t test.TestingT, f func()) (test.TestingT, bool, string) {
+	if f == nil {
+		return t, false, "function to test for panic must not be nil"
+	}
+
+	if reason, stack := catchPanic(f); reason != nil {
+		return t, false, fmt.Sprintf("function panicked with %#v\n%s", reason, stack)
+	}
 
-	return tt, result, msg
+	return t, true, "function did not panic"
+}
+
+// Satisfies checks if a value satisfies a predicate, described by `name` in messages.
+// This is usually used like test.Assert(check.Satisfies(t, date, "is business day", isBusinessDay)).
+func Satisfies[T any](t test.TestingT, v T, name string, pred func(T) bool) (test.TestingT, bool, string) {
+	if !pred(v) {
+		return t, false, fmt.Sprintf("value does not satisfy '%s': %v", name, v)
+	}
+	return t, true, fmt.Sprintf("value satisfies '%s': %v", name, v)
+}
+
+// Deterministic checks that `f` produces the same output, using Compare, each of the `runs` times it is called.
+//
+// It catches outputs depending on the iteration order of maps, or on the scheduling of goroutines,
+// as both vary from one call to another. Outputs should not contain values expected to change between calls,
+// like timestamps, which can be normalized by `f` or ignored with go-cmp options.
+// On failure, the message contains the diff between the output of the first run and the first differing one,
+// which is only computed then.
+// This is usually used like test.Assert(check.Deterministic(t, 20, func() string { return render(input) })).
+func Deterministic[T any](t test.TestingT, runs int, f func() T, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	if runs < 2 {
+		return t, false, fmt.Sprintf("number of runs must be at least 2, got %d", runs)
+	}
+
+	opts := compareOptions(gocmpOpts)
+
+	first := f()
+	for run := 2; run <= runs; run++ {
+		if output := f(); !gocmp.Equal(first, output, opts...) {
+			return t, false, fmt.Sprintf("output of run %d differs from the output of run 1 (-run 1 +run %d):\n%s", run, run, gocmp.Diff(first, output, opts...))
+		}
+	}
+
+	return t, true, fmt.Sprintf("output is the same across %d runs", runs)
 }
 
 // ZeroValue checks if a value is equal to the zero value of its type.
@@ -137,3 +368,48 @@ func ZeroValue[T comparable](t test.TestingT, v T) (test.TestingT, bool, string)
 	}
 	return t, true, fmt.Sprintf("%#v is the zero value of type %T", v, v)
 }
+
+// catchPanic calls f and returns the recovered value if f panicked, along with the stack
+// of the panicking code trimmed to the frames between the panic and the call to f.
+func catchPanic(f func()) (reason any, stack string) { //nolint:nonamedreturns // by design of how panics works named return are required
+	defer func() {
+		if reason = recover(); reason != nil {
+			stack = trimPanicStack(debug.Stack())
+		}
+	}()
+
+	f()
+
+	return nil, ""
+}
+
+// trimPanicStack removes from a stack obtained with debug.Stack inside catchPanic's deferred function
+// the frames that are not part of the code under test:
+// everything up to the runtime panic call, and everything from the catchPanic call.
+func trimPanicStack(stack []byte) string {
+	catchPanicName := runtime.FuncForPC(reflect.ValueOf(catchPanic).Pointer()).Name()
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+
+	// skip the goroutine header, each frame is then made of two lines: the function, and the file and line
+	start, end := 1, len(lines)
+	for i := 1; i < len(lines); i += 2 {
+		switch {
+		case strings.HasPrefix(lines[i], "panic("):
+			if start == 1 {
+				start = i + 2
+			}
+		case strings.HasPrefix(lines[i], catchPanicName+"("):
+			end = i
+		}
+
+		if end != len(lines) {
+			break
+		}
+	}
+
+	if start >= end {
+		return strings.Join(lines, "\n")
+	}
+
+	return strings.Join(lines[start:end], "\n")
+}
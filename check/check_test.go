@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -99,6 +103,115 @@ func Test_Eventually(t *testing.T) {
 	})
 }
 
+func Test_EventuallyKOfN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		attempts := 0
+
+		tt, result, msg := EventuallyKOfN(t.Context(), t, 3, 4, func(context.Context) error {
+			if attempts++; attempts%3 == 0 {
+				return errors.New("lost")
+			}
+			return nil
+		}, time.Millisecond)
+
+		assertCheck(t, tt, result, true, msg, "3 of the last 4 attempts passed in ", "with 4 attempts")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		attempts := 0
+
+		tt, result, msg := EventuallyKOfN(ctx, t, 3, 3, func(context.Context) error {
+			if attempts++; attempts%2 == 0 {
+				return fmt.Errorf("lost %d", attempts)
+			}
+			return nil
+		}, time.Millisecond*20)
+
+		assertCheck(t, tt, result, false, msg, "less than 3 of the last 3 attempts passed in ", "now context is expired, pass ratio history: 1/1 1/2 2/3", "last error: lost ")
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		tt, result, msg := EventuallyKOfN(t.Context(), t, 3, 2, func(context.Context) error { return nil }, time.Millisecond)
+		assertCheck(t, tt, result, false, msg, "k must be positive and lower or equal to n, got k=3 and n=2")
+	})
+}
+
+func Test_EventuallyCompare(t *testing.T) {
+	t.Run("converges", func(t *testing.T) {
+		calls := 0
+
+		tt, result, msg := EventuallyCompare(t.Context(), t, func(context.Context) ([]string, error) {
+			calls++
+			switch calls {
+			case 1:
+				return nil, errors.New("not ready")
+			case 2:
+				return []string{"a"}, nil
+			default:
+				return []string{"a", "b"}, nil
+			}
+		}, []string{"a", "b"}, time.Millisecond)
+
+		assertCheck(t, tt, result, true, msg, "check passed", "2 retries")
+	})
+
+	t.Run("does not converge", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		tt, result, msg := EventuallyCompare(ctx, t, func(context.Context) (int, error) {
+			return 41, nil
+		}, 42, time.Millisecond)
+
+		assertCheck(t, tt, result, false, msg, "check did not pass in ", "now context is expired\nlast produced value comparison differs: \n", "41", "42")
+	})
+
+	t.Run("last call failed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		tt, result, msg := EventuallyCompare(ctx, t, func(context.Context) (int, error) {
+			return 0, errors.New("unavailable")
+		}, 42, time.Millisecond)
+
+		assertCheck(t, tt, result, false, msg, "now context is expired\nlast produced error: unavailable")
+	})
+}
+
+func Test_Attempt(t *testing.T) {
+	t.Run("success after retries", func(t *testing.T) {
+		attempts := 0
+
+		tt, result, msg := Attempt(t, 3, time.Millisecond, func() error {
+			if attempts++; attempts < 3 {
+				return fmt.Errorf("boom %d", attempts)
+			}
+			return nil
+		})
+
+		assertCheck(t, tt, result, true, msg, "attempt 3 of 3 passed")
+	})
+
+	t.Run("all attempts failed", func(t *testing.T) {
+		attempts := 0
+
+		tt, result, msg := Attempt(t, 2, time.Millisecond, func() error {
+			attempts++
+			return fmt.Errorf("boom %d", attempts)
+		})
+
+		assertCheck(t, tt, result, false, msg, "all 2 attempts failed in ", "\n  attempt 1: boom 1\n  attempt 2: boom 2")
+	})
+
+	t.Run("invalid number of attempts", func(t *testing.T) {
+		tt, result, msg := Attempt(t, 0, time.Millisecond, func() error { return nil })
+		assertCheck(t, tt, result, false, msg, "number of attempts must be positive, got 0")
+	})
+}
+
 func Test_Not(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		tt, result, msg := Not(t, true, "foo")
@@ -151,7 +264,133 @@ func Test_Panics(t *testing.T) {
 		tt, result, msg = Panics(t, func() { panic(42) }, func(any) error {
 			return errors.New("boom")
 		})
-		assertCheck(t, tt, result, false, msg, "function panicked like expected, but reason assertion failed")
+		assertCheck(t, tt, result, false, msg, "function panicked like expected, but reason assertion failed", "check.Test_Panics.func2.2()", "check_test.go:")
+
+		if strings.Contains(msg, "runtime/debug.Stack") || strings.Contains(msg, "check.catchPanic") {
+			t.Errorf("expected stack to be trimmed, got %s", msg)
+		}
+	})
+}
+
+func Test_NotPanics(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NotPanics(t, func() {})
+		assertCheck(t, tt, result, true, msg, "function did not panic")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NotPanics(t, func() { panic(errors.New("boom")) })
+		assertCheck(t, tt, result, false, msg, "function panicked with &errors.errorString{s:\"boom\"}\n", "check.Test_NotPanics.func2.1()", "check_test.go:")
+
+		tt, result, msg = NotPanics(t, nil)
+		assertCheck(t, tt, result, false, msg, "function to test for panic must not be nil")
+	})
+}
+
+func Test_catchPanic(t *testing.T) {
+	t.Run("no panic", func(t *testing.T) {
+		reason, stack := catchPanic(func() {})
+		if reason != nil || stack != "" {
+			t.Errorf("expected no reason and no stack, got %v and %q", reason, stack)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		panicking := func() { panic("boom") }
+
+		reason, stack := catchPanic(func() { panicking() })
+		if reason != "boom" {
+			t.Errorf("expected reason to be boom, got %v", reason)
+		}
+
+		lines := strings.Split(stack, "\n")
+		if len(lines) != 4 {
+			t.Fatalf("expected stack to contain exactly the two panicking frames, got %q", stack)
+		}
+
+		if !strings.HasPrefix(lines[0], "github.com/krostar/test/check.Test_catchPanic.func2.1(") {
+			t.Errorf("expected first frame to be the panicking function, got %s", lines[0])
+		}
+
+		if !strings.HasPrefix(lines[2], "github.com/krostar/test/check.Test_catchPanic.func2.2(") {
+			t.Errorf("expected second frame to be the function given to catchPanic, got %s", lines[2])
+		}
+	})
+}
+
+func Test_trimPanicStack(t *testing.T) {
+	stack := "goroutine 1 [running]:\nfoo()\n\tfoo.go:1\nbar()\n\tbar.go:2"
+	if trimmed := trimPanicStack([]byte(stack)); trimmed != strings.Join(strings.Split(stack, "\n")[1:], "\n") {
+		t.Errorf("expected unrecognized stack to be returned without its header, got %q", trimmed)
+	}
+}
+
+func Test_PanicsWithError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := PanicsWithError(t, func() { panic(fmt.Errorf("wrapped: %w", errBoom)) }, errBoom)
+		assertCheck(t, tt, result, true, msg, "function panicked like expected")
+
+		var pathErr *fs.PathError
+		tt, result, msg = PanicsWithError(t, func() { panic(&fs.PathError{Op: "open", Path: "/", Err: errBoom}) }, &pathErr)
+		assertCheck(t, tt, result, true, msg, "function panicked like expected")
+
+		if pathErr == nil || pathErr.Op != "open" {
+			t.Errorf("expected target to be set, got %v", pathErr)
+		}
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := PanicsWithError(t, func() {}, errBoom)
+		assertCheck(t, tt, result, false, msg, "expected function to panic")
+
+		tt, result, msg = PanicsWithError(t, func() { panic("boom") }, errBoom)
+		assertCheck(t, tt, result, false, msg, "reason assertion failed", `recovered value "boom" of type string is not an error`)
+
+		tt, result, msg = PanicsWithError(t, func() { panic(io.EOF) }, errBoom)
+		assertCheck(t, tt, result, false, msg, "reason assertion failed", `boom is not in the error tree of recovered error "EOF"`)
+
+		var pathErr *fs.PathError
+		tt, result, msg = PanicsWithError(t, func() { panic(io.EOF) }, &pathErr)
+		assertCheck(t, tt, result, false, msg, "reason assertion failed", `recovered error "EOF" cannot be defined as **fs.PathError`)
+
+		tt, result, msg = PanicsWithError(t, func() { panic(io.EOF) }, 42)
+		assertCheck(t, tt, result, false, msg, "target must be an error or a non-nil pointer to an error type, got int")
+
+		tt, result, msg = PanicsWithError(t, func() { panic(io.EOF) }, new(int))
+		assertCheck(t, tt, result, false, msg, "target must be an error or a non-nil pointer to an error type, got *int")
+	})
+}
+
+func Test_Satisfies(t *testing.T) {
+	isBusinessDay := func(d time.Time) bool { return d.Weekday() != time.Saturday && d.Weekday() != time.Sunday }
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Satisfies(t, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), "is business day", isBusinessDay)
+		assertCheck(t, tt, result, true, msg, "value satisfies 'is business day': 2024-06-03")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Satisfies(t, time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), "is business day", isBusinessDay)
+		assertCheck(t, tt, result, false, msg, "value does not satisfy 'is business day': 2024-06-02")
+	})
+}
+
+func Test_Deterministic(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8}
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Deterministic(t, 20, func() []string { return slices.Sorted(maps.Keys(m)) })
+		assertCheck(t, tt, result, true, msg, "output is the same across 20 runs")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Deterministic(t, 50, func() []string { return slices.Collect(maps.Keys(m)) })
+		assertCheck(t, tt, result, false, msg, "differs from the output of run 1 (-run 1 +run ")
+
+		tt, result, msg = Deterministic(t, 1, func() int { return 42 })
+		assertCheck(t, tt, result, false, msg, "number of runs must be at least 2, got 1")
 	})
 }
 
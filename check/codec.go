@@ -0,0 +1,42 @@
+package check
+
+import (
+	"fmt"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test"
+)
+
+// UnmarshalsInto checks that `data` decodes, using `unmarshal`, into a value equal to `want`, using Compare.
+// Decoding failures are reported distinctly from value mismatches.
+// This is usually used like test.Assert(check.UnmarshalsInto(t, []byte(`{"id":42}`), Payload{ID: 42}, json.Unmarshal)).
+func UnmarshalsInto[T any](t test.TestingT, data []byte, want T, unmarshal func([]byte, any) error, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	var got T
+	if err := unmarshal(data, &got); err != nil {
+		return t, false, fmt.Sprintf("unable to decode %q into %T: %v", data, got, err)
+	}
+
+	return Compare(t, got, want, gocmpOpts...)
+}
+
+// RoundTrips checks that `v` is encoded by `marshal`, then decoded back by `unmarshal` into a value equal to `v`, using Compare.
+// Encoding and decoding failures are reported distinctly from value mismatches.
+// This is usually used like test.Assert(check.RoundTrips(t, Payload{ID: 42}, json.Marshal, json.Unmarshal)).
+func RoundTrips[T any](t test.TestingT, v T, marshal func(any) ([]byte, error), unmarshal func([]byte, any) error, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	data, err := marshal(v)
+	if err != nil {
+		return t, false, fmt.Sprintf("unable to encode %T: %v", v, err)
+	}
+
+	var got T
+	if err := unmarshal(data, &got); err != nil {
+		return t, false, fmt.Sprintf("unable to decode %q back into %T: %v", data, got, err)
+	}
+
+	if t, result, msg := Compare(t, got, v, gocmpOpts...); !result {
+		return t, false, fmt.Sprintf("value changed after a round trip through %q: %s", data, msg)
+	}
+
+	return t, true, "value is unchanged after a round trip"
+}
@@ -0,0 +1,60 @@
+package check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func Test_UnmarshalsInto(t *testing.T) {
+	type payload struct {
+		ID   int    `json:"id"   xml:"id"`
+		Name string `json:"name" xml:"name"`
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := UnmarshalsInto(t, []byte(`{"id": 42, "name": "bob"}`), payload{ID: 42, Name: "bob"}, json.Unmarshal)
+		assertCheck(t, tt, result, true, msg, "no differences")
+
+		tt, result, msg = UnmarshalsInto(t, []byte(`<payload><id>42</id><name>bob</name></payload>`), payload{ID: 42, Name: "bob"}, xml.Unmarshal)
+		assertCheck(t, tt, result, true, msg, "no differences")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := UnmarshalsInto(t, []byte(`{"id": 42, "name": "alice"}`), payload{ID: 42, Name: "bob"}, json.Unmarshal)
+		assertCheck(t, tt, result, false, msg, "comparison differs", "alice", "bob")
+
+		tt, result, msg = UnmarshalsInto(t, []byte(`{"id": "42"}`), payload{ID: 42}, json.Unmarshal)
+		assertCheck(t, tt, result, false, msg, `unable to decode "{\"id\": \"42\"}" into check.payload: json: cannot unmarshal`)
+	})
+}
+
+func Test_RoundTrips(t *testing.T) {
+	type payload struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		private string
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := RoundTrips(t, payload{ID: 42, Name: "bob"}, json.Marshal, json.Unmarshal, gocmp.AllowUnexported(payload{}))
+		assertCheck(t, tt, result, true, msg, "value is unchanged after a round trip")
+
+		tt, result, msg = RoundTrips(t, payload{ID: 42, Name: "bob"}, xml.Marshal, xml.Unmarshal, gocmp.AllowUnexported(payload{}))
+		assertCheck(t, tt, result, true, msg, "value is unchanged after a round trip")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := RoundTrips(t, payload{ID: 42, private: "lost"}, json.Marshal, json.Unmarshal, gocmp.AllowUnexported(payload{}))
+		assertCheck(t, tt, result, false, msg, `value changed after a round trip through "{\"id\":42,\"name\":\"\"}"`, "lost")
+
+		tt, result, msg = RoundTrips(t, make(chan int), json.Marshal, json.Unmarshal)
+		assertCheck(t, tt, result, false, msg, "unable to encode chan int: json: unsupported type: chan int")
+
+		tt, result, msg = RoundTrips(t, payload{ID: 42}, json.Marshal, func([]byte, any) error { return errors.New("boom") }, gocmp.AllowUnexported(payload{}))
+		assertCheck(t, tt, result, false, msg, "unable to decode", "back into check.payload: boom")
+	})
+}
@@ -0,0 +1,44 @@
+package check
+
+import (
+	"slices"
+	"sync"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+//nolint:gochecknoglobals // options are registered once for the whole test binary, usually from an init function
+var _compareOptions struct {
+	m    sync.RWMutex
+	opts []gocmp.Option
+}
+
+// RegisterCompareOptions registers go-cmp options applied to every Compare call,
+// and to every check relying on Compare, like UnmarshalsInto and HTTPBodyJSON.
+// Options provided to a check call are applied after the registered ones.
+//
+// It is meant to be called once, from an init function or a TestMain, so that the same options
+// are not provided to every Compare call:
+//
+//	func TestMain(m *testing.M) {
+//		check.RegisterCompareOptions(protocmp.Transform(), cmpopts.EquateApproxTime(time.Second))
+//		os.Exit(m.Run())
+//	}
+func RegisterCompareOptions(opts ...gocmp.Option) {
+	_compareOptions.m.Lock()
+	defer _compareOptions.m.Unlock()
+
+	_compareOptions.opts = append(_compareOptions.opts, opts...)
+}
+
+// compareOptions returns the registered options followed by the provided ones.
+func compareOptions(opts []gocmp.Option) []gocmp.Option {
+	_compareOptions.m.RLock()
+	defer _compareOptions.m.RUnlock()
+
+	if len(_compareOptions.opts) == 0 {
+		return opts
+	}
+
+	return append(slices.Clone(_compareOptions.opts), opts...)
+}
@@ -0,0 +1,36 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func Test_RegisterCompareOptions(t *testing.T) {
+	_compareOptions.m.Lock()
+	original := _compareOptions.opts
+	_compareOptions.opts = nil
+	_compareOptions.m.Unlock()
+
+	t.Cleanup(func() {
+		_compareOptions.m.Lock()
+		_compareOptions.opts = original
+		_compareOptions.m.Unlock()
+	})
+
+	tt, result, msg := Compare(t, "Hello", "hello")
+	assertCheck(t, tt, result, false, msg, "comparison differs")
+
+	RegisterCompareOptions(gocmp.Comparer(strings.EqualFold))
+
+	tt, result, msg = Compare(t, "Hello", "hello")
+	assertCheck(t, tt, result, true, msg, "no differences")
+
+	tt, result, msg = Compare(t, "Hello", "hello", gocmp.FilterPath(func(gocmp.Path) bool { return true }, gocmp.Ignore()))
+	assertCheck(t, tt, result, true, msg, "no differences")
+
+	if opts := compareOptions(nil); len(opts) != 1 {
+		t.Errorf("expected a single registered option, got %d", len(opts))
+	}
+}
@@ -0,0 +1,134 @@
+package check
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/krostar/test"
+)
+
+// Checker is a check that is not evaluated yet.
+// It is usually a closure wrapping a call to any of the check functions.
+type Checker func(t test.TestingT) (test.TestingT, bool, string)
+
+// Lazy returns the checker in the shape accepted by test.Assert and test.Require,
+// which only evaluate it, and only build its message, when they run it.
+//
+// Example:
+//
+//	test.Assert(t, check.Lazy(func(t test.TestingT) (test.TestingT, bool, string) {
+//		return check.All(t, checkName, checkAge)
+//	}))
+func Lazy(check Checker) func(t test.TestingT) (bool, string) {
+	return func(t test.TestingT) (bool, string) {
+		t.Helper()
+		_, result, msg := check(t)
+		return result, msg
+	}
+}
+
+// All checks that all the provided checks pass.
+//
+// Every check is evaluated, even after a failure, so the resulting message
+// lists the messages of all the failing checks at once.
+//
+// Example:
+//
+//	test.Assert(check.All(t,
+//		func(t test.TestingT) (test.TestingT, bool, string) { return check.Compare(t, got.Name, "bob") },
+//		func(t test.TestingT) (test.TestingT, bool, string) { return check.ZeroValue(t, got.Age) },
+//	))
+func All(t test.TestingT, checks ...Checker) (test.TestingT, bool, string) {
+	t.Helper()
+
+	var failures []string
+	for i, check := range checks {
+		if _, result, msg := check(t); !result {
+			failures = append(failures, describeCheck(i, msg))
+		}
+	}
+
+	if len(failures) > 0 {
+		return t, false, fmt.Sprintf("%d of %d checks failed:\n  - %s", len(failures), len(checks), strings.Join(failures, "\n  - "))
+	}
+
+	return t, true, fmt.Sprintf("all %d checks passed", len(checks))
+}
+
+// Group checks that all the provided labeled checks pass.
+//
+// Like All, every check is evaluated, but failures are described by their label instead of their position,
+// which makes it convenient to validate every field of a struct with a single assertion.
+// Labels are listed in lexical order.
+//
+// Example:
+//
+//	test.Assert(check.Group(t, map[string]check.Checker{
+//		"name": func(t test.TestingT) (test.TestingT, bool, string) { return check.Compare(t, got.Name, "bob") },
+//		"age":  func(t test.TestingT) (test.TestingT, bool, string) { return check.ZeroValue(t, got.Age) },
+//	}))
+func Group(t test.TestingT, checks map[string]Checker) (test.TestingT, bool, string) {
+	t.Helper()
+
+	var failures []string
+	for _, label := range slices.Sorted(maps.Keys(checks)) {
+		if _, result, msg := checks[label](t); !result {
+			if msg == "" {
+				msg = "<no message>"
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", label, indentContinuationLines(msg)))
+		}
+	}
+
+	if len(failures) > 0 {
+		return t, false, fmt.Sprintf("%d of %d checks failed:\n  - %s", len(failures), len(checks), strings.Join(failures, "\n  - "))
+	}
+
+	return t, true, fmt.Sprintf("all %d checks passed", len(checks))
+}
+
+// describeCheck formats the message of the check at position `i` (0-based) of a list of checks.
+func describeCheck(i int, msg string) string {
+	if msg == "" {
+		msg = "<no message>"
+	}
+	return fmt.Sprintf("check #%d: %s", i+1, indentContinuationLines(msg))
+}
+
+// indentContinuationLines indents the lines of a multi-line message after the first one,
+// for them to stay under the bullet of the message in the lists of failures.
+func indentContinuationLines(msg string) string {
+	return strings.ReplaceAll(msg, "\n", "\n    ")
+}
+
+// Any checks that at least one of the provided checks passes.
+//
+// Checks are evaluated in order until one of them passes.
+// If none of them passes, the resulting message lists the messages of every check.
+//
+// Example:
+//
+//	test.Assert(check.Any(t,
+//		func(t test.TestingT) (test.TestingT, bool, string) { return t, errors.Is(err, ErrA), "err is not ErrA" },
+//		func(t test.TestingT) (test.TestingT, bool, string) { return t, errors.Is(err, ErrB), "err is not ErrB" },
+//	))
+func Any(t test.TestingT, checks ...Checker) (test.TestingT, bool, string) {
+	t.Helper()
+
+	failures := make([]string, 0, len(checks))
+	for i, check := range checks {
+		_, result, msg := check(t)
+		if result {
+			return t, true, describeCheck(i, msg)
+		}
+		failures = append(failures, describeCheck(i, msg))
+	}
+
+	if len(failures) == 0 {
+		return t, false, "no checks to pass"
+	}
+
+	return t, false, fmt.Sprintf("none of the %d checks passed:\n  - %s", len(checks), strings.Join(failures, "\n  - "))
+}
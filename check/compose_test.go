@@ -0,0 +1,98 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/krostar/test"
+)
+
+func Test_Lazy(t *testing.T) {
+	var evaluated bool
+
+	lazy := Lazy(func(t test.TestingT) (test.TestingT, bool, string) {
+		evaluated = true
+		return Compare(t, 42, 21)
+	})
+
+	if evaluated {
+		t.Fatal("expected the checker not to be evaluated before being run")
+	}
+
+	result, msg := lazy(t)
+	assertCheck(t, t, result, false, msg, "comparison differs")
+}
+
+func Test_All(t *testing.T) {
+	passing := func(t test.TestingT) (test.TestingT, bool, string) { return ZeroValue(t, 0) }
+	failing := func(t test.TestingT) (test.TestingT, bool, string) { return Compare(t, 42, 21) }
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := All(t, passing, passing)
+		assertCheck(t, tt, result, true, msg, "all 2 checks passed")
+
+		tt, result, msg = All(t)
+		assertCheck(t, tt, result, true, msg, "all 0 checks passed")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := All(t, passing, failing, passing, failing, func(t test.TestingT) (test.TestingT, bool, string) { return t, false, "" })
+		assertCheck(t, tt, result, false, msg,
+			"3 of 5 checks failed:\n",
+			"  - check #2: comparison differs",
+			"  - check #4: comparison differs",
+			"  - check #5: <no message>",
+		)
+
+		tt, result, msg = All(t, passing, func(t test.TestingT) (test.TestingT, bool, string) { return t, false, "first line\nsecond line" })
+		assertCheck(t, tt, result, false, msg, "1 of 2 checks failed:\n  - check #2: first line\n    second line")
+	})
+}
+
+func Test_Group(t *testing.T) {
+	passing := func(t test.TestingT) (test.TestingT, bool, string) { return ZeroValue(t, 0) }
+	failing := func(t test.TestingT) (test.TestingT, bool, string) { return Compare(t, 42, 21) }
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Group(t, map[string]Checker{"name": passing, "age": passing})
+		assertCheck(t, tt, result, true, msg, "all 2 checks passed")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Group(t, map[string]Checker{
+			"name":  failing,
+			"age":   passing,
+			"email": func(t test.TestingT) (test.TestingT, bool, string) { return t, false, "" },
+		})
+		assertCheck(t, tt, result, false, msg, "2 of 3 checks failed:\n  - email: <no message>\n  - name: comparison differs")
+
+		tt, result, msg = Group(t, map[string]Checker{
+			"name": func(t test.TestingT) (test.TestingT, bool, string) { return t, false, "first line\nsecond line" },
+		})
+		assertCheck(t, tt, result, false, msg, "1 of 1 checks failed:\n  - name: first line\n    second line")
+	})
+}
+
+func Test_Any(t *testing.T) {
+	passing := func(t test.TestingT) (test.TestingT, bool, string) { return ZeroValue(t, 0) }
+	failing := func(t test.TestingT) (test.TestingT, bool, string) { return Compare(t, 42, 21) }
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Any(t, failing, passing, failing)
+		assertCheck(t, tt, result, true, msg, "check #2: 0 is the zero value of type int")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Any(t, failing, func(t test.TestingT) (test.TestingT, bool, string) { return t, false, "" })
+		assertCheck(t, tt, result, false, msg,
+			"none of the 2 checks passed:\n",
+			"  - check #1: comparison differs",
+			"  - check #2: <no message>",
+		)
+
+		tt, result, msg = Any(t, func(t test.TestingT) (test.TestingT, bool, string) { return t, false, "first line\nsecond line" })
+		assertCheck(t, tt, result, false, msg, "none of the 1 checks passed:\n  - check #1: first line\n    second line")
+
+		tt, result, msg = Any(t)
+		assertCheck(t, tt, result, false, msg, "no checks to pass")
+	})
+}
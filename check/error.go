@@ -0,0 +1,81 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/internal/diff"
+)
+
+// EqualError checks that the error is not nil, and that its message is equal to `want`.
+// On failure, the message contains a character-level diff of both messages,
+// where [-...-] are characters only in the error message, and {+...+} are characters only in `want`.
+// This is usually used like test.Assert(check.EqualError(t, err, "unable to open config: file does not exist")).
+func EqualError(t test.TestingT, err error, want string) (test.TestingT, bool, string) {
+	if err == nil {
+		return t, false, fmt.Sprintf("expected error with message %q, got nil", want)
+	}
+
+	if got := err.Error(); got != want {
+		return t, false, fmt.Sprintf("error messages differ:\n  got:  %q\n  want: %q\n  diff: %s", got, want, diff.Inline(got, want))
+	}
+
+	return t, true, fmt.Sprintf("error message is %q", want)
+}
+
+// EqualErrorMessagesIgnoringWrapPrefixes checks if two errors have the same root message,
+// regardless of the context added while wrapping them.
+//
+// The root message of an error is the message of the innermost error of its chain (see errors.Unwrap),
+// stripped from any remaining "context: " prefix, like the ones added by fmt.Errorf("failed to X: %v", err).
+// For errors wrapping multiple errors, like the ones returned by errors.Join, it is made of the root messages
+// of each of the wrapped errors, separated by newlines.
+// This avoids brittle assertions on full error messages, which change each time a wrapping layer is refactored.
+// On failure, both full messages are reported.
+// This is usually used like test.Assert(check.EqualErrorMessagesIgnoringWrapPrefixes(t, err, errors.New("connection refused"))).
+func EqualErrorMessagesIgnoringWrapPrefixes(t test.TestingT, got, want error) (test.TestingT, bool, string) {
+	switch {
+	case got == nil && want == nil:
+		return t, true, "both errors are nil"
+	case got == nil:
+		return t, false, fmt.Sprintf("expected error with root message %q, got nil", rootErrorMessage(want))
+	case want == nil:
+		return t, false, fmt.Sprintf("expected nil error, got %q", got.Error())
+	}
+
+	gotRoot, wantRoot := rootErrorMessage(got), rootErrorMessage(want)
+	if gotRoot != wantRoot {
+		return t, false, fmt.Sprintf("root error messages differ: got %q from %q, want %q from %q", gotRoot, got.Error(), wantRoot, want.Error())
+	}
+
+	return t, true, fmt.Sprintf("root error messages are equal: %q", gotRoot)
+}
+
+// rootErrorMessage returns the message of the innermost error of err's chain, without wrap prefixes,
+// or the root messages of the errors err wraps, separated by newlines, if it wraps multiple errors.
+func rootErrorMessage(err error) string {
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if unwrapped := wrapper.Unwrap(); unwrapped != nil {
+			return rootErrorMessage(unwrapped)
+		}
+	case interface{ Unwrap() []error }:
+		var roots []string
+		for _, unwrapped := range wrapper.Unwrap() {
+			if unwrapped != nil {
+				roots = append(roots, rootErrorMessage(unwrapped))
+			}
+		}
+		if len(roots) > 0 {
+			return strings.Join(roots, "\n")
+		}
+	}
+
+	msg := err.Error()
+	if i := strings.LastIndex(msg, ": "); i >= 0 {
+		msg = msg[i+2:]
+	}
+
+	return msg
+}
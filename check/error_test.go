@@ -0,0 +1,60 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_EqualError(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := EqualError(t, fmt.Errorf("unable to dial: %w", errors.New("connection refused")), "unable to dial: connection refused")
+		assertCheck(t, tt, result, true, msg, `error message is "unable to dial: connection refused"`)
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := EqualError(t, errors.New("connection refused"), "connection reset")
+		assertCheck(t, tt, result, false, msg, "error messages differ:\n  got:  \"connection refused\"\n  want: \"connection reset\"\n  diff: connection re[-fu-]se[-d-]{+t+}")
+
+		tt, result, msg = EqualError(t, nil, "connection reset")
+		assertCheck(t, tt, result, false, msg, `expected error with message "connection reset", got nil`)
+	})
+}
+
+func Test_EqualErrorMessagesIgnoringWrapPrefixes(t *testing.T) {
+	errRefused := errors.New("connection refused")
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := EqualErrorMessagesIgnoringWrapPrefixes(t, nil, nil)
+		assertCheck(t, tt, result, true, msg, "both errors are nil")
+
+		tt, result, msg = EqualErrorMessagesIgnoringWrapPrefixes(t,
+			fmt.Errorf("failed to get user: %w", fmt.Errorf("failed to query: %w", errRefused)),
+			errors.New("connection refused"),
+		)
+		assertCheck(t, tt, result, true, msg, `root error messages are equal: "connection refused"`)
+
+		tt, result, msg = EqualErrorMessagesIgnoringWrapPrefixes(t,
+			fmt.Errorf("failed to get user: %v", errRefused),
+			fmt.Errorf("unable to dial: %w", errRefused),
+		)
+		assertCheck(t, tt, result, true, msg, `root error messages are equal: "connection refused"`)
+
+		tt, result, msg = EqualErrorMessagesIgnoringWrapPrefixes(t,
+			fmt.Errorf("failed to sync: %w", errors.Join(fmt.Errorf("node a: %w", errRefused), errors.New("node b: timeout"))),
+			fmt.Errorf("sync: %w, %w", errRefused, errors.New("timeout")),
+		)
+		assertCheck(t, tt, result, true, msg, `root error messages are equal: "connection refused\ntimeout"`)
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := EqualErrorMessagesIgnoringWrapPrefixes(t, nil, fmt.Errorf("failed: %w", errRefused))
+		assertCheck(t, tt, result, false, msg, `expected error with root message "connection refused", got nil`)
+
+		tt, result, msg = EqualErrorMessagesIgnoringWrapPrefixes(t, errRefused, nil)
+		assertCheck(t, tt, result, false, msg, `expected nil error, got "connection refused"`)
+
+		tt, result, msg = EqualErrorMessagesIgnoringWrapPrefixes(t, fmt.Errorf("failed to get user: %w", errRefused), errors.New("timeout"))
+		assertCheck(t, tt, result, false, msg, `root error messages differ: got "connection refused" from "failed to get user: connection refused", want "timeout" from "timeout"`)
+	})
+}
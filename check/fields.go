@@ -0,0 +1,85 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test"
+)
+
+// FieldsEqual checks that the provided fields of two structs are equal, using go-cmp, ignoring every other field.
+//
+// Fields are designated by their path, made of field names separated by dots, like "Address.City",
+// pointers being dereferenced along the way. Paths that do not exist in T make the check fail.
+// Options registered with RegisterCompareOptions are applied before the provided ones,
+// unexported fields therefore require options like gocmp.AllowUnexported.
+// The diff, expensive to build for large values, is only computed when fields differ.
+// This is usually used like test.Assert(check.FieldsEqual(t, got, want, []string{"Name", "Address.City"})).
+func FieldsEqual[T any](t test.TestingT, got, want T, paths []string, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	if len(paths) == 0 {
+		return t, false, "at least one field path must be provided"
+	}
+
+	for _, path := range paths {
+		if err := checkFieldPath(reflect.TypeFor[T](), path); err != nil {
+			return t, false, err.Error()
+		}
+	}
+
+	ignoreOthers := gocmp.FilterPath(func(p gocmp.Path) bool {
+		if _, ok := p.Last().(gocmp.StructField); !ok {
+			return false
+		}
+
+		// fields are kept if they are designated, or if they lead to or are part of designated fields
+		current := fieldPath(p)
+		return !slices.ContainsFunc(paths, func(path string) bool {
+			return path == current || strings.HasPrefix(path, current+".") || strings.HasPrefix(current, path+".")
+		})
+	}, gocmp.Ignore())
+
+	opts := append(compareOptions(gocmpOpts), ignoreOthers)
+	if !gocmp.Equal(got, want, opts...) {
+		return t, false, fmt.Sprintf("fields %s differ: \n%s", strings.Join(paths, ", "), gocmp.Diff(got, want, opts...))
+	}
+
+	return t, true, fmt.Sprintf("fields %s are equal", strings.Join(paths, ", "))
+}
+
+// fieldPath returns the dot-separated names of the struct fields of the path.
+func fieldPath(p gocmp.Path) string {
+	var names []string
+	for _, step := range p {
+		if sf, ok := step.(gocmp.StructField); ok {
+			names = append(names, sf.Name())
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// checkFieldPath returns an error if the dot-separated field path does not exist in typ.
+func checkFieldPath(typ reflect.Type, path string) error {
+	current := typ
+	for name := range strings.SplitSeq(path, ".") {
+		for current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+
+		if current.Kind() != reflect.Struct {
+			return fmt.Errorf("field path %q does not exist in %s: %s is not a struct", path, typ, current)
+		}
+
+		field, ok := current.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("field path %q does not exist in %s: %s has no field %s", path, typ, current, name)
+		}
+
+		current = field.Type
+	}
+
+	return nil
+}
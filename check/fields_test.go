@@ -0,0 +1,50 @@
+package check
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func Test_FieldsEqual(t *testing.T) {
+	type address struct {
+		City    string
+		Country string
+	}
+
+	type user struct {
+		ID      int
+		Name    string
+		Address *address
+		Tags    []string
+		secret  string
+	}
+
+	got := user{ID: 1, Name: "bob", Address: &address{City: "Paris", Country: "FR"}, Tags: []string{"a"}, secret: "x"}
+	want := user{ID: 2, Name: "bob", Address: &address{City: "Paris", Country: "US"}, secret: "y"}
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := FieldsEqual(t, got, want, []string{"Name", "Address.City"})
+		assertCheck(t, tt, result, true, msg, "fields Name, Address.City are equal")
+
+		tt, result, msg = FieldsEqual(t, &got, &want, []string{"Name"})
+		assertCheck(t, tt, result, true, msg, "fields Name are equal")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := FieldsEqual(t, got, want, []string{"Name", "Address"})
+		assertCheck(t, tt, result, false, msg, "fields Name, Address differ: \n", `"FR"`, `"US"`)
+
+		tt, result, msg = FieldsEqual(t, got, want, []string{"ID", "secret"}, gocmp.AllowUnexported(user{}))
+		assertCheck(t, tt, result, false, msg, "fields ID, secret differ: \n", "ID:", "secret:")
+
+		tt, result, msg = FieldsEqual(t, got, want, []string{"Address.Zip"})
+		assertCheck(t, tt, result, false, msg, `field path "Address.Zip" does not exist in check.user: check.address has no field Zip`)
+
+		tt, result, msg = FieldsEqual(t, got, want, []string{"Name.First"})
+		assertCheck(t, tt, result, false, msg, `field path "Name.First" does not exist in check.user: string is not a struct`)
+
+		tt, result, msg = FieldsEqual(t, got, want, nil)
+		assertCheck(t, tt, result, false, msg, "at least one field path must be provided")
+	})
+}
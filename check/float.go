@@ -0,0 +1,56 @@
+package check
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/krostar/test"
+)
+
+// NaN checks if a floating-point value is NaN.
+// This is usually used like test.Assert(check.NaN(t, math.Sqrt(-1))).
+func NaN[F ~float32 | ~float64](t test.TestingT, v F) (test.TestingT, bool, string) {
+	if !math.IsNaN(float64(v)) {
+		return t, false, fmt.Sprintf("expected NaN, got %v", v)
+	}
+	return t, true, "value is NaN"
+}
+
+// NotNaN checks if a floating-point value is not NaN.
+// This is usually used like test.Assert(check.NotNaN(t, ratio)).
+func NotNaN[F ~float32 | ~float64](t test.TestingT, v F) (test.TestingT, bool, string) {
+	if math.IsNaN(float64(v)) {
+		return t, false, "expected value not to be NaN"
+	}
+	return t, true, fmt.Sprintf("%v is not NaN", v)
+}
+
+// Finite checks if a floating-point value is neither NaN nor an infinity.
+// This is usually used like test.Assert(check.Finite(t, ratio)).
+func Finite[F ~float32 | ~float64](t test.TestingT, v F) (test.TestingT, bool, string) {
+	if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+		return t, false, fmt.Sprintf("expected finite value, got %v", v)
+	}
+	return t, true, fmt.Sprintf("%v is finite", v)
+}
+
+// Inf checks if a floating-point value is an infinity, according to sign (see math.IsInf):
+// if sign > 0 the value must be positive infinity, if sign < 0 the value must be negative infinity,
+// and if sign == 0 the value can be either infinity.
+// This is usually used like test.Assert(check.Inf(t, 1/zero, 1)).
+func Inf[F ~float32 | ~float64](t test.TestingT, v F, sign int) (test.TestingT, bool, string) {
+	var expected string
+	switch {
+	case sign > 0:
+		expected = "+Inf"
+	case sign < 0:
+		expected = "-Inf"
+	default:
+		expected = "+Inf or -Inf"
+	}
+
+	if !math.IsInf(float64(v), sign) {
+		return t, false, fmt.Sprintf("expected %s, got %v", expected, v)
+	}
+	return t, true, fmt.Sprintf("value is %v", v)
+}
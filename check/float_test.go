@@ -0,0 +1,72 @@
+package check
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_NaN(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NaN(t, math.NaN())
+		assertCheck(t, tt, result, true, msg, "value is NaN")
+
+		tt, result, msg = NaN(t, float32(math.NaN()))
+		assertCheck(t, tt, result, true, msg, "value is NaN")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NaN(t, 4.2)
+		assertCheck(t, tt, result, false, msg, "expected NaN, got 4.2")
+	})
+}
+
+func Test_NotNaN(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NotNaN(t, math.Inf(1))
+		assertCheck(t, tt, result, true, msg, "+Inf is not NaN")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NotNaN(t, math.NaN())
+		assertCheck(t, tt, result, false, msg, "expected value not to be NaN")
+	})
+}
+
+func Test_Finite(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Finite(t, float32(4.2))
+		assertCheck(t, tt, result, true, msg, "4.2 is finite")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Finite(t, math.NaN())
+		assertCheck(t, tt, result, false, msg, "expected finite value, got NaN")
+
+		tt, result, msg = Finite(t, math.Inf(-1))
+		assertCheck(t, tt, result, false, msg, "expected finite value, got -Inf")
+	})
+}
+
+func Test_Inf(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Inf(t, math.Inf(1), 1)
+		assertCheck(t, tt, result, true, msg, "value is +Inf")
+
+		tt, result, msg = Inf(t, math.Inf(-1), -1)
+		assertCheck(t, tt, result, true, msg, "value is -Inf")
+
+		tt, result, msg = Inf(t, math.Inf(-1), 0)
+		assertCheck(t, tt, result, true, msg, "value is -Inf")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Inf(t, math.Inf(-1), 1)
+		assertCheck(t, tt, result, false, msg, "expected +Inf, got -Inf")
+
+		tt, result, msg = Inf(t, 42., -1)
+		assertCheck(t, tt, result, false, msg, "expected -Inf, got 42")
+
+		tt, result, msg = Inf(t, math.NaN(), 0)
+		assertCheck(t, tt, result, false, msg, "expected +Inf or -Inf, got NaN")
+	})
+}
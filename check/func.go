@@ -0,0 +1,80 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/krostar/test"
+)
+
+// FuncSignatureEqual checks that `fn` is a function whose signature is the one of the function type F.
+// Named function types are compared by their underlying signature.
+//
+// On failure, the message lists every parameter and result whose type differs.
+// This is useful for plugin systems checking callbacks registered as `any`:
+//
+//	test.Assert(check.FuncSignatureEqual[func(context.Context, Event) error](t, registry.Handler("created")))
+func FuncSignatureEqual[F any](t test.TestingT, fn any) (test.TestingT, bool, string) {
+	want := reflect.TypeFor[F]()
+	if want.Kind() != reflect.Func {
+		return t, false, fmt.Sprintf("%s is not a function type", want)
+	}
+
+	got := reflect.TypeOf(fn)
+	if got == nil || got.Kind() != reflect.Func {
+		return t, false, fmt.Sprintf("%T is not a function", fn)
+	}
+
+	var mismatches []string
+
+	mismatches = append(mismatches, describeTypesMismatches("parameter", got.NumIn(), want.NumIn(), got.In, want.In)...)
+	mismatches = append(mismatches, describeTypesMismatches("result", got.NumOut(), want.NumOut(), got.Out, want.Out)...)
+
+	if got.IsVariadic() != want.IsVariadic() {
+		mismatches = append(mismatches, fmt.Sprintf("got variadic %t, want variadic %t", got.IsVariadic(), want.IsVariadic()))
+	}
+
+	if len(mismatches) > 0 {
+		return t, false, fmt.Sprintf("signature %s differs from %s:\n  - %s", funcSignature(got), funcSignature(want), strings.Join(mismatches, "\n  - "))
+	}
+
+	return t, true, fmt.Sprintf("signature is %s", funcSignature(want))
+}
+
+// describeTypesMismatches lists the positions (1-based) of a list of types whose types differ, along with missing and unexpected ones.
+func describeTypesMismatches(kind string, gotNum, wantNum int, gotType, wantType func(int) reflect.Type) []string {
+	var mismatches []string
+
+	for i := range max(gotNum, wantNum) {
+		switch {
+		case i >= gotNum:
+			mismatches = append(mismatches, fmt.Sprintf("%s #%d: missing, want %s", kind, i+1, wantType(i)))
+		case i >= wantNum:
+			mismatches = append(mismatches, fmt.Sprintf("%s #%d: got %s, want none", kind, i+1, gotType(i)))
+		case gotType(i) != wantType(i):
+			mismatches = append(mismatches, fmt.Sprintf("%s #%d: got %s, want %s", kind, i+1, gotType(i), wantType(i)))
+		}
+	}
+
+	return mismatches
+}
+
+// funcSignature returns the signature of the function type, without its name.
+func funcSignature(typ reflect.Type) string {
+	if typ.Name() == "" {
+		return typ.String()
+	}
+
+	in := make([]reflect.Type, typ.NumIn())
+	for i := range in {
+		in[i] = typ.In(i)
+	}
+
+	out := make([]reflect.Type, typ.NumOut())
+	for i := range out {
+		out[i] = typ.Out(i)
+	}
+
+	return reflect.FuncOf(in, out, typ.IsVariadic()).String()
+}
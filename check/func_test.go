@@ -0,0 +1,43 @@
+package check
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func Test_FuncSignatureEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := FuncSignatureEqual[func(context.Context, string) error](t, func(context.Context, string) error { return nil })
+		assertCheck(t, tt, result, true, msg, "signature is func(context.Context, string) error")
+
+		tt, result, msg = FuncSignatureEqual[func(http.ResponseWriter, *http.Request)](t, http.HandlerFunc(http.NotFound))
+		assertCheck(t, tt, result, true, msg, "signature is func(http.ResponseWriter, *http.Request)")
+
+		tt, result, msg = FuncSignatureEqual[http.HandlerFunc](t, http.NotFound)
+		assertCheck(t, tt, result, true, msg, "signature is func(http.ResponseWriter, *http.Request)")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := FuncSignatureEqual[func(context.Context, string) error](t, func(string, int) (int, error) { return 0, nil })
+		assertCheck(t, tt, result, false, msg,
+			"signature func(string, int) (int, error) differs from func(context.Context, string) error:\n",
+			"  - parameter #1: got string, want context.Context\n",
+			"  - parameter #2: got int, want string\n",
+			"  - result #1: got int, want error\n",
+			"  - result #2: got error, want none",
+		)
+
+		tt, result, msg = FuncSignatureEqual[func(string, ...int)](t, func(string) {})
+		assertCheck(t, tt, result, false, msg, "  - parameter #2: missing, want []int\n  - got variadic false, want variadic true")
+
+		tt, result, msg = FuncSignatureEqual[func()](t, 42)
+		assertCheck(t, tt, result, false, msg, "int is not a function")
+
+		tt, result, msg = FuncSignatureEqual[func()](t, nil)
+		assertCheck(t, tt, result, false, msg, "<nil> is not a function")
+
+		tt, result, msg = FuncSignatureEqual[string](t, func() {})
+		assertCheck(t, tt, result, false, msg, "string is not a function type")
+	})
+}
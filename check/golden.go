@@ -0,0 +1,81 @@
+package check
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/internal/diff"
+	"github.com/krostar/test/testingt"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	// UpdateGoldenFiles controls whether golden files and baselines are updated with the actual values, instead of being compared to them.
+	UpdateGoldenFiles      = false
+	_flagUpdateGoldenFiles = flag.Bool("check.update-golden-files", false, "Whether to update golden files and baselines with the actual values instead of comparing them")
+)
+
+// GoldenEqual checks that `got` is equal to the content of the golden file located at `goldenPath`,
+// after applying the normalizations of the provided options to both.
+//
+// If `goldenPath` is empty, it is derived from the name of the test: testdata/<test name>.golden,
+// subtests being stored in sub-directories.
+//
+// When tests are run with the -check.update-golden-files flag, or if UpdateGoldenFiles is true,
+// the golden file is written with `got` instead.
+// On failure, the message contains a line-based diff of the normalized contents.
+// This is usually used like test.Assert(check.GoldenEqual(t, output, "testdata/output.golden")).
+func GoldenEqual(t test.TestingT, got, goldenPath string, opts ...StringOption) (test.TestingT, bool, string) {
+	if goldenPath == "" {
+		name := testingt.Innermost(t).Name()
+		if name == "" {
+			return t, false, "golden file path must be provided when the test name is unknown"
+		}
+		goldenPath = filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+	}
+
+	if UpdateGoldenFiles || *_flagUpdateGoldenFiles {
+		if err := writeGoldenFile(goldenPath, got); err != nil {
+			return t, false, err.Error()
+		}
+		return t, true, fmt.Sprintf("golden file %s updated", goldenPath)
+	}
+
+	raw, err := os.ReadFile(goldenPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return t, false, fmt.Sprintf("golden file %s does not exist, run tests with -check.update-golden-files to create it", goldenPath)
+		}
+		return t, false, fmt.Sprintf("unable to read golden file %s: %v", goldenPath, err)
+	}
+
+	var o stringOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if got, want := o.normalize(got), o.normalize(string(raw)); got != want {
+		return t, false, fmt.Sprintf("content differs from golden file %s (-got +want):\n%s", goldenPath, diff.Unified(strings.Split(got, "\n"), strings.Split(want, "\n"), 3))
+	}
+
+	return t, true, fmt.Sprintf("content is equal to golden file %s", goldenPath)
+}
+
+// writeGoldenFile writes the content to the golden file, creating its directories if needed.
+func writeGoldenFile(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("unable to create golden file %s directory: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("unable to write golden file %s: %v", path, err)
+	}
+
+	return nil
+}
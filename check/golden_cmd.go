@@ -0,0 +1,104 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/krostar/test"
+)
+
+// GoldenCmdOption is a function that configures how GoldenCmd handles the output before comparing it.
+type GoldenCmdOption func(o *goldenCmdOptions)
+
+// GoldenCmdScrub makes GoldenCmd replace the matches of `pattern` in the output by `replacement`,
+// which can reference submatches like in regexp.Regexp.ReplaceAllString.
+// Rewrites are applied in the order of the options.
+func GoldenCmdScrub(pattern *regexp.Regexp, replacement string) GoldenCmdOption {
+	return func(o *goldenCmdOptions) {
+		o.rewrites = append(o.rewrites, goldenCmdRewrite{pattern: pattern, replacement: replacement})
+	}
+}
+
+// GoldenCmdScrubTempDirs makes GoldenCmd replace the paths of the directories created in the
+// temporary directory, like the ones created by testing.T.TempDir, by <tmpdir>.
+func GoldenCmdScrubTempDirs() GoldenCmdOption {
+	return func(o *goldenCmdOptions) {
+		roots := []string{os.TempDir()}
+		if resolved, err := filepath.EvalSymlinks(roots[0]); err == nil && resolved != roots[0] {
+			roots = append(roots, resolved)
+		}
+
+		for _, root := range roots {
+			o.rewrites = append(o.rewrites, goldenCmdRewrite{
+				pattern:     regexp.MustCompile(regexp.QuoteMeta(root) + `[/\\][^\s/\\"']+`),
+				replacement: "<tmpdir>",
+			})
+		}
+	}
+}
+
+// GoldenCmdScrubPorts makes GoldenCmd replace the ports of local addresses, like 127.0.0.1:34567, by <port>.
+func GoldenCmdScrubPorts() GoldenCmdOption {
+	return GoldenCmdScrub(regexp.MustCompile(`(localhost|127\.0\.0\.1|0\.0\.0\.0|\[::1?\]):\d+`), "$1:<port>")
+}
+
+// GoldenCmdScrubDurations makes GoldenCmd replace durations formatted like time.Duration.String, like 1m2.5s, by <duration>.
+func GoldenCmdScrubDurations() GoldenCmdOption {
+	return GoldenCmdScrub(regexp.MustCompile(`\b(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+\b`), "<duration>")
+}
+
+// GoldenCmdAllowFailure makes GoldenCmd compare the output even if producing it failed,
+// which is useful to check the output of commands expected to fail.
+func GoldenCmdAllowFailure() GoldenCmdOption {
+	return func(o *goldenCmdOptions) { o.allowFailure = true }
+}
+
+// GoldenCmdStringOptions makes GoldenCmd apply the provided normalizations when comparing the output to the golden file.
+func GoldenCmdStringOptions(opts ...StringOption) GoldenCmdOption {
+	return func(o *goldenCmdOptions) { o.stringOpts = append(o.stringOpts, opts...) }
+}
+
+type goldenCmdOptions struct {
+	rewrites     []goldenCmdRewrite
+	allowFailure bool
+	stringOpts   []StringOption
+}
+
+type goldenCmdRewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// GoldenCmd checks that the output produced by `output`, once scrubbed of environment-specific substrings,
+// is equal to the content of the golden file located at `goldenPath`, using GoldenEqual.
+//
+// The output is usually produced by running a command, in which case exec.Cmd.CombinedOutput or exec.Cmd.Output
+// can be provided directly, but any function producing output can be used.
+// If producing the output fails, the check fails, unless GoldenCmdAllowFailure is provided.
+// The golden file always contains the scrubbed output, including when it is updated.
+//
+// This is usually used like:
+//
+//	test.Assert(check.GoldenCmd(t, exec.Command("./app", "--help").CombinedOutput, "testdata/help.golden",
+//		check.GoldenCmdScrubTempDirs(), check.GoldenCmdScrubDurations(),
+//	))
+func GoldenCmd(t test.TestingT, output func() ([]byte, error), goldenPath string, opts ...GoldenCmdOption) (test.TestingT, bool, string) {
+	var o goldenCmdOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	raw, err := output()
+	if err != nil && !o.allowFailure {
+		return t, false, fmt.Sprintf("unable to produce output: %v\n%s", err, raw)
+	}
+
+	got := string(raw)
+	for _, rewrite := range o.rewrites {
+		got = rewrite.pattern.ReplaceAllString(got, rewrite.replacement)
+	}
+
+	return GoldenEqual(t, got, goldenPath, o.stringOpts...)
+}
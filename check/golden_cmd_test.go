@@ -0,0 +1,47 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_GoldenCmd(t *testing.T) {
+	dir := t.TempDir()
+
+	output := func() ([]byte, error) {
+		return fmt.Appendf(nil, "serving on 127.0.0.1:%d\nready in %s\nwrote %s\n",
+			40000+time.Now().Nanosecond()%20000, time.Duration(time.Now().Nanosecond()), filepath.Join(dir, "out.txt"),
+		), nil
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := GoldenCmd(t, output, "testdata/golden_cmd.golden",
+			GoldenCmdScrubPorts(), GoldenCmdScrubDurations(), GoldenCmdScrubTempDirs(),
+			GoldenCmdScrub(regexp.MustCompile(`(<tmpdir>)[/\\]`), "$1/"),
+		)
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/golden_cmd.golden")
+
+		tt, result, msg = GoldenCmd(t, func() ([]byte, error) {
+			return []byte("SERVING ON 127.0.0.1:1234\nready in 1m2.5s\nwrote " + os.TempDir() + "/random/001/out.txt\n"), errors.New("exit status 1")
+		}, "testdata/golden_cmd.golden",
+			GoldenCmdScrubPorts(), GoldenCmdScrubDurations(), GoldenCmdScrubTempDirs(), GoldenCmdAllowFailure(),
+			GoldenCmdStringOptions(StringIgnoreCase()),
+		)
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/golden_cmd.golden")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := GoldenCmd(t, output, "testdata/golden_cmd.golden", GoldenCmdScrubPorts())
+		assertCheck(t, tt, result, false, msg, "content differs from golden file testdata/golden_cmd.golden (-got +want):\n", "+ready in <duration>")
+
+		tt, result, msg = GoldenCmd(t, func() ([]byte, error) {
+			return []byte("usage: app"), errors.New("exit status 2")
+		}, "testdata/golden_cmd.golden")
+		assertCheck(t, tt, result, false, msg, "unable to produce output: exit status 2\nusage: app")
+	})
+}
@@ -0,0 +1,56 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_GoldenEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := GoldenEqual(t, "Hello  Bob,\nwelcome!\n", "testdata/template.golden")
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/template.golden")
+
+		tt, result, msg = GoldenEqual(t, "Hello Bob, welcome!", "testdata/template.golden", StringCollapseWhitespace())
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/template.golden")
+	})
+
+	t.Run("derived path", func(t *testing.T) {
+		tt, result, msg := GoldenEqual(t, "derived\n", "")
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/Test_GoldenEqual/derived_path.golden")
+
+		_, result, msg = GoldenEqual(double.NewFake(), "derived\n", "")
+		if result || msg != "golden file path must be provided when the test name is unknown" {
+			t.Errorf("unexpected result %t with message %q", result, msg)
+		}
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := GoldenEqual(t, "Hello Alice,\nwelcome!\n", "testdata/template.golden")
+		assertCheck(t, tt, result, false, msg, "content differs from golden file testdata/template.golden (-got +want):\n@@ -1,3 +1,3 @@\n-Hello Alice,\n+Hello  Bob,\n welcome!\n \n")
+
+		tt, result, msg = GoldenEqual(t, "", "testdata/notexisting.golden")
+		assertCheck(t, tt, result, false, msg, "golden file testdata/notexisting.golden does not exist, run tests with -check.update-golden-files to create it")
+
+		tt, result, msg = GoldenEqual(t, "", "testdata")
+		assertCheck(t, tt, result, false, msg, "unable to read golden file testdata")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		originalUpdateGoldenFiles := UpdateGoldenFiles
+		t.Cleanup(func() { UpdateGoldenFiles = originalUpdateGoldenFiles })
+
+		UpdateGoldenFiles = true
+
+		path := filepath.Join(t.TempDir(), "sub", "file.golden")
+
+		tt, result, msg := GoldenEqual(t, "updated", path)
+		assertCheck(t, tt, result, true, msg, "golden file "+path+" updated")
+
+		if raw, err := os.ReadFile(path); err != nil || string(raw) != "updated" {
+			t.Errorf("expected golden file to be updated, got %q: %v", raw, err)
+		}
+	})
+}
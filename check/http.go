@@ -0,0 +1,88 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test"
+)
+
+// HTTPStatus checks that the response has the expected status code.
+// On failure, the response body is part of the message, and remains readable for further checks.
+// Responses recorded with httptest.ResponseRecorder can be checked using its Result method.
+// This is usually used like test.Assert(check.HTTPStatus(t, resp, http.StatusOK)).
+func HTTPStatus(t test.TestingT, resp *http.Response, want int) (test.TestingT, bool, string) {
+	if resp == nil {
+		return t, false, "response must not be nil"
+	}
+
+	if resp.StatusCode != want {
+		body, err := readHTTPBody(resp)
+		if err != nil {
+			body = []byte(fmt.Sprintf("<unable to read body: %v>", err))
+		}
+		return t, false, fmt.Sprintf("expected status %d %s, got %d %s with body: %s", want, http.StatusText(want), resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	}
+
+	return t, true, fmt.Sprintf("status is %d %s", want, http.StatusText(want))
+}
+
+// HTTPHeader checks that the response header `key` has the expected value.
+// If the header has multiple values, the first one is checked.
+// This is usually used like test.Assert(check.HTTPHeader(t, resp, "Content-Type", "application/json")).
+func HTTPHeader(t test.TestingT, resp *http.Response, key, want string) (test.TestingT, bool, string) {
+	if resp == nil {
+		return t, false, "response must not be nil"
+	}
+
+	values := resp.Header.Values(key)
+	switch {
+	case len(values) == 0:
+		return t, false, fmt.Sprintf("expected header %s to be %q, but header is not set", key, want)
+	case values[0] != want:
+		return t, false, fmt.Sprintf("expected header %s to be %q, got %q", key, want, values)
+	default:
+		return t, true, fmt.Sprintf("header %s is %q", key, want)
+	}
+}
+
+// HTTPBodyJSON checks that the response body decodes as JSON into a value equal to `want`, using Compare.
+// The body is read in a way that keeps it readable for further checks.
+// This is usually used like test.Assert(check.HTTPBodyJSON(t, resp, map[string]any{"id": "42"})).
+func HTTPBodyJSON[T any](t test.TestingT, resp *http.Response, want T, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	if resp == nil {
+		return t, false, "response must not be nil"
+	}
+
+	body, err := readHTTPBody(resp)
+	if err != nil {
+		return t, false, fmt.Sprintf("unable to read response body: %v", err)
+	}
+
+	var got T
+	if err := json.Unmarshal(body, &got); err != nil {
+		return t, false, fmt.Sprintf("unable to decode response body %q as JSON into %T: %v", body, got, err)
+	}
+
+	return Compare(t, got, want, gocmpOpts...)
+}
+
+// readHTTPBody reads the whole response body and replaces it with an in-memory copy
+// so that the body can be read again.
+func readHTTPBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, err
+}
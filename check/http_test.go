@@ -0,0 +1,104 @@
+package check
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_HTTPStatus(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := HTTPStatus(t, newHTTPResponse(http.StatusCreated, nil, ""), http.StatusCreated)
+		assertCheck(t, tt, result, true, msg, "status is 201 Created")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		resp := newHTTPResponse(http.StatusInternalServerError, nil, "boom")
+
+		tt, result, msg := HTTPStatus(t, resp, http.StatusOK)
+		assertCheck(t, tt, result, false, msg, "expected status 200 OK, got 500 Internal Server Error with body: boom")
+
+		if body, err := io.ReadAll(resp.Body); err != nil || string(body) != "boom" {
+			t.Errorf("expected body to still be readable, got %q and %v", body, err)
+		}
+
+		resp.Body = io.NopCloser(iotest.ErrReader(errors.New("boom")))
+		tt, result, msg = HTTPStatus(t, resp, http.StatusOK)
+		assertCheck(t, tt, result, false, msg, "with body: <unable to read body: boom>")
+
+		tt, result, msg = HTTPStatus(t, nil, http.StatusOK)
+		assertCheck(t, tt, result, false, msg, "response must not be nil")
+	})
+}
+
+func Test_HTTPHeader(t *testing.T) {
+	resp := newHTTPResponse(http.StatusOK, http.Header{"Content-Type": {"application/json", "text/plain"}}, "")
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := HTTPHeader(t, resp, "content-type", "application/json")
+		assertCheck(t, tt, result, true, msg, `header content-type is "application/json"`)
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := HTTPHeader(t, resp, "Content-Type", "text/plain")
+		assertCheck(t, tt, result, false, msg, `expected header Content-Type to be "text/plain", got ["application/json" "text/plain"]`)
+
+		tt, result, msg = HTTPHeader(t, resp, "X-Request-Id", "42")
+		assertCheck(t, tt, result, false, msg, `expected header X-Request-Id to be "42", but header is not set`)
+
+		tt, result, msg = HTTPHeader(t, nil, "X-Request-Id", "42")
+		assertCheck(t, tt, result, false, msg, "response must not be nil")
+	})
+}
+
+func Test_HTTPBodyJSON(t *testing.T) {
+	type payload struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusOK)
+		_, _ = rec.WriteString(`{"id": "42", "name": "bob"}`)
+		resp := rec.Result()
+
+		tt, result, msg := HTTPBodyJSON(t, resp, payload{ID: "42", Name: "bob"})
+		assertCheck(t, tt, result, true, msg, "no differences")
+
+		tt, result, msg = HTTPBodyJSON(t, resp, map[string]any{"id": "42", "name": "bob"})
+		assertCheck(t, tt, result, true, msg, "no differences")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := HTTPBodyJSON(t, newHTTPResponse(http.StatusOK, nil, `{"id": "42"}`), payload{ID: "21"})
+		assertCheck(t, tt, result, false, msg, "comparison differs")
+
+		tt, result, msg = HTTPBodyJSON(t, newHTTPResponse(http.StatusOK, nil, `not json`), payload{ID: "21"})
+		assertCheck(t, tt, result, false, msg, `unable to decode response body "not json" as JSON into check.payload`)
+
+		tt, result, msg = HTTPBodyJSON(t, &http.Response{Body: io.NopCloser(iotest.ErrReader(errors.New("boom")))}, payload{})
+		assertCheck(t, tt, result, false, msg, "unable to read response body: boom")
+
+		tt, result, msg = HTTPBodyJSON(t, &http.Response{Body: http.NoBody}, payload{})
+		assertCheck(t, tt, result, false, msg, "unable to decode response body")
+
+		tt, result, msg = HTTPBodyJSON(t, nil, payload{})
+		assertCheck(t, tt, result, false, msg, "response must not be nil")
+	})
+}
+
+func newHTTPResponse(status int, header http.Header, body string) *http.Response {
+	rec := httptest.NewRecorder()
+	for key, values := range header {
+		for _, value := range values {
+			rec.Header().Add(key, value)
+		}
+	}
+	rec.WriteHeader(status)
+	_, _ = rec.WriteString(body)
+	return rec.Result()
+}
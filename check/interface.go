@@ -0,0 +1,42 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/krostar/test"
+)
+
+// SupportsOptionalInterface checks that `v` implements the interface I.
+//
+// The `assertImpl` argument is an optional function that can be used to assert on the behavior of the implementation.
+// The message distinguishes values that do not implement I from values implementing I in an unexpected way.
+//
+// This is useful to test wrappers that are expected to forward optional interfaces, like http.ResponseWriter wrappers:
+//
+//	test.Assert(check.SupportsOptionalInterface(t, w, func(f http.Flusher) error {
+//		f.Flush()
+//		if !underlying.Flushed {
+//			return errors.New("flush was not forwarded")
+//		}
+//		return nil
+//	}))
+func SupportsOptionalInterface[I any](t test.TestingT, v any, assertImpl func(I) error) (test.TestingT, bool, string) {
+	typ := reflect.TypeFor[I]()
+	if typ.Kind() != reflect.Interface {
+		return t, false, fmt.Sprintf("%s is not an interface type", typ)
+	}
+
+	impl, ok := v.(I)
+	if !ok {
+		return t, false, fmt.Sprintf("%T does not implement %s", v, typ)
+	}
+
+	if assertImpl != nil {
+		if err := assertImpl(impl); err != nil {
+			return t, false, fmt.Sprintf("%T implements %s, but implementation assertion failed: %v", v, typ, err)
+		}
+	}
+
+	return t, true, fmt.Sprintf("%T implements %s", v, typ)
+}
@@ -0,0 +1,38 @@
+package check
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func Test_SupportsOptionalInterface(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := SupportsOptionalInterface[io.Reader](t, strings.NewReader("hello"), nil)
+		assertCheck(t, tt, result, true, msg, "*strings.Reader implements io.Reader")
+
+		tt, result, msg = SupportsOptionalInterface(t, slog.LevelWarn, func(l slog.Leveler) error {
+			if l.Level() != slog.LevelWarn {
+				return errors.New("unexpected level")
+			}
+			return nil
+		})
+		assertCheck(t, tt, result, true, msg, "slog.Level implements slog.Leveler")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := SupportsOptionalInterface[io.Writer](t, strings.NewReader("hello"), nil)
+		assertCheck(t, tt, result, false, msg, "*strings.Reader does not implement io.Writer")
+
+		tt, result, msg = SupportsOptionalInterface[io.Writer](t, nil, nil)
+		assertCheck(t, tt, result, false, msg, "<nil> does not implement io.Writer")
+
+		tt, result, msg = SupportsOptionalInterface(t, slog.LevelWarn, func(slog.Leveler) error { return errors.New("unexpected level") })
+		assertCheck(t, tt, result, false, msg, "slog.Level implements slog.Leveler, but implementation assertion failed: unexpected level")
+
+		tt, result, msg = SupportsOptionalInterface[*strings.Reader](t, strings.NewReader("hello"), nil)
+		assertCheck(t, tt, result, false, msg, "*strings.Reader is not an interface type")
+	})
+}
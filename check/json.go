@@ -0,0 +1,192 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test"
+)
+
+// JSONOption is a function that configures how JSON documents are compared by CompareJSON.
+type JSONOption func(o *jsonOptions)
+
+// JSONIgnorePaths makes the comparison ignore the values located at the provided paths in both documents.
+//
+// Paths use a JSONPath-like syntax: they start with $ which is the document root,
+// followed by `.key` to select an object member, `[n]` to select an array element,
+// and `.*` or `[*]` to select every member or element.
+// For instance `$.id`, `$.items[*].created_at`, or `$.users[0].*`.
+func JSONIgnorePaths(paths ...string) JSONOption {
+	return func(o *jsonOptions) { o.ignorePaths = append(o.ignorePaths, paths...) }
+}
+
+type jsonOptions struct {
+	ignorePaths []string
+}
+
+// CompareJSON checks if two JSON documents are equivalent, regardless of formatting and object members order.
+// Options can be provided to ignore parts of the documents, like generated identifiers or timestamps.
+// This is usually used like test.Assert(check.CompareJSON(t, body, `{"id":"","name":"bob"}`, check.JSONIgnorePaths("$.id"))).
+func CompareJSON[D ~string | ~[]byte](t test.TestingT, got, want D, opts ...JSONOption) (test.TestingT, bool, string) {
+	var o jsonOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	gotDoc, err := decodeJSON([]byte(got))
+	if err != nil {
+		return t, false, fmt.Sprintf("unable to decode got JSON document: %v", err)
+	}
+
+	wantDoc, err := decodeJSON([]byte(want))
+	if err != nil {
+		return t, false, fmt.Sprintf("unable to decode want JSON document: %v", err)
+	}
+
+	for _, path := range o.ignorePaths {
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			return t, false, fmt.Sprintf("invalid ignored path %q: %v", path, err)
+		}
+
+		gotDoc = removeJSONPath(gotDoc, segments)
+		wantDoc = removeJSONPath(wantDoc, segments)
+	}
+
+	if diff := gocmp.Diff(gotDoc, wantDoc); diff != "" {
+		return t, false, "JSON documents differ (-got +want):\n" + diff
+	}
+
+	return t, true, "JSON documents are equivalent"
+}
+
+// decodeJSON decodes a single JSON document, keeping numbers as they are written.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the top-level value at offset %d", decoder.InputOffset())
+	}
+
+	return doc, nil
+}
+
+// jsonPathSegment is a single step of a path, selecting an object member, an array element, or every member or element.
+type jsonPathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONPath parses a path as described in JSONIgnorePaths.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("path must start with $")
+	}
+
+	var segments []jsonPathSegment
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("empty member name")
+			}
+
+			segments = append(segments, jsonPathSegment{key: key, wildcard: key == "*"})
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing bracket")
+			}
+
+			if selector := rest[1:end]; selector == "*" {
+				segments = append(segments, jsonPathSegment{isIndex: true, wildcard: true})
+			} else {
+				index, err := strconv.Atoi(selector)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid array index %q", selector)
+				}
+				segments = append(segments, jsonPathSegment{isIndex: true, index: index})
+			}
+
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q, expected . or [", rest[0])
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path must select something else than the document root")
+	}
+
+	return segments, nil
+}
+
+// removeJSONPath removes from the decoded document the values selected by the path.
+// Selected object members are deleted, and selected array elements are replaced by null to keep other elements position.
+func removeJSONPath(doc any, segments []jsonPathSegment) any {
+	segment, last := segments[0], len(segments) == 1
+
+	switch v := doc.(type) {
+	case map[string]any:
+		if segment.isIndex {
+			return doc
+		}
+
+		for key, member := range v {
+			if !segment.wildcard && key != segment.key {
+				continue
+			}
+
+			if last {
+				delete(v, key)
+			} else {
+				v[key] = removeJSONPath(member, segments[1:])
+			}
+		}
+
+	case []any:
+		if !segment.isIndex {
+			return doc
+		}
+
+		for i, element := range v {
+			if !segment.wildcard && i != segment.index {
+				continue
+			}
+
+			if last {
+				v[i] = nil
+			} else {
+				v[i] = removeJSONPath(element, segments[1:])
+			}
+		}
+	}
+
+	return doc
+}
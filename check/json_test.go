@@ -0,0 +1,75 @@
+package check
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func Test_CompareJSON(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := CompareJSON(t, `{"a": 1, "b": [true, null]}`, `{"b":[true,null],"a":1}`)
+		assertCheck(t, tt, result, true, msg, "JSON documents are equivalent")
+
+		tt, result, msg = CompareJSON(t,
+			[]byte(`{"id": "1234", "items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}], "meta": {"x": 1, "y": 2}}`),
+			[]byte(`{"items": [{"name": "a"}, {"id": 42, "name": "b"}], "meta": {"z": 3}}`),
+			JSONIgnorePaths("$.id", "$.items[*].id"),
+			JSONIgnorePaths("$.meta.*"),
+		)
+		assertCheck(t, tt, result, true, msg, "JSON documents are equivalent")
+
+		tt, result, msg = CompareJSON(t, `[1, 2, 3]`, `[1, 4, 3]`, JSONIgnorePaths("$[1]"))
+		assertCheck(t, tt, result, true, msg, "JSON documents are equivalent")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := CompareJSON(t, `{"id": 1, "name": "bob"}`, `{"id": 2, "name": "alice"}`, JSONIgnorePaths("$.id"))
+		assertCheck(t, tt, result, false, msg, "JSON documents differ (-got +want):", `"bob"`, `"alice"`)
+
+		tt, result, msg = CompareJSON(t, `1.000000000000000001`, `1`)
+		assertCheck(t, tt, result, false, msg, "JSON documents differ")
+
+		tt, result, msg = CompareJSON(t, `{`, `{}`)
+		assertCheck(t, tt, result, false, msg, "unable to decode got JSON document")
+
+		tt, result, msg = CompareJSON(t, `{}`, `{} {}`)
+		assertCheck(t, tt, result, false, msg, "unable to decode want JSON document: unexpected data after the top-level value at offset 3")
+
+		tt, result, msg = CompareJSON(t, `{}`, `{}`, JSONIgnorePaths("id"))
+		assertCheck(t, tt, result, false, msg, `invalid ignored path "id": path must start with $`)
+	})
+}
+
+func Test_parseJSONPath(t *testing.T) {
+	for path, expected := range map[string][]jsonPathSegment{
+		"$.id":                {{key: "id"}},
+		"$.items[*].id":       {{key: "items"}, {isIndex: true, wildcard: true}, {key: "id"}},
+		"$[2].*":              {{isIndex: true, index: 2}, {key: "*", wildcard: true}},
+		"$.a.b_c[10][0].name": {{key: "a"}, {key: "b_c"}, {isIndex: true, index: 10}, {isIndex: true}, {key: "name"}},
+	} {
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", path, err)
+			continue
+		}
+
+		if diff := gocmp.Diff(expected, segments, gocmp.AllowUnexported(jsonPathSegment{})); diff != "" {
+			t.Errorf("unexpected segments for %s:\n%s", path, diff)
+		}
+	}
+
+	for path, expectedErr := range map[string]string{
+		"":      "path must start with $",
+		"$":     "path must select something else than the document root",
+		"$.":    "empty member name",
+		"$[0":   "missing closing bracket",
+		"$[-1]": `invalid array index "-1"`,
+		"$[a]":  `invalid array index "a"`,
+		"$a":    `unexpected character 'a', expected . or [`,
+	} {
+		if _, err := parseJSONPath(path); err == nil || err.Error() != expectedErr {
+			t.Errorf("expected error %q for %q, got %v", expectedErr, path, err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package check
+
+import (
+	"strings"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/internal/diff"
+)
+
+// LinesEqual checks if two multiline texts are equal.
+// On failure, the message contains a line-based diff of the texts in the unified format
+// (lines prefixed by '-' are only in got, lines prefixed by '+' are only in want)
+// instead of both full texts, which makes it suitable to compare rendered templates, commands outputs, queries, ...
+// This is usually used like test.Assert(check.LinesEqual(t, got, want)).
+func LinesEqual(t test.TestingT, got, want string) (test.TestingT, bool, string) {
+	if got == want {
+		return t, true, "texts are equal"
+	}
+
+	return t, false, "texts differ (-got +want):\n" + diff.Unified(strings.Split(got, "\n"), strings.Split(want, "\n"), 3)
+}
@@ -0,0 +1,20 @@
+package check
+
+import (
+	"testing"
+)
+
+func Test_LinesEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := LinesEqual(t, "SELECT *\nFROM users\n", "SELECT *\nFROM users\n")
+		assertCheck(t, tt, result, true, msg, "texts are equal")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := LinesEqual(t, "SELECT *\nFROM users\nWHERE id = 1\n", "SELECT *\nFROM accounts\nWHERE id = 1\n")
+		assertCheck(t, tt, result, false, msg, "texts differ (-got +want):\n@@ -1,4 +1,4 @@\n SELECT *\n-FROM users\n+FROM accounts\n WHERE id = 1\n \n")
+
+		tt, result, msg = LinesEqual(t, "a\nb", "a\nb\n")
+		assertCheck(t, tt, result, false, msg, " a\n b\n+\n")
+	})
+}
@@ -0,0 +1,86 @@
+package check
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test"
+)
+
+// KeysEqual checks that two maps have the same set of keys, regardless of their values.
+// On failure, the message lists the missing and the extra keys.
+// This is usually used like test.Assert(check.KeysEqual(t, headers, map[string]string{"Content-Type": "", "Etag": ""})).
+func KeysEqual[K comparable, V1, V2 any](t test.TestingT, got map[K]V1, want map[K]V2) (test.TestingT, bool, string) {
+	var missing, extra []string
+
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			missing = append(missing, fmt.Sprintf("%#v", k))
+		}
+	}
+
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			extra = append(extra, fmt.Sprintf("%#v", k))
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		return t, false, "keys differ:" + describeMissingAndExtra("keys", missing, extra)
+	}
+
+	return t, true, fmt.Sprintf("both maps have the same %d keys", len(got))
+}
+
+// ValuesEqual checks that two maps have the same values, regardless of their keys,
+// each value being expected as many times as it appears in `want`. Values are compared using go-cmp,
+// with the options registered with RegisterCompareOptions and the provided ones. As options, like approximations,
+// can make equality not transitive, values are paired using a maximum matching, like ElementsMatchFunc does.
+// On failure, the message lists the missing and the extra values.
+// This is usually used like test.Assert(check.ValuesEqual(t, assignments, map[string]string{"a": "node1", "b": "node1", "c": "node2"})).
+func ValuesEqual[K1, K2 comparable, V any](t test.TestingT, got map[K1]V, want map[K2]V, gocmpOpts ...gocmp.Option) (test.TestingT, bool, string) {
+	opts := compareOptions(gocmpOpts)
+
+	gotValues, wantValues := slices.Collect(maps.Values(got)), slices.Collect(maps.Values(want))
+
+	gotMatched, wantMatch := maximumMatching(gotValues, wantValues, func(a, b V) bool { return gocmp.Equal(a, b, opts...) })
+
+	var missing, extra []string
+	for i, matched := range gotMatched {
+		if !matched {
+			extra = append(extra, fmt.Sprintf("%#v", gotValues[i]))
+		}
+	}
+	for j, i := range wantMatch {
+		if i < 0 {
+			missing = append(missing, fmt.Sprintf("%#v", wantValues[j]))
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		return t, false, "values differ:" + describeMissingAndExtra("values", missing, extra)
+	}
+
+	return t, true, fmt.Sprintf("both maps have the same %d values", len(got))
+}
+
+// describeMissingAndExtra describes sorted lists of missing and extra items, one list per line.
+func describeMissingAndExtra(items string, missing, extra []string) string {
+	var msg string
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		msg += fmt.Sprintf("\n  missing %s: %s", items, strings.Join(missing, ", "))
+	}
+
+	if len(extra) > 0 {
+		slices.Sort(extra)
+		msg += fmt.Sprintf("\n  extra %s: %s", items, strings.Join(extra, ", "))
+	}
+
+	return msg
+}
@@ -0,0 +1,42 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	gocmpopts "github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_KeysEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := KeysEqual(t, map[string]int{"a": 1, "b": 2}, map[string]bool{"b": true, "a": false})
+		assertCheck(t, tt, result, true, msg, "both maps have the same 2 keys")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := KeysEqual(t, map[string]int{"a": 1, "c": 3, "d": 4}, map[string]int{"a": 1, "b": 2})
+		assertCheck(t, tt, result, false, msg, "keys differ:\n  missing keys: \"b\"\n  extra keys: \"c\", \"d\"")
+
+		tt, result, msg = KeysEqual(t, map[int]int{}, map[int]int{2: 0, 1: 0})
+		assertCheck(t, tt, result, false, msg, "keys differ:\n  missing keys: 1, 2")
+	})
+}
+
+func Test_ValuesEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := ValuesEqual(t, map[string]string{"a": "node1", "b": "node1", "c": "node2"}, map[int]string{1: "node2", 2: "node1", 3: "node1"})
+		assertCheck(t, tt, result, true, msg, "both maps have the same 3 values")
+
+		tt, result, msg = ValuesEqual(t, map[string]string{"a": "NODE"}, map[string]string{"b": "node"}, gocmpopts.AcyclicTransformer("lower", strings.ToLower))
+		assertCheck(t, tt, result, true, msg, "both maps have the same 1 values")
+
+		// 1.0 is equal to both 1.4 and 0.8, and 1.5 only to 1.4: pairing 1.0 with 1.4 first would leave 1.5 unmatched
+		tt, result, msg = ValuesEqual(t, map[string]float64{"a": 1.0, "b": 1.5}, map[string]float64{"a": 1.4, "b": 0.8}, gocmpopts.EquateApprox(0, 0.5))
+		assertCheck(t, tt, result, true, msg, "both maps have the same 2 values")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := ValuesEqual(t, map[string]string{"a": "node1", "b": "node1", "c": "node3"}, map[string]string{"a": "node1", "b": "node2", "c": "node2"})
+		assertCheck(t, tt, result, false, msg, "values differ:\n  missing values: \"node2\", \"node2\"\n  extra values: \"node1\"", "\"node3\"")
+	})
+}
@@ -0,0 +1,59 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/krostar/test"
+)
+
+// NilValue checks if a value is nil.
+//
+// Unlike v == nil, a non-nil interface holding a nil pointer (or a nil map, slice, channel, function)
+// is considered nil, and the message explicitly says so, as this typed nil is a common source of confusion,
+// for instance when a function returns a nil *MyError as an error.
+// This is usually used like test.Assert(check.NilValue(t, err)).
+func NilValue(t test.TestingT, v any) (test.TestingT, bool, string) {
+	switch isNil, typed := nilValue(v); {
+	case typed:
+		return t, true, fmt.Sprintf("value is a typed nil: a nil %T held by a non-nil interface", v)
+	case isNil:
+		return t, true, "value is nil"
+	default:
+		return t, false, fmt.Sprintf("expected nil, got %#v of type %T", v, v)
+	}
+}
+
+// NotNilValue checks if a value is not nil.
+//
+// Unlike v != nil, a non-nil interface holding a nil pointer (or a nil map, slice, channel, function)
+// is considered nil, and the message explicitly says so, as this typed nil is a common source of confusion,
+// for instance when a function returns a nil *MyError as an error.
+// This is usually used like test.Assert(check.NotNilValue(t, user)).
+func NotNilValue(t test.TestingT, v any) (test.TestingT, bool, string) {
+	switch isNil, typed := nilValue(v); {
+	case typed:
+		return t, false, fmt.Sprintf("expected non-nil value, got a typed nil: a nil %T held by a non-nil interface (comparing it to nil returns false)", v)
+	case isNil:
+		return t, false, "expected non-nil value, got nil"
+	default:
+		return t, true, fmt.Sprintf("%T value is not nil", v)
+	}
+}
+
+// nilValue returns whether v is nil, and if so, whether it is a typed nil held by a non-nil interface.
+func nilValue(v any) (isNil, typed bool) { //nolint:nonamedreturns // names document the returned booleans
+	if v == nil {
+		return true, false
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		if rv.IsNil() {
+			return true, true
+		}
+	default:
+	}
+
+	return false, false
+}
@@ -0,0 +1,44 @@
+package check
+
+import (
+	"io/fs"
+	"testing"
+)
+
+func Test_NilValue(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NilValue(t, nil)
+		assertCheck(t, tt, result, true, msg, "value is nil")
+
+		var err error = (*fs.PathError)(nil)
+		tt, result, msg = NilValue(t, err)
+		assertCheck(t, tt, result, true, msg, "value is a typed nil: a nil *fs.PathError held by a non-nil interface")
+
+		tt, result, msg = NilValue(t, map[string]int(nil))
+		assertCheck(t, tt, result, true, msg, "value is a typed nil: a nil map[string]int held by a non-nil interface")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NilValue(t, 42)
+		assertCheck(t, tt, result, false, msg, "expected nil, got 42 of type int")
+
+		tt, result, msg = NilValue(t, []int{})
+		assertCheck(t, tt, result, false, msg, "expected nil, got []int{} of type []int")
+	})
+}
+
+func Test_NotNilValue(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NotNilValue(t, &fs.PathError{})
+		assertCheck(t, tt, result, true, msg, "*fs.PathError value is not nil")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NotNilValue(t, nil)
+		assertCheck(t, tt, result, false, msg, "expected non-nil value, got nil")
+
+		var err error = (*fs.PathError)(nil)
+		tt, result, msg = NotNilValue(t, err)
+		assertCheck(t, tt, result, false, msg, "expected non-nil value, got a typed nil: a nil *fs.PathError held by a non-nil interface")
+	})
+}
@@ -0,0 +1,252 @@
+package check
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unsafe"
+
+	"github.com/krostar/test"
+)
+
+// Unique checks that a slice contains no duplicate elements.
+// On failure, the message lists every duplicated element along with its indexes.
+// This is usually used like test.Assert(check.Unique(t, ids)).
+func Unique[T comparable](t test.TestingT, s []T) (test.TestingT, bool, string) {
+	return UniqueBy(t, s, func(v T) T { return v })
+}
+
+// UniqueBy checks that a slice contains no elements sharing the same key, as returned by `key`.
+// On failure, the message lists every duplicated key along with the indexes of the elements sharing it.
+// This is usually used like test.Assert(check.UniqueBy(t, users, func(u User) string { return u.Email })).
+func UniqueBy[T any, K comparable](t test.TestingT, s []T, key func(T) K) (test.TestingT, bool, string) {
+	var (
+		keys    []K // keys in order of first appearance
+		indexes = make(map[K][]int, len(s))
+	)
+
+	for i, v := range s {
+		k := key(v)
+		if _, exists := indexes[k]; !exists {
+			keys = append(keys, k)
+		}
+		indexes[k] = append(indexes[k], i)
+	}
+
+	var duplicates []string
+	for _, k := range keys {
+		if len(indexes[k]) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("%#v at indexes %v", k, indexes[k]))
+		}
+	}
+
+	if len(duplicates) > 0 {
+		return t, false, fmt.Sprintf("found %d duplicated elements out of %d:\n  - %s", len(duplicates), len(s), strings.Join(duplicates, "\n  - "))
+	}
+
+	return t, true, fmt.Sprintf("all %d elements are unique", len(s))
+}
+
+// SameBacking checks that two slices share the same backing array,
+// that is the elements they can reach within their capacity overlap.
+// It is useful to verify that a function does not copy a slice.
+// This is usually used like test.Assert(check.SameBacking(t, got, input)).
+func SameBacking[T any](t test.TestingT, a, b []T) (test.TestingT, bool, string) {
+	if !shareBacking(a, b) {
+		return t, false, fmt.Sprintf("expected slices to share the same backing array, got distinct arrays (len %d, cap %d and len %d, cap %d)", len(a), cap(a), len(b), cap(b))
+	}
+	return t, true, "slices share the same backing array"
+}
+
+// NotSameBacking checks that two slices do not share the same backing array, see SameBacking.
+// It is useful to verify that a function makes a defensive copy of a slice.
+// This is usually used like test.Assert(check.NotSameBacking(t, got, input)).
+func NotSameBacking[T any](t test.TestingT, a, b []T) (test.TestingT, bool, string) {
+	if shareBacking(a, b) {
+		return t, false, "expected slices to have distinct backing arrays, got slices sharing the same backing array"
+	}
+	return t, true, "slices have distinct backing arrays"
+}
+
+// shareBacking returns whether the ranges of the elements the two slices can reach within their capacity overlap.
+func shareBacking[T any](a, b []T) bool {
+	if cap(a) == 0 || cap(b) == 0 {
+		return false
+	}
+
+	if unsafe.Sizeof(a[:1][0]) == 0 {
+		return false // zero-sized elements may all share the same address without sharing any array
+	}
+
+	aFirst, aLast := uintptr(unsafe.Pointer(&a[:1][0])), uintptr(unsafe.Pointer(&a[:cap(a)][cap(a)-1]))
+	bFirst, bLast := uintptr(unsafe.Pointer(&b[:1][0])), uintptr(unsafe.Pointer(&b[:cap(b)][cap(b)-1]))
+
+	return aFirst <= bLast && bFirst <= aLast
+}
+
+// Cap checks that the capacity of the slice, array or channel is equal to `want`.
+// The message includes both the length and the capacity, to give context on preallocation issues.
+// This is usually used like test.Assert(check.Cap(t, buf, 64)).
+func Cap(t test.TestingT, v any, want int) (test.TestingT, bool, string) {
+	rv := reflect.ValueOf(v)
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array && k != reflect.Chan {
+		return t, false, fmt.Sprintf("value of type %T has no capacity", v)
+	}
+
+	if c := rv.Cap(); c != want {
+		return t, false, fmt.Sprintf("expected capacity %d, got capacity %d (length %d)", want, c, rv.Len())
+	}
+
+	return t, true, fmt.Sprintf("capacity is %d (length %d)", want, rv.Len())
+}
+
+// ContainsAll checks that the slice contains all the provided items.
+// On failure, the message lists the missing items.
+// This is usually used like test.Assert(check.ContainsAll(t, roles, "admin", "editor")).
+func ContainsAll[T comparable](t test.TestingT, s []T, items ...T) (test.TestingT, bool, string) {
+	var missing []string
+	for _, item := range items {
+		if !slices.Contains(s, item) {
+			missing = append(missing, fmt.Sprintf("%#v", item))
+		}
+	}
+
+	if len(missing) > 0 {
+		return t, false, fmt.Sprintf("%d of %d items are missing from %#v: %s", len(missing), len(items), s, strings.Join(missing, ", "))
+	}
+
+	return t, true, fmt.Sprintf("all %d items are contained", len(items))
+}
+
+// ContainsAny checks that the slice contains at least one of the provided items.
+// This is usually used like test.Assert(check.ContainsAny(t, roles, "admin", "owner")).
+func ContainsAny[T comparable](t test.TestingT, s []T, items ...T) (test.TestingT, bool, string) {
+	for _, item := range items {
+		if slices.Contains(s, item) {
+			return t, true, fmt.Sprintf("%#v is contained", item)
+		}
+	}
+
+	return t, false, fmt.Sprintf("none of the %d items are contained in %#v", len(items), s)
+}
+
+// ContainsNone checks that the slice contains none of the provided items.
+// On failure, the message lists the unwanted items that are present.
+// This is usually used like test.Assert(check.ContainsNone(t, roles, "admin", "owner")).
+func ContainsNone[T comparable](t test.TestingT, s []T, items ...T) (test.TestingT, bool, string) {
+	var present []string
+	for _, item := range items {
+		if i := slices.Index(s, item); i >= 0 {
+			present = append(present, fmt.Sprintf("%#v at index %d", item, i))
+		}
+	}
+
+	if len(present) > 0 {
+		return t, false, fmt.Sprintf("%d of %d unwanted items are contained: %s", len(present), len(items), strings.Join(present, ", "))
+	}
+
+	return t, true, fmt.Sprintf("none of the %d items are contained", len(items))
+}
+
+// Increasing checks that the values of the slice are increasing, strictly if `strict` is true.
+// On failure, the message points to the first pair of values violating the order.
+// This is usually used like test.Assert(check.Increasing(t, cursors, true)).
+func Increasing[T cmp.Ordered](t test.TestingT, s []T, strict bool) (test.TestingT, bool, string) {
+	return monotonic(t, s, strict, "increasing", func(a, b T) bool { return cmp.Less(a, b) || (!strict && cmp.Compare(a, b) == 0) })
+}
+
+// Decreasing checks that the values of the slice are decreasing, strictly if `strict` is true.
+// On failure, the message points to the first pair of values violating the order.
+// This is usually used like test.Assert(check.Decreasing(t, scores, false)).
+func Decreasing[T cmp.Ordered](t test.TestingT, s []T, strict bool) (test.TestingT, bool, string) {
+	return monotonic(t, s, strict, "decreasing", func(a, b T) bool { return cmp.Less(b, a) || (!strict && cmp.Compare(a, b) == 0) })
+}
+
+// monotonic checks that every pair of consecutive values of the slice is ordered according to `ordered`.
+func monotonic[T cmp.Ordered](t test.TestingT, s []T, strict bool, order string, ordered func(a, b T) bool) (test.TestingT, bool, string) {
+	if strict {
+		order = "strictly " + order
+	}
+
+	for i := 1; i < len(s); i++ {
+		if !ordered(s[i-1], s[i]) {
+			return t, false, fmt.Sprintf("values are not %s: %#v at index %d is followed by %#v at index %d", order, s[i-1], i-1, s[i], i)
+		}
+	}
+
+	return t, true, fmt.Sprintf("%d values are %s", len(s), order)
+}
+
+// ElementsMatchFunc checks that both slices have the same elements, regardless of their order,
+// elements being considered equal if `eq` returns true.
+//
+// It is useful for elements that are not comparable, or that should be matched on a subset of their fields,
+// like func(a, b User) bool { return a.ID == b.ID }. As `eq` does not need to be transitive, like for fuzzy matching,
+// elements are paired using a maximum matching rather than greedily.
+// On failure, the message lists the elements of each slice left without a match.
+// This is usually used like test.Assert(check.ElementsMatchFunc(t, got, want, func(a, b User) bool { return a.ID == b.ID })).
+func ElementsMatchFunc[T any](t test.TestingT, got, want []T, eq func(a, b T) bool) (test.TestingT, bool, string) {
+	gotMatched, wantMatch := maximumMatching(got, want, eq)
+
+	var unmatchedGot, unmatchedWant []string
+	for i, matched := range gotMatched {
+		if !matched {
+			unmatchedGot = append(unmatchedGot, fmt.Sprintf("[%d] %#v", i, got[i]))
+		}
+	}
+	for j, i := range wantMatch {
+		if i < 0 {
+			unmatchedWant = append(unmatchedWant, fmt.Sprintf("[%d] %#v", j, want[j]))
+		}
+	}
+
+	if len(unmatchedGot) > 0 || len(unmatchedWant) > 0 {
+		msg := "elements do not match:"
+		if len(unmatchedGot) > 0 {
+			msg += fmt.Sprintf("\n  %d elements of got have no match in want: %s", len(unmatchedGot), strings.Join(unmatchedGot, ", "))
+		}
+		if len(unmatchedWant) > 0 {
+			msg += fmt.Sprintf("\n  %d elements of want have no match in got: %s", len(unmatchedWant), strings.Join(unmatchedWant, ", "))
+		}
+		return t, false, msg
+	}
+
+	return t, true, fmt.Sprintf("all %d elements match", len(got))
+}
+
+// maximumMatching pairs as many elements of got with elements of want as possible, elements being paired if `eq`
+// returns true, which does not need to be transitive. It returns, for each element of got, whether it is paired,
+// and for each element of want, the index of the element of got it is paired with, or -1.
+func maximumMatching[T any](got, want []T, eq func(a, b T) bool) ([]bool, []int) {
+	// wantMatch[j] is the index of the element of got paired with want[j], or -1
+	wantMatch := make([]int, len(want))
+	for j := range wantMatch {
+		wantMatch[j] = -1
+	}
+
+	// augment tries to pair got[i], by taking a free element of want, or by re-pairing the element of got paired with it
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j := range want {
+			if visited[j] || !eq(got[i], want[j]) {
+				continue
+			}
+			visited[j] = true
+
+			if wantMatch[j] < 0 || augment(wantMatch[j], visited) {
+				wantMatch[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	gotMatched := make([]bool, len(got))
+	for i := range got {
+		gotMatched[i] = augment(i, make([]bool, len(want)))
+	}
+
+	return gotMatched, wantMatch
+}
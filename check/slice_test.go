@@ -0,0 +1,213 @@
+package check
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_Unique(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Unique(t, []int{1, 2, 3})
+		assertCheck(t, tt, result, true, msg, "all 3 elements are unique")
+
+		tt, result, msg = Unique[string](t, nil)
+		assertCheck(t, tt, result, true, msg, "all 0 elements are unique")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Unique(t, []string{"a", "b", "b", "c", "a", "b"})
+		assertCheck(t, tt, result, false, msg, "found 2 duplicated elements out of 6:\n  - \"a\" at indexes [0 4]\n  - \"b\" at indexes [1 2 5]")
+	})
+}
+
+func Test_UniqueBy(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := UniqueBy(t, []string{"a", "B", "c"}, strings.ToLower)
+		assertCheck(t, tt, result, true, msg, "all 3 elements are unique")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := UniqueBy(t, []string{"a", "B", "b"}, strings.ToLower)
+		assertCheck(t, tt, result, false, msg, "found 1 duplicated elements out of 3:\n  - \"b\" at indexes [1 2]")
+	})
+}
+
+func Test_SameBacking(t *testing.T) {
+	s := make([]int, 5, 10)
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := SameBacking(t, s, s)
+		assertCheck(t, tt, result, true, msg, "slices share the same backing array")
+
+		tt, result, msg = SameBacking(t, s[:2], s[4:])
+		assertCheck(t, tt, result, true, msg, "slices share the same backing array")
+
+		tt, result, msg = SameBacking(t, s[:1], s[5:10])
+		assertCheck(t, tt, result, true, msg, "slices share the same backing array")
+
+		tt, result, msg = SameBacking(t, s[:3:3], s)
+		assertCheck(t, tt, result, true, msg, "slices share the same backing array")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		other := make([]int, 5)
+		copy(other, s)
+
+		tt, result, msg := SameBacking(t, s, other)
+		assertCheck(t, tt, result, false, msg, "expected slices to share the same backing array, got distinct arrays (len 5, cap 10 and len 5, cap 5)")
+
+		tt, result, msg = SameBacking(t, s[:2:2], s[2:])
+		assertCheck(t, tt, result, false, msg, "expected slices to share the same backing array")
+
+		tt, result, msg = SameBacking(t, nil, s)
+		assertCheck(t, tt, result, false, msg, "expected slices to share the same backing array")
+
+		tt, result, msg = SameBacking(t, make([]struct{}, 2), make([]struct{}, 2))
+		assertCheck(t, tt, result, false, msg, "expected slices to share the same backing array")
+	})
+}
+
+func Test_NotSameBacking(t *testing.T) {
+	s := make([]string, 5)
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := NotSameBacking(t, s, slices.Clone(s))
+		assertCheck(t, tt, result, true, msg, "slices have distinct backing arrays")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := NotSameBacking(t, s[1:3], s[2:])
+		assertCheck(t, tt, result, false, msg, "expected slices to have distinct backing arrays, got slices sharing the same backing array")
+
+		tt, result, msg = NotSameBacking(t, s[:2:2], s)
+		assertCheck(t, tt, result, false, msg, "expected slices to have distinct backing arrays, got slices sharing the same backing array")
+	})
+}
+
+func Test_Cap(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Cap(t, make([]int, 2, 8), 8)
+		assertCheck(t, tt, result, true, msg, "capacity is 8 (length 2)")
+
+		tt, result, msg = Cap(t, make(chan string, 4), 4)
+		assertCheck(t, tt, result, true, msg, "capacity is 4 (length 0)")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Cap(t, append(make([]int, 0, 2), 1, 2, 3), 2)
+		assertCheck(t, tt, result, false, msg, "expected capacity 2, got capacity 4 (length 3)")
+
+		c := make(chan int, 2)
+		c <- 1
+		tt, result, msg = Cap(t, c, 1)
+		assertCheck(t, tt, result, false, msg, "expected capacity 1, got capacity 2 (length 1)")
+
+		tt, result, msg = Cap(t, "hello", 5)
+		assertCheck(t, tt, result, false, msg, "value of type string has no capacity")
+	})
+}
+
+func Test_ContainsAll(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := ContainsAll(t, []string{"a", "b", "c"}, "c", "a")
+		assertCheck(t, tt, result, true, msg, "all 2 items are contained")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := ContainsAll(t, []string{"a", "b"}, "a", "c", "d")
+		assertCheck(t, tt, result, false, msg, `2 of 3 items are missing from []string{"a", "b"}: "c", "d"`)
+	})
+}
+
+func Test_ContainsAny(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := ContainsAny(t, []int{1, 2, 3}, 4, 2)
+		assertCheck(t, tt, result, true, msg, "2 is contained")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := ContainsAny(t, []int{1, 2, 3}, 4, 5)
+		assertCheck(t, tt, result, false, msg, "none of the 2 items are contained in []int{1, 2, 3}")
+	})
+}
+
+func Test_ContainsNone(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := ContainsNone(t, []int{1, 2, 3}, 4, 5)
+		assertCheck(t, tt, result, true, msg, "none of the 2 items are contained")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := ContainsNone(t, []int{1, 2, 3}, 3, 4, 1)
+		assertCheck(t, tt, result, false, msg, "2 of 3 unwanted items are contained: 3 at index 2, 1 at index 0")
+	})
+}
+
+func Test_Increasing(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Increasing(t, []int{1, 2, 2, 5}, false)
+		assertCheck(t, tt, result, true, msg, "4 values are increasing")
+
+		tt, result, msg = Increasing(t, []string{"v1.0", "v1.1", "v2.0"}, true)
+		assertCheck(t, tt, result, true, msg, "3 values are strictly increasing")
+
+		tt, result, msg = Increasing(t, []int(nil), true)
+		assertCheck(t, tt, result, true, msg, "0 values are strictly increasing")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Increasing(t, []int{1, 2, 2, 5}, true)
+		assertCheck(t, tt, result, false, msg, "values are not strictly increasing: 2 at index 1 is followed by 2 at index 2")
+
+		tt, result, msg = Increasing(t, []float64{1, 3, 2}, false)
+		assertCheck(t, tt, result, false, msg, "values are not increasing: 3 at index 1 is followed by 2 at index 2")
+	})
+}
+
+func Test_Decreasing(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Decreasing(t, []int{5, 2, 2, 1}, false)
+		assertCheck(t, tt, result, true, msg, "4 values are decreasing")
+
+		tt, result, msg = Decreasing(t, []int{5, 2, 1}, true)
+		assertCheck(t, tt, result, true, msg, "3 values are strictly decreasing")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Decreasing(t, []int{5, 2, 2, 1}, true)
+		assertCheck(t, tt, result, false, msg, "values are not strictly decreasing: 2 at index 1 is followed by 2 at index 2")
+
+		tt, result, msg = Decreasing(t, []string{"c", "a", "b"}, false)
+		assertCheck(t, tt, result, false, msg, `values are not decreasing: "a" at index 1 is followed by "b" at index 2`)
+	})
+}
+
+func Test_ElementsMatchFunc(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	sameID := func(a, b user) bool { return a.ID == b.ID }
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := ElementsMatchFunc(t, []user{{ID: 1, Name: "a"}, {ID: 2}}, []user{{ID: 2, Name: "b"}, {ID: 1}}, sameID)
+		assertCheck(t, tt, result, true, msg, "all 2 elements match")
+
+		// a greedy pairing would pair 1 with 1, leaving 2 without a match
+		near := func(a, b float64) bool { return math.Abs(a-b) <= 1 }
+		tt, result, msg = ElementsMatchFunc(t, []float64{1, 2}, []float64{1, 0}, near)
+		assertCheck(t, tt, result, true, msg, "all 2 elements match")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := ElementsMatchFunc(t, []user{{ID: 1}, {ID: 3}, {ID: 1}}, []user{{ID: 2}, {ID: 1}}, sameID)
+		assertCheck(t, tt, result, false, msg,
+			"elements do not match:\n",
+			"  2 elements of got have no match in want: [1] check.user{ID:3, Name:\"\"}, [2] check.user{ID:1, Name:\"\"}\n",
+			"  1 elements of want have no match in got: [0] check.user{ID:2, Name:\"\"}",
+		)
+	})
+}
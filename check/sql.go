@@ -0,0 +1,127 @@
+package check
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/internal/diff"
+)
+
+// SQLQuerier is implemented by types able to run SQL queries, like *sql.DB, *sql.Conn and *sql.Tx.
+type SQLQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// SQLQuery is a query to run against a database, by checks like RowCount and QueryReturns.
+type SQLQuery struct {
+	Querier SQLQuerier
+	Query   string
+	Args    []any
+}
+
+// RowCount checks that the query returns exactly `want` rows.
+// The query is run with t.Context().
+// This is usually used like test.Assert(check.RowCount(t, check.SQLQuery{Querier: tx, Query: "SELECT id FROM users"}, 2)).
+func RowCount(t test.TestingT, q SQLQuery, want int) (test.TestingT, bool, string) {
+	rows, err := q.rows(t.Context())
+	if err != nil {
+		return t, false, err.Error()
+	}
+
+	if len(rows) != want {
+		return t, false, fmt.Sprintf("expected query %q to return %d rows, got %d:\n%s", q.Query, want, len(rows), diff.List(rows, identity))
+	}
+
+	return t, true, fmt.Sprintf("query %q returned %d rows", q.Query, want)
+}
+
+// QueryReturns checks that the query returns exactly the `want` rows, in order.
+// The query is run with t.Context().
+// Cells are compared using their textual representation, so that want can be written without
+// knowing the exact types returned by the driver, like int64 or []byte.
+// On failure, the message contains a table of the returned and expected rows.
+// This is usually used like test.Assert(check.QueryReturns(t, check.SQLQuery{Querier: db, Query: "SELECT id, name FROM users"}, [][]any{{1, "bob"}})).
+func QueryReturns(t test.TestingT, q SQLQuery, want [][]any) (test.TestingT, bool, string) {
+	rows, err := q.rows(t.Context())
+	if err != nil {
+		return t, false, err.Error()
+	}
+
+	wantRows := make([]string, len(want))
+	for i, row := range want {
+		wantRows[i] = renderRow(row)
+	}
+
+	if !slices.Equal(rows, wantRows) {
+		table := diff.Table(rows, wantRows, func(got, want string) bool { return got == want }, identity)
+		if len(rows) != len(wantRows) {
+			return t, false, fmt.Sprintf("query %q returned %d rows, expected %d:\n%s", q.Query, len(rows), len(wantRows), table)
+		}
+		return t, false, fmt.Sprintf("query %q returned unexpected rows:\n%s", q.Query, table)
+	}
+
+	return t, true, fmt.Sprintf("query %q returned the %d expected rows", q.Query, len(rows))
+}
+
+// rows runs the query and returns every returned rows rendered with renderRow.
+func (q SQLQuery) rows(ctx context.Context) ([]string, error) {
+	if q.Querier == nil {
+		return nil, fmt.Errorf("unable to run query %q: querier is nil", q.Query)
+	}
+
+	rows, err := q.Querier.QueryContext(ctx, q.Query, q.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run query %q: %v", q.Query, err)
+	}
+	defer rows.Close() //nolint:errcheck // error is reported by rows.Err
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get columns of query %q: %v", q.Query, err)
+	}
+
+	var rendered []string
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("unable to scan row #%d of query %q: %v", len(rendered)+1, q.Query, err)
+		}
+
+		rendered = append(rendered, renderRow(values))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to iterate over rows of query %q: %v", q.Query, err)
+	}
+
+	return rendered, nil
+}
+
+// renderRow returns the textual representation of a row, made of its cells separated by pipes.
+func renderRow(row []any) string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		switch c := cell.(type) {
+		case nil:
+			cells[i] = "NULL"
+		case []byte:
+			cells[i] = string(c)
+		default:
+			cells[i] = fmt.Sprint(c)
+		}
+	}
+	return strings.Join(cells, " | ")
+}
+
+// identity returns the already rendered row as is.
+func identity(row string) string { return row }
@@ -0,0 +1,117 @@
+package check
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+func Test_RowCount(t *testing.T) {
+	db := newFakeSQLDB(t)
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := RowCount(t, SQLQuery{Querier: db, Query: "users"}, 2)
+		assertCheck(t, tt, result, true, msg, `query "users" returned 2 rows`)
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := RowCount(t, SQLQuery{Querier: db, Query: "users"}, 3)
+		assertCheck(t, tt, result, false, msg, `expected query "users" to return 3 rows, got 2:
+#  got
+1  1 | bob
+2  2 | NULL`)
+
+		tt, result, msg = RowCount(t, SQLQuery{Querier: db, Query: "boom"}, 3)
+		assertCheck(t, tt, result, false, msg, `unable to run query "boom": boom`)
+
+		tt, result, msg = RowCount(t, SQLQuery{Query: "users"}, 3)
+		assertCheck(t, tt, result, false, msg, `unable to run query "users": querier is nil`)
+	})
+}
+
+func Test_QueryReturns(t *testing.T) {
+	db := newFakeSQLDB(t)
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := QueryReturns(t, SQLQuery{Querier: db, Query: "users"}, [][]any{{1, "bob"}, {2, nil}})
+		assertCheck(t, tt, result, true, msg, `query "users" returned the 2 expected rows`)
+
+		tt, result, msg = QueryReturns(t, SQLQuery{Querier: db, Query: "empty"}, nil)
+		assertCheck(t, tt, result, true, msg, `query "empty" returned the 0 expected rows`)
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := QueryReturns(t, SQLQuery{Querier: db, Query: "users"}, [][]any{{1, "bob"}, {2, "alice"}})
+		assertCheck(t, tt, result, false, msg, `query "users" returned unexpected rows:
+#  status    got       want
+1  ok        1 | bob   1 | bob
+2  mismatch  2 | NULL  2 | alice`)
+
+		tt, result, msg = QueryReturns(t, SQLQuery{Querier: db, Query: "users"}, [][]any{{1, "bob"}})
+		assertCheck(t, tt, result, false, msg, `query "users" returned 2 rows, expected 1:
+#  status      got       want
+1  ok          1 | bob   1 | bob
+2  unexpected  2 | NULL  -`)
+
+		tt, result, msg = QueryReturns(t, SQLQuery{Querier: db, Query: "empty"}, [][]any{{1}})
+		assertCheck(t, tt, result, false, msg, "1  missing  -    1")
+	})
+}
+
+// newFakeSQLDB returns a database whose queries are the name of the results to return.
+func newFakeSQLDB(t *testing.T) *sql.DB {
+	db := sql.OpenDB(fakeSQLConnector{results: map[string]fakeSQLRows{
+		"users": {columns: []string{"id", "name"}, values: [][]driver.Value{{int64(1), []byte("bob")}, {int64(2), nil}}},
+		"empty": {columns: []string{"id"}},
+	}})
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+type fakeSQLConnector struct{ results map[string]fakeSQLRows }
+
+func (c fakeSQLConnector) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c fakeSQLConnector) Driver() driver.Driver                        { return nil }
+func (fakeSQLConnector) Begin() (driver.Tx, error)                      { return nil, errors.New("not supported") }
+func (fakeSQLConnector) Close() error                                   { return nil }
+func (c fakeSQLConnector) Prepare(query string) (driver.Stmt, error) {
+	return fakeSQLStmt{connector: c, query: query}, nil
+}
+
+type fakeSQLStmt struct {
+	connector fakeSQLConnector
+	query     string
+}
+
+func (fakeSQLStmt) Close() error  { return nil }
+func (fakeSQLStmt) NumInput() int { return -1 }
+func (fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	rows, ok := s.connector.results[s.query]
+	if !ok {
+		return nil, errors.New(s.query)
+	}
+	return &rows, nil
+}
+
+type fakeSQLRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return r.columns }
+func (*fakeSQLRows) Close() error        { return nil }
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,228 @@
+package check
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/krostar/test"
+)
+
+// StringOption is a function that configures how strings are compared by StringEqual.
+type StringOption func(o *stringOptions)
+
+// StringIgnoreCase makes the comparison case-insensitive.
+func StringIgnoreCase() StringOption {
+	return func(o *stringOptions) { o.ignoreCase = true }
+}
+
+// StringCollapseWhitespace makes the comparison replace every sequence of whitespaces by a single space,
+// and ignore leading and trailing whitespaces.
+func StringCollapseWhitespace() StringOption {
+	return func(o *stringOptions) { o.collapseWhitespace = true }
+}
+
+// StringIgnoreTrailingNewlines makes the comparison ignore trailing newlines.
+func StringIgnoreTrailingNewlines() StringOption {
+	return func(o *stringOptions) { o.ignoreTrailingNewlines = true }
+}
+
+type stringOptions struct {
+	ignoreCase             bool
+	collapseWhitespace     bool
+	ignoreTrailingNewlines bool
+}
+
+// normalize applies the configured normalizations to the provided string.
+func (o stringOptions) normalize(s string) string {
+	if o.ignoreCase {
+		s = strings.ToLower(s)
+	}
+
+	if o.collapseWhitespace {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+
+	if o.ignoreTrailingNewlines {
+		s = strings.TrimRight(s, "\r\n")
+	}
+
+	return s
+}
+
+// StringEqual checks that two strings are equal, after applying the normalizations of the provided options.
+// On failure, the message shows where the normalized strings start to differ.
+// This is usually used like test.Assert(check.StringEqual(t, got, "hello world", check.StringIgnoreCase())).
+func StringEqual(t test.TestingT, got, want string, opts ...StringOption) (test.TestingT, bool, string) {
+	var o stringOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if got, want = o.normalize(got), o.normalize(want); got != want {
+		return t, false, describeStringsDivergence(got, want)
+	}
+
+	return t, true, "strings are equal"
+}
+
+// StringContainsAll checks that the string contains all the provided substrings.
+// On failure, the message lists the missing substrings.
+// This is usually used like test.Assert(check.StringContainsAll(t, output, "created", "user-42")).
+func StringContainsAll(t test.TestingT, s string, substrs ...string) (test.TestingT, bool, string) {
+	var missing []string
+	for _, substr := range substrs {
+		if !strings.Contains(s, substr) {
+			missing = append(missing, strconv.Quote(substr))
+		}
+	}
+
+	if len(missing) > 0 {
+		return t, false, fmt.Sprintf("%d of %d substrings are missing from %q: %s", len(missing), len(substrs), s, strings.Join(missing, ", "))
+	}
+
+	return t, true, fmt.Sprintf("all %d substrings are contained", len(substrs))
+}
+
+// StringContainsAny checks that the string contains at least one of the provided substrings.
+// This is usually used like test.Assert(check.StringContainsAny(t, output, "created", "updated")).
+func StringContainsAny(t test.TestingT, s string, substrs ...string) (test.TestingT, bool, string) {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return t, true, fmt.Sprintf("%q is contained", substr)
+		}
+	}
+
+	return t, false, fmt.Sprintf("none of the %d substrings are contained in %q", len(substrs), s)
+}
+
+// StringContainsNone checks that the string contains none of the provided substrings.
+// On failure, the message lists the unwanted substrings that are present.
+// This is usually used like test.Assert(check.StringContainsNone(t, output, "error", "panic")).
+func StringContainsNone(t test.TestingT, s string, substrs ...string) (test.TestingT, bool, string) {
+	var present []string
+	for _, substr := range substrs {
+		if i := strings.Index(s, substr); i >= 0 {
+			present = append(present, fmt.Sprintf("%q at byte %d", substr, i))
+		}
+	}
+
+	if len(present) > 0 {
+		return t, false, fmt.Sprintf("%d of %d unwanted substrings are contained: %s", len(present), len(substrs), strings.Join(present, ", "))
+	}
+
+	return t, true, fmt.Sprintf("none of the %d substrings are contained", len(substrs))
+}
+
+// EqualNFC checks that two strings are equal once normalized to the Unicode Normalization Form C,
+// so that strings made of different sequences of code points representing the same characters,
+// like "é" written as U+00E9 or as U+0065 U+0301, are considered equal.
+// On failure, the message shows where the normalized strings start to differ,
+// along with the code points of the differing runes, as they are often invisible or indistinguishable.
+// This is usually used like test.Assert(check.EqualNFC(t, got, "Crème brûlée")).
+func EqualNFC(t test.TestingT, got, want string) (test.TestingT, bool, string) {
+	return equalNormalized(t, got, want, norm.NFC, "NFC")
+}
+
+// EqualNFD behaves like EqualNFC, normalizing strings to the Unicode Normalization Form D instead.
+// Both forms consider the same strings equal, but with NFD, characters are decomposed in the failure message,
+// which then shows, for instance, the combining accent that differs instead of the whole accented letter.
+// This is usually used like test.Assert(check.EqualNFD(t, got, "Crème brûlée")).
+func EqualNFD(t test.TestingT, got, want string) (test.TestingT, bool, string) {
+	return equalNormalized(t, got, want, norm.NFD, "NFD")
+}
+
+// equalNormalized checks that two strings are equal once normalized to the provided form, named `formName`.
+func equalNormalized(t test.TestingT, got, want string, form norm.Form, formName string) (test.TestingT, bool, string) {
+	if got, want = form.String(got), form.String(want); got != want {
+		return t, false, describeStringsDivergence(got, want) + "\n" + describeDifferingCodePoints(got, want)
+	}
+
+	return t, true, "strings are equal once normalized to " + formName
+}
+
+// describeDifferingCodePoints describes the code points of both strings from the first differing rune.
+func describeDifferingCodePoints(got, want string) string {
+	const maxCodePoints = 8
+
+	gotRunes, wantRunes := []rune(got), []rune(want)
+
+	divergence := 0
+	for divergence < len(gotRunes) && divergence < len(wantRunes) && gotRunes[divergence] == wantRunes[divergence] {
+		divergence++
+	}
+
+	codePoints := func(runes []rune) string {
+		runes = runes[divergence:]
+		if len(runes) == 0 {
+			return "<end of string>"
+		}
+
+		var suffix string
+		if len(runes) > maxCodePoints {
+			runes, suffix = runes[:maxCodePoints], " ..."
+		}
+
+		described := make([]string, len(runes))
+		for i, r := range runes {
+			described[i] = fmt.Sprintf("%U %q", r, r)
+		}
+
+		return strings.Join(described, ", ") + suffix
+	}
+
+	return fmt.Sprintf("code points from rune %d:\n  got:  %s\n  want: %s", divergence, codePoints(gotRunes), codePoints(wantRunes))
+}
+
+// describeStringsDivergence describes where two different strings start to differ,
+// showing a quoted excerpt of both strings around the first differing rune.
+func describeStringsDivergence(got, want string) string {
+	const excerptContext = 20
+
+	gotRunes, wantRunes := []rune(got), []rune(want)
+
+	divergence := 0
+	for divergence < len(gotRunes) && divergence < len(wantRunes) && gotRunes[divergence] == wantRunes[divergence] {
+		divergence++
+	}
+
+	line, column := 1, 1
+	for _, r := range gotRunes[:divergence] {
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	excerpt := func(runes []rune) string {
+		start, end := max(0, divergence-excerptContext), min(len(runes), divergence+excerptContext)
+
+		var prefix, suffix string
+		if start > 0 {
+			prefix = "..."
+		}
+		if end < len(runes) {
+			suffix = "..."
+		}
+
+		return prefix + strconv.Quote(string(runes[start:end])) + suffix
+	}
+
+	// the excerpts share the same content up to the divergence, so the caret is aligned for both
+	common := gotRunes[max(0, divergence-excerptContext):divergence]
+	caretOffset := utf8.RuneCountInString(strconv.Quote(string(common))) - 1
+	if divergence > excerptContext {
+		caretOffset += len("...")
+	}
+
+	return fmt.Sprintf("strings differ at line %d, column %d (rune %d):\n  got:  %s\n  want: %s\n        %s^",
+		line, column, divergence,
+		excerpt(gotRunes), excerpt(wantRunes),
+		strings.Repeat(" ", caretOffset),
+	)
+}
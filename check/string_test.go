@@ -0,0 +1,159 @@
+package check
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_StringEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := StringEqual(t, "hello world", "hello world")
+		assertCheck(t, tt, result, true, msg, "strings are equal")
+
+		tt, result, msg = StringEqual(t, "Hello World", "hello world", StringIgnoreCase())
+		assertCheck(t, tt, result, true, msg, "strings are equal")
+
+		tt, result, msg = StringEqual(t, "  hello \t\n world ", "hello world", StringCollapseWhitespace())
+		assertCheck(t, tt, result, true, msg, "strings are equal")
+
+		tt, result, msg = StringEqual(t, "hello world\r\n\n", "hello world", StringIgnoreTrailingNewlines())
+		assertCheck(t, tt, result, true, msg, "strings are equal")
+
+		tt, result, msg = StringEqual(t, "hello world\n", "hello world", StringIgnoreCase(), StringCollapseWhitespace())
+		assertCheck(t, tt, result, true, msg, "strings are equal")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := StringEqual(t, "Hello World", "hello world")
+		assertCheck(t, tt, result, false, msg, "strings differ at line 1, column 1 (rune 0)")
+
+		tt, result, msg = StringEqual(t, "hello\nwurld", "hello\nworld")
+		assertCheck(t, tt, result, false, msg, "strings differ at line 2, column 2 (rune 7)")
+	})
+}
+
+func Test_describeStringsDivergence(t *testing.T) {
+	for name, tt := range map[string]struct {
+		got, want string
+		expected  []string
+	}{
+		"short strings": {
+			got:  "hello wurld",
+			want: "hello world",
+			expected: []string{
+				"strings differ at line 1, column 8 (rune 7):",
+				`  got:  "hello wurld"`,
+				`  want: "hello world"`,
+				`                ^`,
+			},
+		},
+		"long strings": {
+			got:  strings.Repeat("a", 30) + "b" + strings.Repeat("c", 30),
+			want: strings.Repeat("a", 30) + "B" + strings.Repeat("c", 30),
+			expected: []string{
+				"strings differ at line 1, column 31 (rune 30):",
+				`  got:  ..."aaaaaaaaaaaaaaaaaaaabccccccccccccccccccc"...`,
+				`  want: ..."aaaaaaaaaaaaaaaaaaaaBccccccccccccccccccc"...`,
+				`                                ^`,
+			},
+		},
+		"escaped characters": {
+			got:  "a\tb\nc",
+			want: "a\tb\nC",
+			expected: []string{
+				"strings differ at line 2, column 1 (rune 4):",
+				`  got:  "a\tb\nc"`,
+				`  want: "a\tb\nC"`,
+				`               ^`,
+			},
+		},
+		"prefix": {
+			got:  "hello",
+			want: "hello world",
+			expected: []string{
+				"strings differ at line 1, column 6 (rune 5):",
+				`  got:  "hello"`,
+				`  want: "hello world"`,
+				`              ^`,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got, expected := describeStringsDivergence(tt.got, tt.want), strings.Join(tt.expected, "\n"); got != expected {
+				t.Errorf("unexpected description, got:\n%s\nwant:\n%s", got, expected)
+			}
+		})
+	}
+}
+
+func Test_EqualNFC(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := EqualNFC(t, "Cre\u0300me", "Cr\u00e8me")
+		assertCheck(t, tt, result, true, msg, "strings are equal once normalized to NFC")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := EqualNFC(t, "Cre\u0300me", "Cr\u00e9me")
+		assertCheck(t, tt, result, false, msg,
+			"strings differ at line 1, column 3 (rune 2)",
+			"code points from rune 2:\n  got:  U+00E8 'è', U+006D 'm', U+0065 'e'\n  want: U+00E9 'é', U+006D 'm', U+0065 'e'",
+		)
+
+		tt, result, msg = EqualNFC(t, "abc\u200b", "abc")
+		assertCheck(t, tt, result, false, msg, "code points from rune 3:\n  got:  U+200B '\\u200b'\n  want: <end of string>")
+
+		tt, result, msg = EqualNFC(t, "0123456789", "")
+		assertCheck(t, tt, result, false, msg, "U+0037 '7' ...\n  want: <end of string>")
+	})
+}
+
+func Test_EqualNFD(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := EqualNFD(t, "Cr\u00e8me", "Cre\u0300me")
+		assertCheck(t, tt, result, true, msg, "strings are equal once normalized to NFD")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := EqualNFD(t, "Cr\u00e8me", "Cr\u00e9me")
+		assertCheck(t, tt, result, false, msg,
+			"strings differ at line 1, column 4 (rune 3)",
+			"code points from rune 3:\n  got:  U+0300 '\u0300', U+006D 'm', U+0065 'e'\n  want: U+0301 '\u0301', U+006D 'm', U+0065 'e'",
+		)
+	})
+}
+
+func Test_StringContainsAll(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := StringContainsAll(t, "user 42 created", "created", "42")
+		assertCheck(t, tt, result, true, msg, "all 2 substrings are contained")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := StringContainsAll(t, "user 42 created", "created", "43", "deleted")
+		assertCheck(t, tt, result, false, msg, `2 of 3 substrings are missing from "user 42 created": "43", "deleted"`)
+	})
+}
+
+func Test_StringContainsAny(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := StringContainsAny(t, "user 42 created", "updated", "created")
+		assertCheck(t, tt, result, true, msg, `"created" is contained`)
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := StringContainsAny(t, "user 42 created", "updated", "deleted")
+		assertCheck(t, tt, result, false, msg, `none of the 2 substrings are contained in "user 42 created"`)
+	})
+}
+
+func Test_StringContainsNone(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := StringContainsNone(t, "user 42 created", "error", "panic")
+		assertCheck(t, tt, result, true, msg, "none of the 2 substrings are contained")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := StringContainsNone(t, "panic: error", "error", "warning", "panic")
+		assertCheck(t, tt, result, false, msg, `2 of 3 unwanted substrings are contained: "error" at byte 7, "panic" at byte 0`)
+	})
+}
@@ -0,0 +1,117 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/internal/goroutine"
+)
+
+// Completes checks that the wait group counter drops to zero before the context expires.
+// On failure, the message contains the stacks of all running goroutines, to find out which ones are stuck.
+// Note that the goroutine waiting for the wait group is leaked if the wait group never completes.
+// This is usually used like test.Assert(check.Completes(ctx, t, &wg)).
+func Completes(ctx context.Context, t test.TestingT, wg *sync.WaitGroup) (test.TestingT, bool, string) {
+	return CompletesWithoutError(ctx, t, waitGroupWaiter{wg: wg})
+}
+
+// CompletesWithoutError checks that the group completes before the context expires, and that it returns no error.
+// It works with any group having a Wait() error method, like golang.org/x/sync/errgroup.Group.
+// On timeout, the message contains the stacks of all running goroutines, to find out which ones are stuck.
+// Note that the goroutine waiting for the group is leaked if the group never completes.
+// This is usually used like test.Assert(check.CompletesWithoutError(ctx, t, g)).
+func CompletesWithoutError(ctx context.Context, t test.TestingT, group interface{ Wait() error }) (test.TestingT, bool, string) {
+	startedAt := time.Now()
+
+	done := make(chan error, 1)
+	go func() { done <- group.Wait() }()
+
+	select {
+	case <-ctx.Done():
+		return t, false, fmt.Sprintf("group did not complete after %s and now context is expired, running goroutines:\n%s", time.Since(startedAt).String(), goroutine.Dump())
+
+	case err := <-done:
+		if err != nil {
+			return t, false, fmt.Sprintf("group completed after %s with error: %v", time.Since(startedAt).String(), err)
+		}
+		return t, true, fmt.Sprintf("group completed after %s", time.Since(startedAt).String())
+	}
+}
+
+// Blocks checks that `f` does not return before `atLeast`, which is useful to test locks, rate limiters, or wait loops.
+//
+// If `unblock` is not nil, it is called once `atLeast` elapsed, and `f` is then expected to return before the context expires.
+// On failure to return, the message contains the stacks of all running goroutines, to find out where `f` is stuck.
+// Note that the goroutine running `f` is leaked if `f` never returns.
+//
+//	Example: test.Assert(check.Blocks(ctx, t, func() { mu.Lock() }, time.Millisecond*50, func() { mu.Unlock() }))
+func Blocks(ctx context.Context, t test.TestingT, f func(), atLeast time.Duration, unblock func()) (test.TestingT, bool, string) {
+	startedAt := time.Now()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+
+	select {
+	case <-done:
+		return t, false, fmt.Sprintf("function returned after %s, expected it to block for at least %s", time.Since(startedAt).String(), atLeast.String())
+	case <-time.After(atLeast):
+	}
+
+	if unblock == nil {
+		return t, true, fmt.Sprintf("function blocked for at least %s", atLeast.String())
+	}
+
+	unblock()
+	unblockedAt := time.Now()
+
+	select {
+	case <-ctx.Done():
+		return t, false, fmt.Sprintf("function blocked for at least %s like expected, but did not return %s after being unblocked and now context is expired, running goroutines:\n%s",
+			atLeast.String(), time.Since(unblockedAt).String(), goroutine.Dump(),
+		)
+	case <-done:
+		return t, true, fmt.Sprintf("function blocked for at least %s, and returned %s after being unblocked", atLeast.String(), time.Since(unblockedAt).String())
+	}
+}
+
+// CompletesWithin checks that `f` returns before `within` elapsed.
+// On failure, the message contains the elapsed time and the stack of the goroutine running `f`, to find out where it is stuck.
+// Note that the goroutine running `f` is leaked if `f` never returns.
+// This is usually used like test.Assert(check.CompletesWithin(t, func() { server.Shutdown(ctx) }, time.Second)).
+func CompletesWithin(t test.TestingT, f func(), within time.Duration) (test.TestingT, bool, string) {
+	startedAt := time.Now()
+
+	started := make(chan string, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		started <- goroutine.Header()
+		f()
+	}()
+
+	header := <-started
+
+	select {
+	case <-time.After(within):
+		return t, false, fmt.Sprintf("function did not complete within %s, still running after %s:\n%s",
+			within.String(), time.Since(startedAt).String(), goroutine.StackOf(goroutine.Dump(), header),
+		)
+
+	case <-done:
+		return t, true, fmt.Sprintf("function completed in %s", time.Since(startedAt).String())
+	}
+}
+
+// waitGroupWaiter adapts a sync.WaitGroup to a group whose Wait returns an error.
+type waitGroupWaiter struct{ wg *sync.WaitGroup }
+
+func (w waitGroupWaiter) Wait() error {
+	w.wg.Wait()
+	return nil
+}
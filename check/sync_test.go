@@ -0,0 +1,100 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_Completes(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Go(func() { time.Sleep(10 * time.Millisecond) })
+
+		tt, result, msg := Completes(t.Context(), t, &wg)
+		assertCheck(t, tt, result, true, msg, "group completed after")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		var wg sync.WaitGroup
+		wg.Go(func() { stuckInTest(unblock) })
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		tt, result, msg := Completes(ctx, t, &wg)
+		assertCheck(t, tt, result, false, msg, "group did not complete after", "context is expired, running goroutines:", "check.stuckInTest(")
+	})
+}
+
+func Test_CompletesWithoutError(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := CompletesWithoutError(t.Context(), t, waiterFunc(func() error { return nil }))
+		assertCheck(t, tt, result, true, msg, "group completed after")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := CompletesWithoutError(t.Context(), t, waiterFunc(func() error { return errors.New("boom") }))
+		assertCheck(t, tt, result, false, msg, "group completed after", "with error: boom")
+	})
+}
+
+func Test_Blocks(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var mu sync.Mutex
+		mu.Lock()
+
+		tt, result, msg := Blocks(t.Context(), t, func() { mu.Lock() }, 20*time.Millisecond, mu.Unlock)
+		assertCheck(t, tt, result, true, msg, "function blocked for at least 20ms, and returned ", "after being unblocked")
+
+		tt, result, msg = Blocks(t.Context(), t, func() { time.Sleep(40 * time.Millisecond) }, 10*time.Millisecond, nil)
+		assertCheck(t, tt, result, true, msg, "function blocked for at least 10ms")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Blocks(t.Context(), t, func() {}, 20*time.Millisecond, nil)
+		assertCheck(t, tt, result, false, msg, "function returned after ", "expected it to block for at least 20ms")
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+
+		stop := make(chan struct{})
+		defer close(stop)
+
+		tt, result, msg = Blocks(ctx, t, func() { <-stop }, 10*time.Millisecond, func() {})
+		assertCheck(t, tt, result, false, msg, "function blocked for at least 10ms like expected, but did not return ", "running goroutines:\ngoroutine ")
+	})
+}
+
+func Test_CompletesWithin(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := CompletesWithin(t, func() { time.Sleep(time.Millisecond) }, time.Second)
+		assertCheck(t, tt, result, true, msg, "function completed in ")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		stop := make(chan struct{})
+		defer close(stop)
+
+		tt, result, msg := CompletesWithin(t, func() { stuckForTest(stop) }, 10*time.Millisecond)
+		assertCheck(t, tt, result, false, msg, "function did not complete within 10ms, still running after ", "[chan receive]:\n", "check.stuckForTest(")
+
+		if strings.Contains(msg, "Test_CompletesWithin.func2(") {
+			t.Errorf("expected message to only contain the stuck goroutine stack, got %s", msg)
+		}
+	})
+}
+
+func stuckForTest(stop <-chan struct{}) { <-stop }
+
+type waiterFunc func() error
+
+func (f waiterFunc) Wait() error { return f() }
+
+func stuckInTest(unblock <-chan struct{}) { <-unblock }
@@ -0,0 +1,27 @@
+package check
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/krostar/test"
+)
+
+// Template is implemented by executable templates, like *text/template.Template and *html/template.Template.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
+
+// TemplateRenders checks that the template executed with `data` renders the content of the golden file located at `wantGolden`.
+// Options can be provided to normalize whitespaces, which are often irrelevant in rendered templates.
+// See GoldenEqual for how golden files are compared and updated.
+// This is usually used like test.Assert(check.TemplateRenders(t, tmpl, data, "testdata/email.golden", check.StringCollapseWhitespace())).
+func TemplateRenders(t test.TestingT, tmpl Template, data any, wantGolden string, opts ...StringOption) (test.TestingT, bool, string) {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return t, false, fmt.Sprintf("unable to execute template: %v", err)
+	}
+
+	return GoldenEqual(t, buf.String(), wantGolden, opts...)
+}
@@ -0,0 +1,29 @@
+package check
+
+import (
+	htmltemplate "html/template"
+	"testing"
+	"text/template"
+)
+
+func Test_TemplateRenders(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tmpl := template.Must(template.New("").Parse("Hello  {{ .Name }},\nwelcome!\n"))
+		tt, result, msg := TemplateRenders(t, tmpl, map[string]string{"Name": "Bob"}, "testdata/template.golden")
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/template.golden")
+
+		htmlTmpl := htmltemplate.Must(htmltemplate.New("").Parse("Hello {{ .Name }},   welcome!"))
+		tt, result, msg = TemplateRenders(t, htmlTmpl, map[string]string{"Name": "Bob"}, "testdata/template.golden", StringCollapseWhitespace())
+		assertCheck(t, tt, result, true, msg, "content is equal to golden file testdata/template.golden")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tmpl := template.Must(template.New("").Parse("Hello {{ .Name }},\nwelcome!\n"))
+		tt, result, msg := TemplateRenders(t, tmpl, map[string]string{"Name": "Alice"}, "testdata/template.golden")
+		assertCheck(t, tt, result, false, msg, "-Hello Alice,\n+Hello  Bob,")
+
+		tmpl = template.Must(template.New("").Option("missingkey=error").Parse("{{ .Name }}"))
+		tt, result, msg = TemplateRenders(t, tmpl, map[string]string{}, "testdata/template.golden")
+		assertCheck(t, tt, result, false, msg, "unable to execute template:", "map has no entry for key")
+	})
+}
@@ -0,0 +1,39 @@
+package check
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/krostar/test"
+)
+
+// TimeBetween checks if a time lies within the [start, end] interval, bounds included.
+// This is usually used like test.Assert(check.TimeBetween(t, event.CreatedAt, before, after)).
+func TimeBetween(t test.TestingT, v, start, end time.Time) (test.TestingT, bool, string) {
+	if end.Before(start) {
+		return t, false, fmt.Sprintf("invalid interval: end %s is before start %s", end.Format(time.RFC3339Nano), start.Format(time.RFC3339Nano))
+	}
+
+	switch {
+	case v.Before(start):
+		return t, false, fmt.Sprintf("expected %s to be within [%s, %s], but it is %s before start", v.Format(time.RFC3339Nano), start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano), start.Sub(v))
+	case v.After(end):
+		return t, false, fmt.Sprintf("expected %s to be within [%s, %s], but it is %s after end", v.Format(time.RFC3339Nano), start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano), v.Sub(end))
+	}
+
+	return t, true, fmt.Sprintf("%s is within [%s, %s]", v.Format(time.RFC3339Nano), start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano))
+}
+
+// Chronological checks if timestamps are in chronological order, that is each timestamp is not before the previous one.
+// Equal consecutive timestamps are allowed.
+// This is usually used like test.Assert(check.Chronological(t, []time.Time{first.At, second.At, third.At})).
+func Chronological(t test.TestingT, timestamps []time.Time) (test.TestingT, bool, string) {
+	for i := 1; i < len(timestamps); i++ {
+		if previous, current := timestamps[i-1], timestamps[i]; current.Before(previous) {
+			return t, false, fmt.Sprintf("timestamps are not in chronological order: timestamp #%d %s is %s before timestamp #%d %s",
+				i, current.Format(time.RFC3339Nano), previous.Sub(current), i-1, previous.Format(time.RFC3339Nano),
+			)
+		}
+	}
+	return t, true, fmt.Sprintf("%d timestamps are in chronological order", len(timestamps))
+}
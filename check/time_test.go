@@ -0,0 +1,50 @@
+package check
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_TimeBetween(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := TimeBetween(t, start.Add(time.Minute), start, end)
+		assertCheck(t, tt, result, true, msg, "2024-01-01T10:01:00Z is within [2024-01-01T10:00:00Z, 2024-01-01T11:00:00Z]")
+
+		tt, result, msg = TimeBetween(t, start, start, end)
+		assertCheck(t, tt, result, true, msg, "is within")
+
+		tt, result, msg = TimeBetween(t, end, start, end)
+		assertCheck(t, tt, result, true, msg, "is within")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := TimeBetween(t, start.Add(-time.Second), start, end)
+		assertCheck(t, tt, result, false, msg, "expected 2024-01-01T09:59:59Z to be within [2024-01-01T10:00:00Z, 2024-01-01T11:00:00Z], but it is 1s before start")
+
+		tt, result, msg = TimeBetween(t, end.Add(time.Millisecond), start, end)
+		assertCheck(t, tt, result, false, msg, "expected 2024-01-01T11:00:00.001Z to be within", "but it is 1ms after end")
+
+		tt, result, msg = TimeBetween(t, start, end, start)
+		assertCheck(t, tt, result, false, msg, "invalid interval: end 2024-01-01T10:00:00Z is before start 2024-01-01T11:00:00Z")
+	})
+}
+
+func Test_Chronological(t *testing.T) {
+	first := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Chronological(t, []time.Time{first, first, first.Add(time.Second)})
+		assertCheck(t, tt, result, true, msg, "3 timestamps are in chronological order")
+
+		tt, result, msg = Chronological(t, nil)
+		assertCheck(t, tt, result, true, msg, "0 timestamps are in chronological order")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := Chronological(t, []time.Time{first, first.Add(time.Minute), first.Add(time.Second)})
+		assertCheck(t, tt, result, false, msg, "timestamps are not in chronological order: timestamp #2 2024-01-01T10:00:01Z is 59s before timestamp #1 2024-01-01T10:01:00Z")
+	})
+}
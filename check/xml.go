@@ -0,0 +1,192 @@
+package check
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/krostar/test"
+)
+
+// XMLOption is a function that configures how XML documents are compared by XMLEqual.
+type XMLOption func(o *xmlOptions)
+
+// XMLIgnoreNamespaces makes the comparison ignore the namespaces of elements and attributes, only comparing their local names.
+func XMLIgnoreNamespaces() XMLOption {
+	return func(o *xmlOptions) { o.ignoreNamespaces = true }
+}
+
+type xmlOptions struct {
+	ignoreNamespaces bool
+}
+
+// XMLEqual checks if two XML documents are semantically equal.
+//
+// Documents are compared as trees of elements: the order of attributes, the namespace prefixes
+// (only the namespaces they refer to matter), comments, processing instructions,
+// and whitespaces surrounding texts are ignored.
+// On failure, the message lists the differences along with the path of the element they were found at.
+// This is usually used like test.Assert(check.XMLEqual(t, body, `<user id="42"><name>bob</name></user>`)).
+func XMLEqual[D ~string | ~[]byte](t test.TestingT, got, want D, opts ...XMLOption) (test.TestingT, bool, string) {
+	var o xmlOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	gotRoot, err := parseXML(string(got), o)
+	if err != nil {
+		return t, false, fmt.Sprintf("unable to parse got XML document: %v", err)
+	}
+
+	wantRoot, err := parseXML(string(want), o)
+	if err != nil {
+		return t, false, fmt.Sprintf("unable to parse want XML document: %v", err)
+	}
+
+	var diffs []string
+	compareXMLNodes("/"+gotRoot.name, gotRoot, wantRoot, &diffs)
+
+	if len(diffs) > 0 {
+		return t, false, fmt.Sprintf("XML documents differ:\n  - %s", strings.Join(diffs, "\n  - "))
+	}
+
+	return t, true, "XML documents are equal"
+}
+
+// xmlNode is an element of a parsed XML document.
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*xmlNode
+}
+
+// parseXML parses the document into a tree of elements, and returns its root element.
+func parseXML(doc string, o xmlOptions) (*xmlNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(doc))
+
+	var (
+		root  *xmlNode
+		stack []*xmlNode
+		texts []*strings.Builder
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, errors.New("document has multiple root elements")
+			}
+
+			node := &xmlNode{name: o.name(tok.Name), attrs: make(map[string]string)}
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue // namespaces declarations are compared through the names they resolve
+				}
+				node.attrs[o.name(attr.Name)] = attr.Value
+			}
+
+			if root == nil {
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+
+			stack = append(stack, node)
+			texts = append(texts, new(strings.Builder))
+
+		case xml.EndElement:
+			stack[len(stack)-1].text = strings.TrimSpace(texts[len(texts)-1].String())
+			stack, texts = stack[:len(stack)-1], texts[:len(texts)-1]
+
+		case xml.CharData:
+			if len(texts) > 0 {
+				texts[len(texts)-1].Write(tok)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("document has no root element")
+	}
+
+	return root, nil
+}
+
+// name returns the name used to compare elements and attributes.
+func (o xmlOptions) name(name xml.Name) string {
+	if name.Space == "" || o.ignoreNamespaces {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// compareXMLNodes appends to diffs the differences between the two elements located at path.
+func compareXMLNodes(path string, got, want *xmlNode, diffs *[]string) {
+	if got.name != want.name {
+		*diffs = append(*diffs, fmt.Sprintf("%s: got element %s, want element %s", path, got.name, want.name))
+		return
+	}
+
+	keys := slices.Collect(maps.Keys(got.attrs))
+	for key := range want.attrs {
+		if _, exists := got.attrs[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		gotValue, inGot := got.attrs[key]
+		wantValue, inWant := want.attrs[key]
+
+		switch {
+		case !inWant:
+			*diffs = append(*diffs, fmt.Sprintf("%s/@%s: unexpected attribute with value %q", path, key, gotValue))
+		case !inGot:
+			*diffs = append(*diffs, fmt.Sprintf("%s/@%s: missing attribute with value %q", path, key, wantValue))
+		case gotValue != wantValue:
+			*diffs = append(*diffs, fmt.Sprintf("%s/@%s: got %q, want %q", path, key, gotValue, wantValue))
+		}
+	}
+
+	if got.text != want.text {
+		*diffs = append(*diffs, fmt.Sprintf("%s/text(): got %q, want %q", path, got.text, want.text))
+	}
+
+	for i := range max(len(got.children), len(want.children)) {
+		switch {
+		case i >= len(want.children):
+			*diffs = append(*diffs, fmt.Sprintf("%s: unexpected element %s", xmlChildPath(path, got.children, i), got.children[i].name))
+		case i >= len(got.children):
+			*diffs = append(*diffs, fmt.Sprintf("%s: missing element %s", xmlChildPath(path, want.children, i), want.children[i].name))
+		default:
+			compareXMLNodes(xmlChildPath(path, got.children, i), got.children[i], want.children[i], diffs)
+		}
+	}
+}
+
+// xmlChildPath returns the path of the i-th of the children elements, with its 1-based position among its siblings
+// of the same name, like XPath does.
+func xmlChildPath(parent string, children []*xmlNode, i int) string {
+	position := 1
+	for _, sibling := range children[:i] {
+		if sibling.name == children[i].name {
+			position++
+		}
+	}
+	return fmt.Sprintf("%s/%s[%d]", parent, children[i].name, position)
+}
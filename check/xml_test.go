@@ -0,0 +1,57 @@
+package check
+
+import (
+	"testing"
+)
+
+func Test_XMLEqual(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := XMLEqual(t,
+			`<?xml version="1.0"?><user id="42" role="admin"><!-- comment --><name> bob </name></user>`,
+			`<user role="admin" id="42">
+				<name>bob</name>
+			</user>`,
+		)
+		assertCheck(t, tt, result, true, msg, "XML documents are equal")
+
+		tt, result, msg = XMLEqual(t,
+			[]byte(`<a:user xmlns:a="urn:users" a:id="42"/>`),
+			[]byte(`<b:user xmlns:b="urn:users" b:id="42"/>`),
+		)
+		assertCheck(t, tt, result, true, msg, "XML documents are equal")
+
+		tt, result, msg = XMLEqual(t, `<a:user xmlns:a="urn:users"/>`, `<user/>`, XMLIgnoreNamespaces())
+		assertCheck(t, tt, result, true, msg, "XML documents are equal")
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		tt, result, msg := XMLEqual(t,
+			`<users><user id="1" extra="x"><name>bob</name></user><user id="2"/><user id="3"/></users>`,
+			`<users><user id="1" role="admin"><name>alice</name></user><group id="2"/></users>`,
+		)
+		assertCheck(t, tt, result, false, msg, `XML documents differ:
+  - /users/user[1]/@extra: unexpected attribute with value "x"
+  - /users/user[1]/@role: missing attribute with value "admin"
+  - /users/user[1]/name[1]/text(): got "bob", want "alice"
+  - /users/user[2]: got element user, want element group
+  - /users/user[3]: unexpected element user`)
+
+		tt, result, msg = XMLEqual(t,
+			`<users><group id="1"/><user id="2"/><group id="3"/><user id="4"/></users>`,
+			`<users><group id="1"/><user id="2"/><group id="3"/><user id="5"/></users>`,
+		)
+		assertCheck(t, tt, result, false, msg, `/users/user[2]/@id: got "4", want "5"`)
+
+		tt, result, msg = XMLEqual(t, `<a:user xmlns:a="urn:users"/>`, `<user/>`)
+		assertCheck(t, tt, result, false, msg, "/{urn:users}user: got element {urn:users}user, want element user")
+
+		tt, result, msg = XMLEqual(t, `<user>`, `<user/>`)
+		assertCheck(t, tt, result, false, msg, "unable to parse got XML document")
+
+		tt, result, msg = XMLEqual(t, `<user/>`, `<user/><user/>`)
+		assertCheck(t, tt, result, false, msg, "unable to parse want XML document: document has multiple root elements")
+
+		tt, result, msg = XMLEqual(t, `<user/>`, ``)
+		assertCheck(t, tt, result, false, msg, "unable to parse want XML document: document has no root element")
+	})
+}
@@ -0,0 +1,20 @@
+// Command krostar-test-analyzer runs the analyzers of the krostar/test analyzer package.
+//
+// It can be run directly:
+//
+//	krostar-test-analyzer ./...
+//
+// or through go vet:
+//
+//	go vet -vettool=$(which krostar-test-analyzer) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/krostar/test/analyzer"
+)
+
+func main() {
+	multichecker.Main(analyzer.Analyzers()...)
+}
@@ -0,0 +1,58 @@
+// Command krostar-test-callsites lists the assertions of packages, along with the message each of them would log on failure.
+//
+// Usage:
+//
+//	krostar-test-callsites [-json] [package directories...]
+//
+// Without arguments, the package of the current directory is used.
+// With the -json flag, call sites are written as a JSON array, for editors integrations.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/krostar/test"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "Whether to write call sites as a JSON array")
+	flag.Parse()
+
+	if err := run(os.Stdout, *asJSON, flag.Args()); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, asJSON bool, dirs []string) error {
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	callSites := []test.CallSite{}
+	for _, dir := range dirs {
+		found, err := test.CallSites(dir)
+		if err != nil {
+			return fmt.Errorf("unable to list call sites of %s: %v", dir, err)
+		}
+		callSites = append(callSites, found...)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(callSites)
+	}
+
+	for _, callSite := range callSites {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s\n", callSite.File, callSite.Line, callSite.Column, callSite.Function, callSite.FailureMessage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/krostar/test"
+)
+
+const callSitesDir = "../../internal/message/testdata/callsites"
+
+func Test_run(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := run(&buf, false, []string{callSitesDir}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("expected 3 call sites, got %d:\n%s", len(lines), buf.String())
+		}
+
+		if expected := "callsites_test.go:13:2: test.Assert: got is not equal to want"; !strings.HasSuffix(lines[0], expected) {
+			t.Errorf("expected first line to end with %q, got %q", expected, lines[0])
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := run(&buf, true, []string{callSitesDir, callSitesDir}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var callSites []test.CallSite
+		if err := json.Unmarshal(buf.Bytes(), &callSites); err != nil {
+			t.Fatalf("unable to decode output: %v", err)
+		}
+
+		if len(callSites) != 6 {
+			t.Fatalf("expected the call sites of both directories, got %d", len(callSites))
+		}
+
+		if callSites[0].Function != "test.Assert" || callSites[0].Line != 13 {
+			t.Errorf("unexpected first call site %+v", callSites[0])
+		}
+	})
+
+	t.Run("no call sites", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := run(&buf, true, []string{"."}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if expected := "[]\n"; buf.String() != expected {
+			t.Errorf("expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("invalid directory", func(t *testing.T) {
+		err := run(new(bytes.Buffer), false, []string{"./does-not-exist"})
+		if err == nil || !strings.Contains(err.Error(), "unable to list call sites of ./does-not-exist") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
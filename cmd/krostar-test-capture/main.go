@@ -0,0 +1,18 @@
+// Command krostar-test-capture attaches the values of the operands of assertions with test.Values,
+// so that failures display them alongside the description of the failed expression.
+//
+// It runs the CaptureValues analyzer of the krostar/test analyzer package. Without flags, it lists
+// the assertions whose values are not attached; with the -fix flag, it rewrites them:
+//
+//	krostar-test-capture -fix ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/krostar/test/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.CaptureValues)
+}
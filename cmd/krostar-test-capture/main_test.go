@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestMain runs the command instead of the tests when the test binary is executed by runCommand.
+func TestMain(m *testing.M) {
+	if os.Getenv("KROSTAR_TEST_CAPTURE_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func Test_main(t *testing.T) {
+	const pkg = "./internal/message/testdata/callsites"
+
+	t.Run("lists assertions without values", func(t *testing.T) {
+		stdout, stderr, exitCode := runCommand(t, pkg)
+		if exitCode != 3 {
+			t.Errorf("expected diagnostics to be reported with exit code 3, got %d: %s", exitCode, stderr)
+		}
+
+		for _, expected := range []string{
+			"callsites_test.go:13:17: values of got, want are not attached to the assertion",
+			"callsites_test.go:16:18: values of err are not attached to the assertion",
+		} {
+			if !strings.Contains(stderr+stdout, expected) {
+				t.Errorf("expected output to contain %q, got:\n%s%s", expected, stdout, stderr)
+			}
+		}
+	})
+
+	t.Run("suggests to attach values", func(t *testing.T) {
+		stdout, stderr, exitCode := runCommand(t, "-fix", "-diff", pkg)
+		if exitCode != 0 {
+			t.Errorf("expected exit code 0, got %d: %s", exitCode, stderr)
+		}
+
+		for _, expected := range []string{
+			"+	test.Assert(t, got == want, test.Values(got, want))",
+			`+	test.Require(t, err == nil, "no error expected", test.Values(err))`,
+		} {
+			if !strings.Contains(stdout, expected) {
+				t.Errorf("expected diff to contain %q, got:\n%s", expected, stdout)
+			}
+		}
+	})
+}
+
+// runCommand runs the command with the provided arguments from the root of the module.
+func runCommand(t *testing.T, args ...string) (string, string, int) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(t.Context(), os.Args[0], args...) //nolint:gosec // the test binary runs itself
+	cmd.Dir = "../.."
+	cmd.Env = append(os.Environ(), "KROSTAR_TEST_CAPTURE_RUN_MAIN=1")
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("unable to run the command: %v", err)
+		}
+		return stdout.String(), stderr.String(), exitErr.ExitCode()
+	}
+
+	return stdout.String(), stderr.String(), 0
+}
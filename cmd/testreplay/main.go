@@ -0,0 +1,119 @@
+// Command testreplay pretty-prints the replay files written by failed assertions, see test.Replay.
+//
+// Usage:
+//
+//	testreplay [replay files or directories...]
+//
+// Directories are expanded to the replay files they contain.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/krostar/test"
+)
+
+func main() {
+	if err := run(os.Stdout, os.Args[1:]); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("usage: %s [replay files or directories...]", filepath.Base(os.Args[0]))
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("unable to stat %s: %v", path, err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		found, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return fmt.Errorf("unable to list replay files of %s: %v", path, err)
+		}
+		files = append(files, found...)
+	}
+
+	for i, file := range files {
+		replay, err := readReplay(file)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		if err := printReplay(w, file, replay); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readReplay(path string) (*test.Replay, error) {
+	raw, err := os.ReadFile(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("unable to read replay file: %v", err)
+	}
+
+	var replay test.Replay
+	if err := json.Unmarshal(raw, &replay); err != nil {
+		return nil, fmt.Errorf("unable to decode replay file %s: %v", path, err)
+	}
+
+	return &replay, nil
+}
+
+func printReplay(w io.Writer, path string, replay *test.Replay) error {
+	name := replay.Test
+	if name == "" {
+		name = "<unknown>"
+	}
+
+	env := replay.Environment
+
+	_, err := fmt.Fprintf(w, "=== %s\nTest:        %s\nLocation:    %s:%d\nExpression:  %s\nMessage:     %s\nRecorded at: %s\nEnvironment: %s %s/%s, %d CPUs, host %q, in %s\n",
+		path, name, replay.File, replay.Line, replay.Expression, replay.Message,
+		replay.RecordedAt.Format(time.RFC3339), env.GoVersion, env.OS, env.Arch, env.NumCPU, env.Hostname, env.WorkingDirectory,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(replay.Operands) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintln(w, "Operands:"); err != nil {
+		return err
+	}
+
+	for _, operand := range slices.Sorted(maps.Keys(replay.Operands)) {
+		if _, err := fmt.Fprintf(w, "  %s = %s\n", operand, replay.Operands[operand]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
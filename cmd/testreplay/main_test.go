@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krostar/test"
+)
+
+func Test_run(t *testing.T) {
+	dir := t.TempDir()
+
+	writeReplay(t, filepath.Join(dir, "a.json"), test.Replay{
+		Test:       "Test_Something",
+		File:       "something_test.go",
+		Line:       42,
+		Expression: "got == want",
+		Message:    "got is not equal to want",
+		Operands:   map[string]string{"want": "2", "got": "1"},
+		Environment: test.ReplayEnvironment{
+			GoVersion: "go1.25.0", OS: "linux", Arch: "amd64", NumCPU: 8, Hostname: "ci", WorkingDirectory: "/src",
+		},
+		RecordedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	writeReplay(t, filepath.Join(dir, "b.json"), test.Replay{File: "other_test.go", Line: 1})
+
+	t.Run("file", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := run(&buf, []string{filepath.Join(dir, "a.json")}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := "=== " + filepath.Join(dir, "a.json") + "\n" +
+			"Test:        Test_Something\n" +
+			"Location:    something_test.go:42\n" +
+			"Expression:  got == want\n" +
+			"Message:     got is not equal to want\n" +
+			"Recorded at: 2026-01-02T03:04:05Z\n" +
+			"Environment: go1.25.0 linux/amd64, 8 CPUs, host \"ci\", in /src\n" +
+			"Operands:\n" +
+			"  got = 1\n" +
+			"  want = 2\n"
+		if buf.String() != expected {
+			t.Errorf("unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := run(&buf, []string{dir}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "Operands:\n  got = 1\n  want = 2\n\n=== "+filepath.Join(dir, "b.json")+"\nTest:        <unknown>\n") {
+			t.Errorf("expected both replays to be printed, separated by an empty line, got:\n%s", buf.String())
+		}
+
+		if strings.Count(buf.String(), "Operands:") != 1 {
+			t.Errorf("expected replays without operands not to list them, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.json")
+		if err := os.WriteFile(invalid, []byte("{"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, tc := range map[string]struct {
+			paths    []string
+			expected string
+		}{
+			"no paths":     {paths: nil, expected: "usage: "},
+			"missing file": {paths: []string{filepath.Join(dir, "missing.json")}, expected: "unable to stat "},
+			"invalid file": {paths: []string{invalid}, expected: "unable to decode replay file " + invalid},
+		} {
+			t.Run(name, func(t *testing.T) {
+				err := run(new(bytes.Buffer), tc.paths)
+				if err == nil || !strings.Contains(err.Error(), tc.expected) {
+					t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+				}
+			})
+		}
+	})
+}
+
+func writeReplay(t *testing.T, path string, replay test.Replay) {
+	t.Helper()
+
+	raw, err := json.Marshal(replay)
+	if err != nil {
+		t.Fatalf("unable to encode replay: %v", err)
+	}
+
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("unable to write replay: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/krostar/test/testingt"
+)
+
+// Collector is a TestingT recording the failures of the assertions made on it, instead of failing the test immediately.
+// It is created with Collect.
+type Collector struct {
+	TestingT
+
+	m        sync.Mutex
+	failures []string
+	failed   bool
+}
+
+// Collect returns a Collector, on which assertions record their failures without failing the test.
+// Collected failures are reported all at once by Report, which is automatically called when the test completes.
+//
+// It is meant for long validations, like table-driven checks of many fields, where seeing every mismatch at once
+// is more helpful than stopping, or being flooded, at the first one. Require still stops the test immediately,
+// after reporting the failures collected so far.
+//
+// Example:
+//
+//	func Test_Import(t *testing.T) {
+//		c := test.Collect(t)
+//		for i, row := range rows {
+//			c.Assert(row.Valid(), "row %d", i)
+//		}
+//		c.Report()
+//	}
+func Collect(t TestingT) *Collector {
+	c := &Collector{TestingT: t}
+	t.Cleanup(c.Report)
+	return c
+}
+
+// Assert behaves like the Assert function made on the collector: a failure is recorded, and reported later by Report.
+func (c *Collector) Assert(result bool, msgAndArgs ...any) bool {
+	c.Helper()
+
+	logResult(c, result, 1, 0, msgAndArgs...)
+
+	return result
+}
+
+// reportFailure records the failure, to be reported by Report.
+func (c *Collector) reportFailure(opts options, failure Failure) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.failures = append(c.failures, colorizeMessage(opts.color, failure.Message))
+}
+
+// Fail records that the test failed, to fail it on the next Report.
+// Failures of assertions are recorded by reportFailure, while this covers the helpers failing the collector directly.
+func (c *Collector) Fail() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.failed = true
+}
+
+// FailNow reports the collected failures, and stops the test.
+func (c *Collector) FailNow() {
+	c.Helper()
+	c.Report()
+	c.TestingT.FailNow()
+}
+
+// Unwrap returns the TestingT the collector reports failures to, see testingt.Unwrap.
+func (c *Collector) Unwrap() testingt.TestingT { return c.TestingT }
+
+// Report logs the failures collected since the last report, if any, and fails the test
+// if there were any, or if the collector failed since the last report.
+func (c *Collector) Report() {
+	c.Helper()
+
+	c.m.Lock()
+	failures, failed := c.failures, c.failed
+	c.failures, c.failed = nil, false
+	c.m.Unlock()
+
+	if len(failures) == 0 && !failed {
+		return
+	}
+
+	if len(failures) > 0 {
+		c.Logf("Error: %d collected failures:\n  - %s", len(failures), strings.Join(failures, "\n  - "))
+	}
+	c.TestingT.Fail()
+}
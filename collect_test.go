@@ -0,0 +1,78 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Collect(t *testing.T) {
+	t.Run("failures are reported at once", func(t *testing.T) {
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))
+		c := Collect(spiedT)
+
+		got, want := 1, 2
+		c.Assert(got == want, "first")
+		Assert(c, got > want)
+		c.Assert(got < want)
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+
+		c.Report()
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: 2 collected failures:\n  - got is not equal to want [first]\n  - got is less than or equal to want")
+
+		cleanup() // failures are not reported twice
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "Fail"})
+	})
+
+	t.Run("failures are reported when test completes", func(t *testing.T) {
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))
+		c := Collect(spiedT)
+
+		c.Assert(false)
+
+		spiedT.ExpectTestToPass(t)
+		cleanup()
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: 1 collected failures:\n  - literal false")
+	})
+
+	t.Run("require reports and stops", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		c := Collect(spiedT)
+
+		c.Assert(false, "first")
+		Require(c, false, "second")
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+		spiedT.ExpectLogsToContain(t, "Error: 2 collected failures:\n  - literal false [first]\n  - literal false [second]")
+	})
+	t.Run("failures of other helpers are reported", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		c := Collect(spiedT)
+
+		helper := double.NewSpy(double.NewFake())
+		helper.Fail()
+		helper.ExpectTestToPass(c)
+
+		spiedT.ExpectTestToPass(t)
+		c.Report()
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Expected test to succeed but test failed")
+
+		spiedT = double.NewSpy(double.NewFake())
+		c = Collect(spiedT)
+		c.Fail()
+		c.Report()
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectNoLogs(t)
+	})
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ColorMode controls whether failure messages are colored with ANSI escape codes.
+type ColorMode string
+
+// Available color modes.
+const (
+	// ColorNever never colors messages, it is the default.
+	ColorNever ColorMode = "never"
+	// ColorAlways always colors messages.
+	ColorAlways ColorMode = "always"
+	// ColorAuto colors messages if the standard output is a terminal, and the NO_COLOR environment variable is not set.
+	ColorAuto ColorMode = "auto"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	_flagColorOutput = newEnumFlag("check.color", "Whether to color failure messages: never, always, or auto to color them only in terminals without NO_COLOR set",
+		ColorNever, ColorAlways, ColorAuto,
+	)
+
+	_colorInlineDeletion  = regexp.MustCompile(`\[-.*?-\]`)
+	_colorInlineInsertion = regexp.MustCompile(`\{\+.*?\+\}`)
+)
+
+const (
+	_colorReset = "\x1b[0m"
+	_colorBold  = "\x1b[1m"
+	_colorRed   = "\x1b[31m"
+	_colorGreen = "\x1b[32m"
+	_colorCyan  = "\x1b[36m"
+)
+
+// WithColor sets whether the failure and warning messages of assertions are colored, see ColorMode,
+// like the -check.color flag does for all assertions.
+// When enabled, the description of the failing expression is in bold, and the lines and runs of characters
+// of differences, like the ones produced by check.Compare, are colored in red when they are only in the
+// first value (usually got) and in green when they are only in the second value (usually want).
+func WithColor(mode ColorMode) Option {
+	return func(o *options) { o.color = mode }
+}
+
+// defaultColorMode returns the color mode set by the flag, or ColorNever if the flag is not set.
+func defaultColorMode() ColorMode {
+	if _flagColorOutput.value != "" {
+		return _flagColorOutput.value
+	}
+	return ColorNever
+}
+
+// colorEnabled returns whether messages should be colored in the provided mode.
+func colorEnabled(mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		return terminalSupportsColor()
+	default:
+		return false
+	}
+}
+
+// terminalSupportsColor returns whether the standard output is a terminal able to display colors.
+// See https://no-color.org for the NO_COLOR convention.
+func terminalSupportsColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorizeMessage colors the failure message, if colors are enabled in the provided mode.
+// The first line, describing the failing expression, is in bold.
+// On the following lines, removed lines and runs of characters are in red, added ones are in green,
+// and unified diff hunk headers are in cyan.
+func colorizeMessage(mode ColorMode, msg string) string {
+	if msg == "" || !colorEnabled(mode) {
+		return msg
+	}
+
+	lines := strings.Split(msg, "\n")
+	lines[0] = _colorBold + lines[0] + _colorReset
+
+	for i, line := range lines[1:] {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			line = _colorCyan + line + _colorReset
+		case strings.HasPrefix(line, "-"):
+			line = _colorRed + line + _colorReset
+		case strings.HasPrefix(line, "+"):
+			line = _colorGreen + line + _colorReset
+		default:
+			line = _colorInlineDeletion.ReplaceAllString(line, _colorRed+"$0"+_colorReset)
+			line = _colorInlineInsertion.ReplaceAllString(line, _colorGreen+"$0"+_colorReset)
+		}
+		lines[i+1] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_colorEnabled(t *testing.T) {
+	for mode, expected := range map[ColorMode]bool{
+		ColorNever:  false,
+		ColorAlways: true,
+		ColorAuto:   false, // tests output is not a terminal
+		"unknown":   false,
+	} {
+		if enabled := colorEnabled(mode); enabled != expected {
+			t.Errorf("expected color mode %q to be enabled=%t, got %t", mode, expected, enabled)
+		}
+	}
+
+	t.Run("auto with NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+
+		if colorEnabled(ColorAuto) {
+			t.Error("expected colors to be disabled when NO_COLOR is set")
+		}
+	})
+}
+
+func Test_colorizeMessage(t *testing.T) {
+	msg := "comparison differs: \n  int(\n-\t1,\n+\t2,\n  )\n@@ -1,1 +1,1 @@\n  diff: re[-fu-]{+jec+}ted"
+
+	if got := colorizeMessage(ColorNever, msg); got != msg {
+		t.Errorf("expected message to be left as is, got %q", got)
+	}
+
+	if got, want := colorizeMessage(ColorAlways, msg), "\x1b[1mcomparison differs: \x1b[0m\n"+
+		"  int(\n"+
+		"\x1b[31m-\t1,\x1b[0m\n"+
+		"\x1b[32m+\t2,\x1b[0m\n"+
+		"  )\n"+
+		"\x1b[36m@@ -1,1 +1,1 @@\x1b[0m\n"+
+		"  diff: re\x1b[31m[-fu-]\x1b[0m\x1b[32m{+jec+}\x1b[0mted"; got != want {
+		t.Errorf("unexpected colored message:\n got: %q\nwant: %q", got, want)
+	}
+
+	t.Run("failures are colored", func(t *testing.T) {
+		if err := flag.Set("check.color", string(ColorAlways)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(func() { _flagColorOutput.value = "" })
+
+		spiedT := double.NewSpy(double.NewFake())
+		Assert(spiedT, false)
+		spiedT.ExpectLogsToContain(t, "Error: \x1b[1mliteral false\x1b[0m")
+	})
+
+	t.Run("option", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		Warn(spiedT, false, WithColor(ColorAlways))
+		spiedT.ExpectLogsToContain(t, "Warning: \x1b[1mliteral false\x1b[0m")
+	})
+
+	t.Run("invalid flag", func(t *testing.T) {
+		err := flag.Set("check.color", "sometimes")
+		if err == nil || err.Error() != "expected one of [never always auto]" {
+			t.Errorf("expected invalid values to be rejected, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/krostar/test/testingt"
+)
+
+// DeduplicateFailures returns a TestingT coalescing the failures of the assertions made on it by call site.
+//
+// An assertion failing in a loop, like a broken invariant checked against every case of a table,
+// can produce thousands of nearly identical messages. Only the first failure of each call site is logged as usual,
+// the following ones are counted, and summarized once the test completes, along with a sample of their messages
+// showing the differing inputs. The test fails the same way as without deduplication.
+//
+// Example:
+//
+//	func Test_Parse(t *testing.T) {
+//		tt := test.DeduplicateFailures(t)
+//		for _, input := range inputs {
+//			test.Assert(tt, Parse(input) == nil, "input %q", input)
+//		}
+//	}
+func DeduplicateFailures(t TestingT) TestingT {
+	d := &deduplicatedT{TestingT: t, sites: make(map[string]*duplicatedFailures)}
+	t.Cleanup(d.flush)
+	return d
+}
+
+// deduplicatedFailuresSamples is the number of messages kept to illustrate the duplicated failures of a call site.
+const deduplicatedFailuresSamples = 3
+
+// deduplicatedT wraps a TestingT to coalesce failures by call site, see DeduplicateFailures.
+type deduplicatedT struct {
+	TestingT
+
+	m     sync.Mutex
+	sites map[string]*duplicatedFailures // keyed by the full path and line of the call sites
+	order []string                       // call sites, in the order of their first failure
+}
+
+// duplicatedFailures holds the failures of a call site following its first failure.
+type duplicatedFailures struct {
+	location string // short location of the call site, for the summary
+	count    int
+	samples  []string
+}
+
+// reportFailure logs the first failure of a call site, and records the following ones.
+func (d *deduplicatedT) reportFailure(opts options, failure Failure) {
+	d.Helper()
+
+	site := fmt.Sprintf("%s:%d", failure.File, failure.Line)
+
+	d.m.Lock()
+	duplicates, seen := d.sites[site]
+	if !seen {
+		d.sites[site] = &duplicatedFailures{location: fmt.Sprintf("%s:%d", filepath.Base(failure.File), failure.Line)}
+		d.order = append(d.order, site)
+	} else {
+		duplicates.count++
+		if len(duplicates.samples) < deduplicatedFailuresSamples && !slices.Contains(duplicates.samples, failure.Message) {
+			duplicates.samples = append(duplicates.samples, failure.Message)
+		}
+	}
+	d.m.Unlock()
+
+	if !seen {
+		logFailure(d.TestingT, opts, failure)
+	}
+}
+
+// Unwrap returns the wrapped TestingT, see testingt.Unwrap.
+func (d *deduplicatedT) Unwrap() testingt.TestingT { return d.TestingT }
+
+// flush logs the summary of the duplicated failures of each call site.
+func (d *deduplicatedT) flush() {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	for _, site := range d.order {
+		duplicates := d.sites[site]
+		if duplicates.count == 0 {
+			continue
+		}
+
+		d.Logf("Error: %d more failures at %s, like:\n  - %s", duplicates.count, duplicates.location, strings.Join(duplicates.samples, "\n  - "))
+		duplicates.count, duplicates.samples = 0, nil
+	}
+}
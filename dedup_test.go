@@ -0,0 +1,45 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_DeduplicateFailures(t *testing.T) {
+	var cleanup func()
+
+	spiedT := double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))
+	tt := DeduplicateFailures(spiedT)
+
+	for i := range 6 {
+		Assert(tt, i < 0, "input %d", i%4)
+	}
+	Assert(tt, false, "other")
+
+	spiedT.ExpectTestToFail(t)
+	spiedT.ExpectLogsToContain(t, "Error: i is greater than or equal to 0 [input 0]")
+	spiedT.ExpectLogsToContain(t, "Error: literal false [other]")
+
+	cleanup()
+
+	spiedT.ExpectLogsToContain(t, "Error: 5 more failures at dedup_test.go:16, like:\n"+
+		"  - i is greater than or equal to 0 [input 1]\n"+
+		"  - i is greater than or equal to 0 [input 2]\n"+
+		"  - i is greater than or equal to 0 [input 3]",
+	)
+}
+
+func Test_deduplicatedT_reportFailure(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+	d := DeduplicateFailures(spiedT).(*deduplicatedT)
+
+	d.reportFailure(options{}, Failure{File: "/src/a/foo_test.go", Line: 1, Message: "in a"})
+	d.reportFailure(options{}, Failure{File: "/src/b/foo_test.go", Line: 1, Message: "in b"})
+
+	spiedT.ExpectLogsToContain(t, "Error: in a", "Error: in b")
+
+	if len(d.order) != 2 {
+		t.Errorf("expected call sites of files sharing the same name to be distinct, got %v", d.order)
+	}
+}
@@ -2,6 +2,8 @@ package double
 
 import (
 	"context"
+	"fmt"
+	"os"
 )
 
 // Fake implements a minimal TestingT that does nothing.
@@ -13,8 +15,9 @@ type Fake struct {
 // NewFake creates a new Fake test double.
 func NewFake(opts ...FakeOption) *Fake {
 	o := &fakeOptions{
-		registerCleanup: func(func()) {},
-		context:         context.Background(),
+		setenv:  func(string, string) {},
+		chdir:   func(string) {},
+		context: context.Background(),
 	}
 
 	for _, opt := range opts {
@@ -30,7 +33,17 @@ func (Fake) Helper() {}
 
 // Cleanup implements the TestingT interface.
 // Registers a function to be called when the test completes.
-func (t Fake) Cleanup(f func()) { t.o.registerCleanup(f) }
+// Unless the Fake is created with FakeWithRegisterCleanup, the function is never called.
+func (t Fake) Cleanup(f func()) {
+	if t.o.registerCleanup != nil {
+		t.o.registerCleanup(f)
+	}
+}
+
+// CleanupsIgnored returns whether the functions registered with Cleanup are never called,
+// which is the case unless the Fake is created with FakeWithRegisterCleanup.
+// It allows code registering cleanups to avoid keeping resources that would never be released.
+func (t Fake) CleanupsIgnored() bool { return t.o.registerCleanup == nil }
 
 // Fail implements the TestingT interface.
 // This is a no-op implementation.
@@ -53,3 +66,35 @@ func (Fake) Logf(string, ...any) {}
 func (t Fake) Context() context.Context {
 	return t.o.context
 }
+
+// Name implements the TestingT interface.
+// Returns the name specified during creation, or an empty string by default.
+func (t Fake) Name() string {
+	return t.o.name
+}
+
+// TempDir implements the TestingT interface.
+// Calls the function specified during creation if any, otherwise creates a new directory, like testing.T.TempDir,
+// whose removal is registered with Cleanup. As Fake cannot fail, it panics if the directory cannot be created.
+func (t Fake) TempDir() string {
+	if t.o.tempDir != nil {
+		return t.o.tempDir()
+	}
+
+	dir, err := os.MkdirTemp("", "krostar-test-fake-*")
+	if err != nil {
+		panic(fmt.Sprintf("unable to create temporary directory: %v", err))
+	}
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+// Setenv implements the TestingT interface.
+// Calls the function specified during creation, does nothing by default.
+func (t Fake) Setenv(key, value string) { t.o.setenv(key, value) }
+
+// Chdir implements the TestingT interface.
+// Calls the function specified during creation, does nothing by default.
+func (t Fake) Chdir(dir string) { t.o.chdir(dir) }
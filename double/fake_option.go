@@ -20,7 +20,36 @@ func FakeWithRegisterCleanup(f func(func())) FakeOption {
 	return func(o *fakeOptions) { o.registerCleanup = f }
 }
 
+// FakeWithName sets the name of the test returned by Name().
+// By default, Name() returns an empty string.
+func FakeWithName(name string) FakeOption {
+	return func(o *fakeOptions) { o.name = name }
+}
+
+// FakeWithTempDir configures the function called by TempDir.
+// By default, TempDir creates a new directory, removed by the functions registered with Cleanup,
+// which only run if the Fake is created with FakeWithRegisterCleanup.
+func FakeWithTempDir(f func() string) FakeOption {
+	return func(o *fakeOptions) { o.tempDir = f }
+}
+
+// FakeWithSetenv configures the function called by Setenv.
+// This allows tests to capture the environment variables set through the Fake.
+func FakeWithSetenv(f func(key, value string)) FakeOption {
+	return func(o *fakeOptions) { o.setenv = f }
+}
+
+// FakeWithChdir configures the function called by Chdir.
+// This allows tests to capture the working directories set through the Fake.
+func FakeWithChdir(f func(dir string)) FakeOption {
+	return func(o *fakeOptions) { o.chdir = f }
+}
+
 type fakeOptions struct {
+	name            string
 	registerCleanup func(func())
+	setenv          func(key, value string)
+	chdir           func(dir string)
+	tempDir         func() string
 	context         context.Context //nolint:containedctx // we store a context so fake can return it
 }
@@ -30,3 +30,49 @@ func Test_FakeWithRegisterCleanup(t *testing.T) {
 		t.Error("registerCleanup was not set")
 	}
 }
+
+func Test_FakeWithName(t *testing.T) {
+	o := new(fakeOptions)
+
+	FakeWithName("Test_Foo")(o)
+
+	if o.name != "Test_Foo" {
+		t.Errorf("o.name should be Test_Foo, got %q", o.name)
+	}
+}
+
+func Test_FakeWithTempDir(t *testing.T) {
+	o := new(fakeOptions)
+
+	FakeWithTempDir(func() string { return "/tmp/foo" })(o)
+
+	if dir := o.tempDir(); dir != "/tmp/foo" {
+		t.Errorf("tempDir was not set, got %q", dir)
+	}
+}
+
+func Test_FakeWithSetenv(t *testing.T) {
+	o := new(fakeOptions)
+
+	var key, value string
+	FakeWithSetenv(func(k, v string) { key, value = k, v })(o)
+
+	o.setenv("FOO", "bar")
+
+	if key != "FOO" || value != "bar" {
+		t.Errorf("setenv was not set, got %q=%q", key, value)
+	}
+}
+
+func Test_FakeWithChdir(t *testing.T) {
+	o := new(fakeOptions)
+
+	var dir string
+	FakeWithChdir(func(d string) { dir = d })(o)
+
+	o.chdir("/tmp")
+
+	if dir != "/tmp" {
+		t.Errorf("chdir was not set, got %q", dir)
+	}
+}
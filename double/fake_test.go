@@ -0,0 +1,50 @@
+package double
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_Fake_TempDir(t *testing.T) {
+	var cleanups []func()
+
+	fake := NewFake(FakeWithRegisterCleanup(func(f func()) { cleanups = append(cleanups, f) }))
+
+	first, second := fake.TempDir(), fake.TempDir()
+	if first == second {
+		t.Errorf("expected each call to create a new directory, got %s twice", first)
+	}
+
+	for _, dir := range []string{first, second} {
+		if stat, err := os.Stat(dir); err != nil || !stat.IsDir() {
+			t.Fatalf("expected %s to be a directory: %v", dir, err)
+		}
+	}
+
+	if len(cleanups) != 2 {
+		t.Fatalf("expected the removal of each directory to be registered, got %d cleanups", len(cleanups))
+	}
+
+	for _, cleanup := range cleanups {
+		cleanup()
+	}
+
+	for _, dir := range []string{first, second} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", dir, err)
+		}
+	}
+}
+
+func Test_Fake_CleanupsIgnored(t *testing.T) {
+	fake := NewFake()
+	if !fake.CleanupsIgnored() {
+		t.Error("expected cleanups to be ignored by default")
+	}
+
+	fake.Cleanup(func() { t.Error("cleanup should not be called") })
+
+	if NewFake(FakeWithRegisterCleanup(func(func()) {})).CleanupsIgnored() {
+		t.Error("expected cleanups not to be ignored when registered")
+	}
+}
@@ -3,6 +3,8 @@ package double
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"slices"
 	"sync"
 
 	"github.com/krostar/test/internal"
@@ -17,10 +19,15 @@ type TestingT = internal.TestingT
 type Spy struct {
 	m           sync.RWMutex // mutex to protect concurrent access
 	underlyingT TestingT     // the wrapped TestingT implementation
+	o           *spyOptions  // the options the spy was created with
 
 	failed  bool                // tracks whether Fail or FailNow was called
 	logs    []string            // stores all messages logged with Logf
 	records []SpyTestingTRecord // stores all method calls with their inputs and outputs
+	cleanup []func()            // stores all functions registered with Cleanup, until flushed
+
+	helpers   map[string]struct{} // stores the name of the functions that called Helper, when recording the helper chain
+	logStacks [][]string          // stores the callers of each Log and Logf calls, when recording the helper chain
 }
 
 // NewSpy creates a new Spy that wraps the provided TestingT implementation.
@@ -29,8 +36,14 @@ type Spy struct {
 //
 // This allows test code to verify the behavior of code that uses a TestingT
 // without failing the actual test unless explicitly checked.
-func NewSpy(underlyingT TestingT) *Spy {
-	return &Spy{underlyingT: underlyingT}
+func NewSpy(underlyingT TestingT, opts ...SpyOption) *Spy {
+	o := new(spyOptions)
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Spy{underlyingT: underlyingT, o: o}
 }
 
 // Helper implements the TestingT interface.
@@ -40,19 +53,56 @@ func (spy *Spy) Helper() {
 
 	spy.underlyingT.Helper()
 	spy.records = append(spy.records, SpyTestingTRecord{Method: "Helper"})
+
+	if spy.o.recordHelperChain {
+		if spy.helpers == nil {
+			spy.helpers = make(map[string]struct{})
+		}
+		spy.helpers[callerFunctionNames(1)[0]] = struct{}{}
+	}
 }
 
 // Cleanup implements the TestingT interface.
+// The function is registered on the underlying TestingT, unless the spy was created with SpyWithCleanupsRunOnFlush,
+// in which case it is only run by Flush.
 func (spy *Spy) Cleanup(cleanupFunc func()) {
 	spy.m.Lock()
 	defer spy.m.Unlock()
 
-	spy.underlyingT.Cleanup(cleanupFunc)
+	if !spy.o.runCleanupsOnFlush {
+		spy.underlyingT.Cleanup(cleanupFunc)
+	}
 	spy.records = append(spy.records, SpyTestingTRecord{
 		Method:  "Cleanup",
 		Inputs:  []any{cleanupFunc},
 		Outputs: nil,
 	})
+	spy.cleanup = append(spy.cleanup, cleanupFunc)
+}
+
+// CleanupFuncs returns the functions registered with Cleanup since the spy creation or the last Flush call,
+// in the order they were registered.
+func (spy *Spy) CleanupFuncs() []func() {
+	spy.m.RLock()
+	defer spy.m.RUnlock()
+
+	return slices.Clone(spy.cleanup)
+}
+
+// Flush forgets about the functions registered with Cleanup.
+// If the spy was created with SpyWithCleanupsRunOnFlush, the functions are called
+// in the last added, first called order, like testing.T does when a test completes.
+func (spy *Spy) Flush() {
+	spy.m.Lock()
+	cleanup := spy.cleanup
+	spy.cleanup = nil
+	spy.m.Unlock()
+
+	if spy.o.runCleanupsOnFlush {
+		for _, f := range slices.Backward(cleanup) {
+			f()
+		}
+	}
 }
 
 // Fail implements the TestingT interface.
@@ -88,6 +138,10 @@ func (spy *Spy) Log(args ...any) {
 		Outputs: nil,
 	})
 	spy.logs = append(spy.logs, fmt.Sprint(args...))
+
+	if spy.o.recordHelperChain {
+		spy.logStacks = append(spy.logStacks, callerFunctionNames(1))
+	}
 }
 
 // Logf implements the TestingT interface.
@@ -102,6 +156,10 @@ func (spy *Spy) Logf(format string, args ...any) {
 		Outputs: nil,
 	})
 	spy.logs = append(spy.logs, fmt.Sprintf(format, args...))
+
+	if spy.o.recordHelperChain {
+		spy.logStacks = append(spy.logStacks, callerFunctionNames(1))
+	}
 }
 
 // Context implements the TestingT interface.
@@ -118,3 +176,89 @@ func (spy *Spy) Context() context.Context {
 
 	return ctx
 }
+
+// Name implements the TestingT interface.
+// Returns the name of the underlying TestingT.
+func (spy *Spy) Name() string {
+	spy.m.Lock()
+	defer spy.m.Unlock()
+
+	name := spy.underlyingT.Name()
+
+	spy.records = append(spy.records, SpyTestingTRecord{
+		Method:  "Name",
+		Inputs:  nil,
+		Outputs: []any{name},
+	})
+
+	return name
+}
+
+// Unwrap returns the TestingT the spy wraps, see testingt.Unwrap.
+func (spy *Spy) Unwrap() TestingT { return spy.underlyingT }
+
+// TempDir implements the TestingT interface.
+// Returns the temporary directory of the underlying TestingT.
+func (spy *Spy) TempDir() string {
+	spy.m.Lock()
+	defer spy.m.Unlock()
+
+	dir := spy.underlyingT.TempDir()
+
+	spy.records = append(spy.records, SpyTestingTRecord{
+		Method:  "TempDir",
+		Inputs:  nil,
+		Outputs: []any{dir},
+	})
+
+	return dir
+}
+
+// Setenv implements the TestingT interface.
+// The call is delegated to the underlying TestingT.
+func (spy *Spy) Setenv(key, value string) {
+	spy.m.Lock()
+	defer spy.m.Unlock()
+
+	spy.underlyingT.Setenv(key, value)
+
+	spy.records = append(spy.records, SpyTestingTRecord{
+		Method:  "Setenv",
+		Inputs:  []any{key, value},
+		Outputs: nil,
+	})
+}
+
+// Chdir implements the TestingT interface.
+// The call is delegated to the underlying TestingT.
+func (spy *Spy) Chdir(dir string) {
+	spy.m.Lock()
+	defer spy.m.Unlock()
+
+	spy.underlyingT.Chdir(dir)
+
+	spy.records = append(spy.records, SpyTestingTRecord{
+		Method:  "Chdir",
+		Inputs:  []any{dir},
+		Outputs: nil,
+	})
+}
+
+// callerFunctionNames returns the name of the functions in the call stack.
+// The argument skip is the number of stack frames to skip, with 0 identifying the caller of callerFunctionNames.
+func callerFunctionNames(skip int) []string {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(skip+2, pcs)]
+
+	var names []string
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		names = append(names, frame.Function)
+
+		if !more {
+			return names
+		}
+	}
+}
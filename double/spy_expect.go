@@ -1,9 +1,13 @@
 package double
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test/internal/diff"
 )
 
 // ExpectRecords verifies that the spy contains the expected method call records.
@@ -29,8 +33,8 @@ func (spy *Spy) ExpectRecords(t TestingT, strict bool, expected ...SpyTestingTRe
 	t.Helper()
 
 	if strict {
-		if diff := gocmp.Diff(spy.records, expected, gocmpOpts...); diff != "" {
-			t.Logf("Expected provided records to match\n%s", diff)
+		if !slices.EqualFunc(spy.records, expected, SpyTestingTRecord.seemsEqualTo) {
+			t.Logf("Expected provided records to match\n%s", diff.Table(spy.records, expected, SpyTestingTRecord.seemsEqualTo, SpyTestingTRecord.render))
 			t.Fail()
 		}
 		return
@@ -130,3 +134,64 @@ func (spy *Spy) ExpectTestToPass(t TestingT) {
 		t.Fail()
 	}
 }
+
+// ExpectHelperChain verifies that every Log and Logf call recorded by the spy would be attributed
+// by a real testing.T to the function `depth` levels above the caller of Log or Logf.
+//
+// In other words, the `depth` functions in the call stack of each log call must have called Helper,
+// and the function right above them must not have.
+// This is useful for authors of assertion helpers to verify their file and line attribution.
+//
+// The spy must have been created with SpyWithHelperChainRecording.
+//
+// Example:
+//
+//	spiedT := double.NewSpy(double.NewFake(), double.SpyWithHelperChainRecording())
+//	myAssertHelper(spiedT, 42) // which calls test.Assert internally
+//	spiedT.ExpectHelperChain(t, 3) // myAssertHelper, test.Assert and its internal logging function called Helper
+func (spy *Spy) ExpectHelperChain(t TestingT, depth int) {
+	spy.m.RLock()
+	defer spy.m.RUnlock()
+
+	t.Helper()
+
+	if !spy.o.recordHelperChain {
+		t.Log("Expected spy to be created with SpyWithHelperChainRecording to verify the helper chain")
+		t.Fail()
+		return
+	}
+
+	if len(spy.logStacks) == 0 {
+		t.Log("Expected at least one log to verify the helper chain, got none")
+		t.Fail()
+		return
+	}
+
+	var errs []string
+
+	for i, stack := range spy.logStacks {
+		for level, function := range stack {
+			_, isHelper := spy.helpers[function]
+
+			switch {
+			case level < depth && !isHelper:
+				errs = append(errs, fmt.Sprintf("log #%d: function %s at level %d did not call Helper", i+1, function, level))
+			case level == depth && isHelper:
+				errs = append(errs, fmt.Sprintf("log #%d: function %s at level %d called Helper, log would be attributed to one of its callers", i+1, function, level))
+			}
+
+			if level >= depth {
+				break
+			}
+		}
+
+		if len(stack) <= depth {
+			errs = append(errs, fmt.Sprintf("log #%d: call stack is only %d levels deep", i+1, len(stack)))
+		}
+	}
+
+	if len(errs) > 0 {
+		t.Logf("Expected helper chain of depth %d:\n\t%s", depth, strings.Join(errs, "\n\t"))
+		t.Fail()
+	}
+}
@@ -1,6 +1,7 @@
 package double
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -95,6 +96,30 @@ func Test_SpyTestingT_ExpectRecords(t *testing.T) {
 	})
 }
 
+func Test_SpyTestingT_ExpectRecords_rendering(t *testing.T) {
+	testedT := NewSpy(NewFake())
+	testedT.Helper()
+	testedT.Logf("hello %s", "world")
+	testedT.Fail()
+
+	spiedT := NewSpy(NewFake())
+	testedT.ExpectRecords(spiedT, true,
+		SpyTestingTRecord{Method: "Helper"},
+		SpyTestingTRecord{Method: "Logf", Inputs: []any{"hello %s", SpyTestingTRecordIgnoreParam}},
+		SpyTestingTRecord{Method: "FailNow"},
+		SpyTestingTRecord{Method: "Log", Inputs: []any{"bye"}},
+	)
+
+	spiedT.ExpectTestToFail(t)
+	spiedT.ExpectLogsToContain(t, strings.Join([]string{
+		"#  status    got                        want",
+		"1  ok        Helper()                   Helper()",
+		`2  ok        Logf("hello %s", [world])  Logf("hello %s", <ignored>)`,
+		"3  mismatch  Fail()                     FailNow()",
+		`4  missing   -                          Log("bye")`,
+	}, "\n"))
+}
+
 func Test_SpyTestingT_ExpectNoLogs(t *testing.T) {
 	testedT := NewSpy(NewFake())
 	testedT.ExpectNoLogs(t)
@@ -149,3 +174,89 @@ func Test_SpyTestingT_ExpectTestToPass(t *testing.T) {
 	spiedT.ExpectTestToFail(t)
 	spiedT.ExpectLogsToContain(t, "Expected test to succeed but test failed")
 }
+
+func Test_SpyTestingT_ExpectHelperChain(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		testedT := NewSpy(NewFake(), SpyWithHelperChainRecording())
+		helperChainLevel1(testedT)
+		helperChainLevel1(testedT)
+		testedT.ExpectHelperChain(t, 1)
+
+		testedT = NewSpy(NewFake(), SpyWithHelperChainRecording())
+		helperChainLevel2(testedT)
+		testedT.ExpectHelperChain(t, 2)
+	})
+
+	t.Run("chain too short", func(t *testing.T) {
+		testedT := NewSpy(NewFake(), SpyWithHelperChainRecording())
+		helperChainLevel2(testedT)
+
+		spiedT := NewSpy(NewFake())
+		testedT.ExpectHelperChain(spiedT, 3)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Expected helper chain of depth 3", "log #1: function github.com/krostar/test/double.Test_SpyTestingT_ExpectHelperChain.func2 at level 2 did not call Helper")
+	})
+
+	t.Run("chain too long", func(t *testing.T) {
+		testedT := NewSpy(NewFake(), SpyWithHelperChainRecording())
+		helperChainLevel2(testedT)
+
+		spiedT := NewSpy(NewFake())
+		testedT.ExpectHelperChain(spiedT, 1)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "log #1: function github.com/krostar/test/double.helperChainLevel2 at level 1 called Helper, log would be attributed to one of its callers")
+	})
+
+	t.Run("missing helper", func(t *testing.T) {
+		testedT := NewSpy(NewFake(), SpyWithHelperChainRecording())
+		helperChainMissingHelper(testedT)
+
+		spiedT := NewSpy(NewFake())
+		testedT.ExpectHelperChain(spiedT, 2)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "log #1: function github.com/krostar/test/double.helperChainMissingHelper at level 1 did not call Helper")
+	})
+
+	t.Run("stack not deep enough", func(t *testing.T) {
+		testedT := NewSpy(NewFake(), SpyWithHelperChainRecording())
+		helperChainLevel1(testedT)
+
+		spiedT := NewSpy(NewFake())
+		testedT.ExpectHelperChain(spiedT, 1000)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "levels deep")
+	})
+
+	t.Run("no logs", func(t *testing.T) {
+		testedT := NewSpy(NewFake(), SpyWithHelperChainRecording())
+
+		spiedT := NewSpy(NewFake())
+		testedT.ExpectHelperChain(spiedT, 1)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Expected at least one log to verify the helper chain, got none")
+	})
+
+	t.Run("not recording", func(t *testing.T) {
+		testedT := NewSpy(NewFake())
+		helperChainLevel1(testedT)
+
+		spiedT := NewSpy(NewFake())
+		testedT.ExpectHelperChain(spiedT, 1)
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Expected spy to be created with SpyWithHelperChainRecording")
+	})
+}
+
+func helperChainLevel1(t TestingT) {
+	t.Helper()
+	t.Logf("hello from level 1")
+}
+
+func helperChainLevel2(t TestingT) {
+	t.Helper()
+	helperChainLevel1(t)
+}
+
+func helperChainMissingHelper(t TestingT) {
+	helperChainLevel1(t)
+}
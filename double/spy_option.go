@@ -0,0 +1,25 @@
+package double
+
+// SpyOption is a function that configures a Spy instance.
+// It follows the functional options pattern for configuring the Spy test double.
+type SpyOption func(o *spyOptions)
+
+// SpyWithHelperChainRecording enables the recording of the functions calling Helper, and
+// of the call stack of every Log and Logf call, for later verification with ExpectHelperChain.
+// This is a diagnostic mode, it slows down the spy and should only be used when testing helpers.
+func SpyWithHelperChainRecording() SpyOption {
+	return func(o *spyOptions) { o.recordHelperChain = true }
+}
+
+// SpyWithCleanupsRunOnFlush makes Flush calls run the functions registered with Cleanup,
+// instead of registering them on the underlying TestingT, for them to only run once.
+// This allows tests to exercise the cleanup behavior of the code under test
+// without having to capture the functions with FakeWithRegisterCleanup.
+func SpyWithCleanupsRunOnFlush() SpyOption {
+	return func(o *spyOptions) { o.runCleanupsOnFlush = true }
+}
+
+type spyOptions struct {
+	recordHelperChain  bool
+	runCleanupsOnFlush bool
+}
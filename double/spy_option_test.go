@@ -0,0 +1,25 @@
+package double
+
+import (
+	"testing"
+)
+
+func Test_SpyWithHelperChainRecording(t *testing.T) {
+	o := new(spyOptions)
+
+	SpyWithHelperChainRecording()(o)
+
+	if !o.recordHelperChain {
+		t.Error("recordHelperChain was not set")
+	}
+}
+
+func Test_SpyWithCleanupsRunOnFlush(t *testing.T) {
+	o := new(spyOptions)
+
+	SpyWithCleanupsRunOnFlush()(o)
+
+	if !o.runCleanupsOnFlush {
+		t.Error("runCleanupsOnFlush was not set")
+	}
+}
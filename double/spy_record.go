@@ -1,6 +1,10 @@
 package double
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 // spyTestingTRecordIgnoreParam is a special type used as a marker for parameters
 // that should be ignored during comparison in Spy expectations.
@@ -71,3 +75,35 @@ func (a SpyTestingTRecord) seemsEqualTo(b SpyTestingTRecord) bool {
 
 	return true
 }
+
+// render returns a human-readable representation of the record, like Method(input1, input2) (output1).
+// Parameters marked with SpyTestingTRecordIgnoreParam are rendered as <ignored>.
+func (a SpyTestingTRecord) render() string {
+	renderParams := func(params []any) string {
+		rendered := make([]string, len(params))
+		for i, param := range params {
+			switch v := reflect.ValueOf(param); {
+			case param == nil:
+				rendered[i] = "nil"
+			case v.Type() == reflect.TypeFor[spyTestingTRecordIgnoreParam]():
+				rendered[i] = "<ignored>"
+			case v.Kind() == reflect.Func && v.IsNil():
+				rendered[i] = "<nil func>"
+			case v.Kind() == reflect.Func:
+				rendered[i] = "<func>"
+			case v.Kind() == reflect.String:
+				rendered[i] = fmt.Sprintf("%q", param)
+			default:
+				rendered[i] = fmt.Sprintf("%v", param)
+			}
+		}
+		return strings.Join(rendered, ", ")
+	}
+
+	str := a.Method + "(" + renderParams(a.Inputs) + ")"
+	if len(a.Outputs) > 0 {
+		str += " (" + renderParams(a.Outputs) + ")"
+	}
+
+	return str
+}
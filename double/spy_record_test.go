@@ -161,3 +161,33 @@ func TestSpyTestingTRecord_seemsEqualTo(t *testing.T) {
 		})
 	}
 }
+
+func TestSpyTestingTRecord_render(t *testing.T) {
+	for name, tt := range map[string]struct {
+		record SpyTestingTRecord
+		want   string
+	}{
+		"no params": {
+			record: SpyTestingTRecord{Method: "Helper"},
+			want:   "Helper()",
+		},
+		"inputs": {
+			record: SpyTestingTRecord{Method: "Logf", Inputs: []any{"hello %s", []any{"world", 42}}},
+			want:   `Logf("hello %s", [world 42])`,
+		},
+		"outputs": {
+			record: SpyTestingTRecord{Method: "Name", Outputs: []any{"Test_Foo"}},
+			want:   `Name() ("Test_Foo")`,
+		},
+		"special params": {
+			record: SpyTestingTRecord{Method: "Foo", Inputs: []any{SpyTestingTRecordIgnoreParam, func() {}, (func())(nil), nil}},
+			want:   "Foo(<ignored>, <func>, <nil func>, nil)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.record.render(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -34,6 +34,87 @@ func Test_SpyTestingT_Cleanup(t *testing.T) {
 	}
 }
 
+func Test_SpyTestingT_CleanupFuncs(t *testing.T) {
+	spiedT := NewSpy(NewFake())
+
+	if funcs := spiedT.CleanupFuncs(); len(funcs) != 0 {
+		t.Errorf("expected no cleanup functions, got %d", len(funcs))
+	}
+
+	var calls []int
+	spiedT.Cleanup(func() { calls = append(calls, 1) })
+	spiedT.Cleanup(func() { calls = append(calls, 2) })
+
+	funcs := spiedT.CleanupFuncs()
+	if len(funcs) != 2 {
+		t.Fatalf("expected 2 cleanup functions, got %d", len(funcs))
+	}
+
+	for _, f := range funcs {
+		f()
+	}
+
+	if len(calls) != 2 || calls[0] != 1 || calls[1] != 2 {
+		t.Errorf("expected cleanup functions to be returned in registration order, got calls %v", calls)
+	}
+}
+
+func Test_SpyTestingT_Flush(t *testing.T) {
+	t.Run("without running cleanups", func(t *testing.T) {
+		spiedT := NewSpy(NewFake())
+
+		called := false
+		spiedT.Cleanup(func() { called = true })
+		spiedT.Flush()
+
+		if called {
+			t.Error("cleanup function should not be called")
+		}
+
+		if funcs := spiedT.CleanupFuncs(); len(funcs) != 0 {
+			t.Errorf("expected cleanup functions to be flushed, got %d", len(funcs))
+		}
+	})
+
+	t.Run("running cleanups", func(t *testing.T) {
+		spiedT := NewSpy(NewFake(), SpyWithCleanupsRunOnFlush())
+
+		var calls []int
+		spiedT.Cleanup(func() { calls = append(calls, 1) })
+		spiedT.Cleanup(func() {
+			calls = append(calls, 2)
+			spiedT.Log("cleanup can use the spy")
+		})
+		spiedT.Flush()
+
+		if len(calls) != 2 || calls[0] != 2 || calls[1] != 1 {
+			t.Errorf("expected cleanup functions to be called in reverse order, got calls %v", calls)
+		}
+
+		spiedT.ExpectLogsToContain(t, "cleanup can use the spy")
+
+		spiedT.Flush()
+
+		if len(calls) != 2 {
+			t.Errorf("expected cleanup functions to be called once, got calls %v", calls)
+		}
+	})
+
+	t.Run("running cleanups of a real test", func(t *testing.T) {
+		var calls int
+
+		t.Run("spied", func(t *testing.T) {
+			spiedT := NewSpy(t, SpyWithCleanupsRunOnFlush())
+			spiedT.Cleanup(func() { calls++ })
+			spiedT.Flush()
+		})
+
+		if calls != 1 {
+			t.Errorf("expected cleanup function to be called once, got %d calls", calls)
+		}
+	})
+}
+
 func Test_SpyTestingT_Fail(t *testing.T) {
 	spiedT := NewSpy(NewFake())
 	spiedT.Fail()
@@ -82,3 +163,72 @@ func Test_SpyTestingT_Context(t *testing.T) {
 		Outputs: []any{ctx},
 	})
 }
+
+func Test_SpyTestingT_Name(t *testing.T) {
+	t.Run("underlying namer", func(t *testing.T) {
+		spiedT := NewSpy(NewFake(FakeWithName("Test_Foo")))
+
+		if name := spiedT.Name(); name != "Test_Foo" {
+			t.Errorf("expected name to be Test_Foo, got %q", name)
+		}
+
+		spiedT.ExpectRecords(t, true, SpyTestingTRecord{
+			Method:  "Name",
+			Outputs: []any{"Test_Foo"},
+		})
+	})
+
+	t.Run("underlying wrapped", func(t *testing.T) {
+		spiedT := NewSpy(struct{ TestingT }{TestingT: NewFake(FakeWithName("Test_Foo"))})
+
+		if name := spiedT.Name(); name != "Test_Foo" {
+			t.Errorf("expected name to be delegated through the wrapper, got %q", name)
+		}
+	})
+}
+
+func Test_SpyTestingT_TempDir(t *testing.T) {
+	spiedT := NewSpy(NewFake(FakeWithTempDir(func() string { return "/tmp/foo" })))
+
+	if dir := spiedT.TempDir(); dir != "/tmp/foo" {
+		t.Errorf("expected TempDir to be delegated, got %q", dir)
+	}
+
+	spiedT.ExpectRecords(t, true, SpyTestingTRecord{
+		Method:  "TempDir",
+		Outputs: []any{"/tmp/foo"},
+	})
+}
+
+func Test_SpyTestingT_Setenv(t *testing.T) {
+	env := make(map[string]string)
+
+	spiedT := NewSpy(NewFake(FakeWithSetenv(func(key, value string) { env[key] = value })))
+	spiedT.Setenv("FOO", "bar")
+
+	if env["FOO"] != "bar" {
+		t.Errorf("expected Setenv to be delegated, got %v", env)
+	}
+
+	spiedT.ExpectRecords(t, true, SpyTestingTRecord{
+		Method: "Setenv",
+		Inputs: []any{"FOO", "bar"},
+	})
+}
+
+func Test_SpyTestingT_Chdir(t *testing.T) {
+	var dir string
+
+	spiedT := NewSpy(NewFake(FakeWithChdir(func(d string) { dir = d })))
+	spiedT.Chdir("/tmp")
+
+	if dir != "/tmp" {
+		t.Errorf("expected Chdir to be delegated, got %q", dir)
+	}
+
+	spiedT.ExpectRecords(t, true, SpyTestingTRecord{
+		Method: "Chdir",
+		Inputs: []any{"/tmp"},
+	})
+	NewSpy(struct{ TestingT }{TestingT: NewFake()}).Chdir("/tmp") // underlying wrapped, it must not panic
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// envOption maps an environment variable to the option it sets, see Configure.
+type envOption struct {
+	name  string
+	parse func(value string) (Option, error)
+}
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	_envOptions = []envOption{
+		{name: "KROSTAR_TEST_SUCCESS_MESSAGES", parse: envBool(func(o *options, v bool) { o.successMessages = v })},
+		{name: "KROSTAR_TEST_FAIL_FAST", parse: envBool(func(o *options, v bool) { o.failFast = v })},
+		{name: "KROSTAR_TEST_STACK_TRACES", parse: envBool(func(o *options, v bool) { o.stackTraces = v })},
+		{name: "KROSTAR_TEST_SOURCE_ANALYSIS", parse: envBool(func(o *options, v bool) { o.sourceAnalysis = v })},
+		{name: "KROSTAR_TEST_COLOR", parse: envEnum(WithColor, ColorNever, ColorAlways, ColorAuto)},
+		{name: "KROSTAR_TEST_VERBOSITY", parse: envEnum(WithVerbosity, VerbosityQuiet, VerbosityNormal, VerbosityVerbose)},
+		{name: "KROSTAR_TEST_MAX_MESSAGE_LENGTH", parse: envInt(WithMaxMessageLength)},
+		{name: "KROSTAR_TEST_MAX_COLLECTION_PREVIEW", parse: envInt(WithMaxCollectionPreview)},
+		{name: "KROSTAR_TEST_MAX_DEPTH", parse: envInt(WithMaxDepth)},
+		{name: "KROSTAR_TEST_CHECK_TIMEOUT", parse: envDuration(WithCheckTimeout)},
+		{name: "KROSTAR_TEST_EVENTUALLY_TIMEOUT", parse: envDuration(WithEventuallyTimeout)},
+		{name: "KROSTAR_TEST_STATS", parse: envBool(func(o *options, v bool) { o.stats = v })},
+		{name: "KROSTAR_TEST_SKIP_REPORT", parse: envString(WithSkipReport)},
+		{name: "KROSTAR_TEST_REPLAY_DIR", parse: envString(WithReplayDir)},
+	}
+
+	_envConfiguration = sync.OnceValues(envConfiguration)
+	_envErrorOnce     sync.Once
+)
+
+// envConfiguration returns the options set by the KROSTAR_TEST_* environment variables, see Configure.
+// Invalid values are ignored, and reported by the returned error.
+// As the environment is not meant to change during the run, it is only read once, through _envConfiguration.
+func envConfiguration() ([]Option, error) {
+	var (
+		opts []Option
+		errs []error
+	)
+
+	for _, env := range _envOptions {
+		value, ok := os.LookupEnv(env.name)
+		if !ok || value == "" {
+			continue
+		}
+
+		opt, err := env.parse(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.name, err))
+			continue
+		}
+
+		opts = append(opts, opt)
+	}
+
+	return opts, errors.Join(errs...)
+}
+
+// applyEnvConfiguration applies the options set by the environment, and logs once per run the invalid ones on t.
+func applyEnvConfiguration(t TestingT, o *options) {
+	opts, err := _envConfiguration()
+	if err != nil {
+		_envErrorOnce.Do(func() { t.Logf("krostar/test internal failure: invalid environment configuration: %v", err) })
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+func envString(option func(string) Option) func(string) (Option, error) {
+	return func(value string) (Option, error) { return option(value), nil }
+}
+
+func envBool(set func(o *options, v bool)) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", value)
+		}
+		return func(o *options) { set(o, v) }, nil
+	}
+}
+
+func envInt(option func(int) Option) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		return option(v), nil
+	}
+}
+
+func envDuration(option func(time.Duration) Option) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", value)
+		}
+		return option(v), nil
+	}
+}
+
+func envEnum[T ~string](option func(T) Option, allowed ...T) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		if !slices.Contains(allowed, T(value)) {
+			return nil, fmt.Errorf("invalid value %q, expected one of %v", value, allowed)
+		}
+		return option(T(value)), nil
+	}
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_envConfiguration(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		t.Setenv("KROSTAR_TEST_FAIL_FAST", "true")
+		t.Setenv("KROSTAR_TEST_VERBOSITY", "verbose")
+		t.Setenv("KROSTAR_TEST_MAX_DEPTH", "")
+		t.Setenv("KROSTAR_TEST_EVENTUALLY_TIMEOUT", "10s")
+		t.Setenv("KROSTAR_TEST_STATS", "true")
+		t.Setenv("KROSTAR_TEST_REPLAY_DIR", "/tmp/replays")
+
+		opts, err := envConfiguration()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var o options
+		for _, opt := range opts {
+			opt(&o)
+		}
+
+		if !o.failFast || o.verbosity != VerbosityVerbose || o.maxDepth != 0 || o.eventuallyTimeout != 10*time.Second ||
+			!o.stats || o.replayDir != "/tmp/replays" || o.skipReportPath != "" {
+			t.Errorf("unexpected options %+v", o)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv("KROSTAR_TEST_FAIL_FAST", "maybe")
+		t.Setenv("KROSTAR_TEST_COLOR", "sometimes")
+		t.Setenv("KROSTAR_TEST_CHECK_TIMEOUT", "1s")
+
+		opts, err := envConfiguration()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if len(opts) != 1 {
+			t.Errorf("expected valid variables to be kept, got %d options", len(opts))
+		}
+
+		for _, expected := range []string{`KROSTAR_TEST_FAIL_FAST: invalid boolean "maybe"`, `KROSTAR_TEST_COLOR: invalid value "sometimes"`} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected error %q to contain %q", err.Error(), expected)
+			}
+		}
+	})
+}
+
+func Test_optionsOf_environment(t *testing.T) {
+	t.Run("overrides globals", func(t *testing.T) {
+		t.Setenv("KROSTAR_TEST_FAIL_FAST", "true")
+		rereadEnvConfiguration(t)
+
+		spiedT := double.NewSpy(double.NewFake())
+		Assert(spiedT, false)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+	})
+
+	t.Run("overridden by call options", func(t *testing.T) {
+		t.Setenv("KROSTAR_TEST_SOURCE_ANALYSIS", "false")
+		rereadEnvConfiguration(t)
+
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 1, 2
+
+		Assert(spiedT, got == want)
+		spiedT.ExpectLogsToContain(t, "Error: assertion failed")
+
+		spiedT = double.NewSpy(double.NewFake())
+		Assert(spiedT, got == want, WithSourceAnalysis(true))
+		spiedT.ExpectLogsToContain(t, "Error: got is not equal to want")
+	})
+
+	t.Run("overrides Configure", func(t *testing.T) {
+		t.Setenv("KROSTAR_TEST_SOURCE_ANALYSIS", "true")
+		rereadEnvConfiguration(t)
+		t.Cleanup(Configure(WithSourceAnalysis(false)))
+
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 1, 2
+
+		Assert(spiedT, got == want)
+		spiedT.ExpectLogsToContain(t, "Error: got is not equal to want")
+	})
+
+	t.Run("read once", func(t *testing.T) {
+		rereadEnvConfiguration(t)
+
+		spiedT := double.NewSpy(double.NewFake())
+		Assert(spiedT, false)
+
+		t.Setenv("KROSTAR_TEST_FAIL_FAST", "true")
+		Assert(spiedT, false)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "Fail"}, double.SpyTestingTRecord{Method: "Fail"})
+	})
+}
+
+// rereadEnvConfiguration makes the next assertions read the environment again, as it is otherwise only read once,
+// for the variables set by the test to be taken into account. The configuration is read again once the test completes.
+func rereadEnvConfiguration(t *testing.T) {
+	reread := func() { _envConfiguration = sync.OnceValues(envConfiguration) }
+	reread()
+	t.Cleanup(reread)
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"fmt"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+// Equal asserts that `got` and `want` are equal, compared with go-cmp using the provided options.
+//
+// Unlike Assert(t, got == want), which only tells that the values differ, the message of a failing Equal
+// includes the differences between both values, which is what is needed to understand failures on large structs.
+//
+// Equal returns true if the values are equal.
+//
+// Example:
+//
+//	test.Equal(t, user, User{Name: "bob", Age: 42})
+//
+// -> Error: user is not equal to User{Name: "bob", Age: 42} (-got +want):
+//
+//	  test.User{
+//	- 	Name: "alice",
+//	+ 	Name: "bob",
+//	  	Age:  42,
+//	  }
+func Equal[T any](t TestingT, got, want T, opts ...gocmp.Option) bool {
+	t.Helper()
+
+	equal := gocmp.Equal(got, want, opts...)
+	logDescribedResult(t, optionsOf(t), equal, 1, 1, func(argExpr func(int, string) string) string {
+		gotExpr, wantExpr := argExpr(1, "got"), argExpr(2, "want")
+		if equal {
+			return gotExpr + " is equal to " + wantExpr
+		}
+		return fmt.Sprintf("%s is not equal to %s (-got +want):\n%s", gotExpr, wantExpr, gocmp.Diff(got, want, opts...))
+	}, 0)
+
+	if !equal {
+		failAssertion(t, nil)
+	}
+
+	return equal
+}
+
+// NotEqual asserts that `got` and `want` are not equal, compared with go-cmp using the provided options.
+//
+// NotEqual returns true if the values are not equal.
+func NotEqual[T any](t TestingT, got, want T, opts ...gocmp.Option) bool {
+	t.Helper()
+
+	equal := gocmp.Equal(got, want, opts...)
+	logDescribedResult(t, optionsOf(t), !equal, 1, 1, func(argExpr func(int, string) string) string {
+		gotExpr, wantExpr := argExpr(1, "got"), argExpr(2, "want")
+		if equal {
+			return gotExpr + " is equal to " + wantExpr
+		}
+		return gotExpr + " is not equal to " + wantExpr
+	}, 0)
+
+	if equal {
+		failAssertion(t, nil)
+	}
+
+	return !equal
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Equal(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got := user{Name: "bob", Age: 42}
+
+		if !Equal(spiedT, got, user{Name: "bob", Age: 42}) {
+			t.Error("expected values to be equal")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got := user{Name: "alice", Age: 42}
+
+		if Equal(spiedT, got, user{Name: "bob", Age: 42}) {
+			t.Error("expected values to differ")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, `Error: got is not equal to user{Name: "bob", Age: 42} (-got +want):`)
+		spiedT.ExpectLogsToContain(t, `"alice"`)
+		spiedT.ExpectLogsToContain(t, `"bob"`)
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		Equal(spiedT, "Bob", "bob", gocmp.Comparer(strings.EqualFold))
+		spiedT.ExpectTestToPass(t)
+	})
+}
+
+func Test_NotEqual(t *testing.T) {
+	t.Run("not equal", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if !NotEqual(spiedT, []int{1}, []int{2}) {
+			t.Error("expected values to differ")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := []int{1}, []int{1}
+
+		if NotEqual(spiedT, got, want) {
+			t.Error("expected values to be equal")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: got is equal to want")
+	})
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"fmt"
+)
+
+// NoError asserts that `err` is nil.
+//
+// Unlike Assert(t, err == nil), the message of a failing NoError includes the error,
+// with the details displayed by its %+v verb, like the stack traces some error libraries record.
+//
+// NoError returns true if `err` is nil.
+//
+// Example:
+//
+//	test.NoError(t, os.Remove(path))
+//
+// -> Error: os.Remove(path) is a non-nil *fs.PathError: remove /tmp/foo: no such file or directory
+func NoError(t TestingT, err error, msgAndArgs ...any) bool {
+	t.Helper()
+
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+
+	passed := err == nil
+	logDescribedResult(t, opts, passed, 1, 1, func(argExpr func(int, string) string) string {
+		if passed {
+			return argExpr(1, "err") + " is nil"
+		}
+		return describeError(argExpr(1, "err"), err)
+	}, 2, msgAndArgs...)
+
+	if !passed {
+		failAssertionWith(t, opts)
+	}
+
+	return passed
+}
+
+// Error asserts that `err` is not nil.
+//
+// Error returns true if `err` is not nil.
+func Error(t TestingT, err error, msgAndArgs ...any) bool {
+	t.Helper()
+
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+
+	passed := err != nil
+	logDescribedResult(t, opts, passed, 1, 1, func(argExpr func(int, string) string) string {
+		if passed {
+			return describeError(argExpr(1, "err"), err)
+		}
+		return argExpr(1, "err") + " is nil"
+	}, 2, msgAndArgs...)
+
+	if !passed {
+		failAssertionWith(t, opts)
+	}
+
+	return passed
+}
+
+// describeError describes the non-nil error `err`, obtained from the expression `expr`.
+// The details given by the %+v verb are added if they differ from the error message.
+func describeError(expr string, err error) string {
+	desc := fmt.Sprintf("%s is a non-nil %T: %s", expr, err, err.Error())
+	if details := fmt.Sprintf("%+v", err); details != err.Error() {
+		desc += "\n" + details
+	}
+	return desc
+}
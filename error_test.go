@@ -0,0 +1,83 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+type detailedError struct{}
+
+func (detailedError) Error() string { return "boom" }
+
+func (e detailedError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		_, _ = fmt.Fprint(f, "boom\n  at main.go:42")
+		return
+	}
+	_, _ = fmt.Fprint(f, e.Error())
+}
+
+func Test_NoError(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		var err error
+
+		if !NoError(spiedT, err) {
+			t.Error("expected error to be nil")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("not nil", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if NoError(spiedT, errors.New("boom"), "while doing %s", "stuff") {
+			t.Error("expected error not to be nil")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, `Error: errors.New("boom") is a non-nil *errors.errorString: boom [while doing stuff]`)
+	})
+
+	t.Run("with details", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		err := fmt.Errorf("wrapped: %w", detailedError{})
+
+		NoError(spiedT, detailedError{})
+		NoError(spiedT, err)
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: detailedError{} is a non-nil test.detailedError: boom\nboom\n  at main.go:42")
+		spiedT.ExpectLogsToContain(t, "Error: err is a non-nil *fmt.wrapError: wrapped: boom")
+	})
+}
+
+func Test_Error(t *testing.T) {
+	t.Run("not nil", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if !Error(spiedT, errors.New("boom")) {
+			t.Error("expected error not to be nil")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		var err error
+
+		if Error(spiedT, err) {
+			t.Error("expected error to be nil")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: err is nil")
+	})
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+
+	"github.com/krostar/test/testingt"
+)
+
+// Errorf fails the test with the formatted message, like testing.T.Errorf does,
+// but the failure goes through the same path as the failures of Assert:
+// the message is rendered by the formatter, along with the subtest name and annotations,
+// the hooks registered with OnFailure are called, and the test is stopped in fail-fast mode (see WithFailFast).
+//
+// It eases the migration of codebases mixing raw testing.T calls with assertions, whose outputs become consistent.
+// Options, like WithColor, can be provided along with `args` to configure this failure only; they are not formatted.
+//
+// Example:
+//
+//	if resp.StatusCode != http.StatusOK {
+//		test.Errorf(t, "unexpected status %d: %s", resp.StatusCode, body)
+//	}
+func Errorf(t TestingT, format string, args ...any) {
+	t.Helper()
+
+	logFailuref(t, format, args...)
+	failAssertion(t, args)
+}
+
+// Fatalf behaves like Errorf, and stops the test, like testing.T.Fatalf does.
+func Fatalf(t TestingT, format string, args ...any) {
+	t.Helper()
+
+	logFailuref(t, format, args...)
+	t.FailNow()
+}
+
+// logFailuref records the failure of the caller with the formatted message, and logs it, see notifyFailure.
+func logFailuref(t TestingT, format string, args ...any) {
+	t.Helper()
+
+	opts := optionsOf(t, callOptions(args)...)
+	recordAssertion(t, opts, 2, false)
+	args = slices.DeleteFunc(slices.Clone(args), func(arg any) bool {
+		_, isOption := arg.(Option)
+		return isOption
+	})
+
+	msg := formatResult(t, opts, AssertionResult{
+		Case:        caseName(t),
+		Description: fmt.Sprintf(format, args...),
+	})
+
+	failure := Failure{Test: testingt.Innermost(t).Name(), Message: msg}
+	_, failure.File, failure.Line, _ = runtime.Caller(2)
+	notifyFailure(t, opts, failure)
+}
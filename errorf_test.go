@@ -0,0 +1,36 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Errorf(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake(double.FakeWithName("Test_Foo/case_a")))
+
+	var failures []Failure
+	OnFailure(spiedT, func(failure Failure) { failures = append(failures, failure) })
+
+	Errorf(spiedT, "unexpected status %d", 500)
+
+	spiedT.ExpectTestToFail(t)
+	spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "Fail"})
+	spiedT.ExpectLogsToContain(t, "Error: [case_a #1] unexpected status 500")
+
+	if len(failures) != 1 || failures[0].Test != "Test_Foo/case_a" || filepath.Base(failures[0].File) != "errorf_test.go" ||
+		failures[0].Message != "[case_a #1] unexpected status 500" {
+		t.Errorf("unexpected failures %+v", failures)
+	}
+}
+
+func Test_Fatalf(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+
+	Fatalf(spiedT, "unable to start server: %v", "address already in use")
+
+	spiedT.ExpectTestToFail(t)
+	spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+	spiedT.ExpectLogsToContain(t, "Error: unable to start server: address already in use")
+}
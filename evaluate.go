@@ -0,0 +1,49 @@
+package test
+
+import (
+	"context"
+	"errors"
+)
+
+// Evaluate checks the provided boolean `result` like Assert does, outside of any test.
+//
+// If `result` is false, it returns an error whose message is the one Assert would have logged,
+// built from the source of the expression, the custom message, and the values provided with `msgAndArgs`.
+// If the source of the expression is not available, the message states that the evaluation failed instead.
+// It returns nil otherwise. It is meant for code without a TestingT, like example programs, scripts,
+// or testable documentation, wishing to describe unmet conditions the same way tests do.
+//
+// Example:
+//
+//	if err := test.Evaluate(len(args) == 2, "usage: %s <src> <dst>", os.Args[0]); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// -> len(args) is not equal to 2 [usage: cp <src> <dst>]
+func Evaluate(result bool, msgAndArgs ...any) error {
+	if result {
+		return nil
+	}
+
+	msg, _ := resultMessage(evaluationT{}, false, 1, 0, msgAndArgs...)
+	return errors.New(msg)
+}
+
+// evaluationT is the TestingT given to the message engine by Evaluate.
+// As there is no test, it has no name, and its methods do nothing.
+// It is not comparable, for no state to be associated to it, see stateOf, as its cleanups never run.
+type evaluationT struct {
+	_ [0]func()
+}
+
+func (evaluationT) Helper()                  {}
+func (evaluationT) Cleanup(func())           {}
+func (evaluationT) Fail()                    {}
+func (evaluationT) FailNow()                 {}
+func (evaluationT) Log(...any)               {}
+func (evaluationT) Logf(string, ...any)      {}
+func (evaluationT) Context() context.Context { return context.Background() }
+func (evaluationT) Name() string             { return "" }
+func (evaluationT) TempDir() string          { return "" }
+func (evaluationT) Setenv(string, string)    {}
+func (evaluationT) Chdir(string)             {}
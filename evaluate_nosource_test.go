@@ -0,0 +1,7 @@
+package test
+
+// evaluateWithoutSource calls Evaluate from a file that does not exist, for its source not to be available.
+// It lives in its own file, as the line directive applies to the rest of the file.
+func evaluateWithoutSource(result bool, msgAndArgs ...any) error {
+	/*line /nonexistent/evaluate.go:1:1*/ return Evaluate(result, msgAndArgs...)
+}
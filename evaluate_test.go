@@ -0,0 +1,45 @@
+package test
+
+import (
+	"testing"
+)
+
+func Test_Evaluate(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		if err := Evaluate(true, "hello"); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("false", func(t *testing.T) {
+		got, want := 1, 2
+
+		err := Evaluate(got == want, "attempt %d", 1, Values(got))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if expected := "got is not equal to want; got=1 [attempt 1]"; err.Error() != expected {
+			t.Errorf("expected error %q, got %q", expected, err.Error())
+		}
+	})
+
+	t.Run("source not available", func(t *testing.T) {
+		err := evaluateWithoutSource(false, "attempt %d", 1)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if expected := "assertion failed [attempt 1]"; err.Error() != expected {
+			t.Errorf("expected error %q, got %q", expected, err.Error())
+		}
+	})
+
+	t.Run("no state is kept", func(t *testing.T) {
+		_ = Evaluate(false)
+
+		if _, ok := lookupState(evaluationT{}); ok {
+			t.Error("expected no state to be associated to evaluations")
+		}
+	})
+}
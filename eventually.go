@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultEventuallyTimeout = 30 * time.Second
+	minEventuallyInterval    = time.Millisecond
+)
+
+// WithEventuallyTimeout sets the time given to conditions of Eventually to become true,
+// instead of deriving it from the deadline of the test, or using 30s if the test has none.
+// It can be set for all assertions with Configure, or with the KROSTAR_TEST_EVENTUALLY_TIMEOUT environment variable.
+func WithEventuallyTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.eventuallyTimeout = timeout }
+}
+
+// WithEventuallyBackoff sets the time Eventually waits between evaluations of its condition:
+// it starts at `initial`, and doubles after each evaluation, up to `maximum`.
+// By default, it starts at 10ms and grows up to 1s. Intervals are at least 1ms,
+// for the condition not to be evaluated in a busy loop, as a zero interval never grows.
+func WithEventuallyBackoff(initial, maximum time.Duration) Option {
+	return func(o *options) {
+		o.eventuallyInterval = max(initial, minEventuallyInterval)
+		o.eventuallyMaxInterval = max(initial, maximum, minEventuallyInterval)
+	}
+}
+
+// Eventually asserts that the condition becomes true in time, by evaluating it repeatedly until it does.
+//
+// It is meant for the common case of waiting for an asynchronous state, without the ceremony of building a context
+// and picking an interval: the condition is given until shortly before the deadline of the test
+// (as provided by testing.T.Deadline, from the -test.timeout flag), or 30s if the test has none,
+// and it is also abandoned once the test context is canceled. Evaluations are spaced by a growing backoff,
+// starting at 10ms and growing up to 1s, and never shorter than 1ms, even when a shorter backoff is set.
+// Options, like WithEventuallyTimeout and WithEventuallyBackoff, override those defaults.
+//
+// If the condition never becomes true, the test fails with a message describing the condition,
+// the time waited, and the number of evaluations. For conditions needing a context, or returning an error
+// worth reporting, see check.Eventually.
+//
+// Eventually returns whether the condition became true.
+//
+// Example:
+//
+//	test.Eventually(t, func() bool { return srv.Ready() })
+//
+// -> Error: func() bool { return srv.Ready() } did not become true within 2.5s, after 8 evaluations
+func Eventually(t TestingT, cond func() bool, opts ...Option) bool {
+	t.Helper()
+
+	o := optionsOf(t, opts...)
+
+	ctx, cancel := eventuallyContext(t, o.eventuallyTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	passed, evaluations := poll(ctx, cond, o.eventuallyInterval, o.eventuallyMaxInterval)
+	elapsed := time.Since(startedAt).Round(time.Millisecond)
+
+	logDescribedResult(t, o, passed, 1, 1, func(argExpr func(int, string) string) string {
+		if passed {
+			return fmt.Sprintf("%s became true within %s, after %d evaluations", argExpr(1, "condition"), elapsed.String(), evaluations)
+		}
+		return fmt.Sprintf("%s did not become true within %s, after %d evaluations", argExpr(1, "condition"), elapsed.String(), evaluations)
+	}, 2)
+
+	if !passed {
+		failAssertionWith(t, o)
+	}
+
+	return passed
+}
+
+// eventuallyContext returns the context bounding the evaluations of Eventually:
+// it expires after the provided timeout if positive, or shortly before the deadline of the test,
+// or after 30s if the test has no deadline. It is canceled with the test context.
+func eventuallyContext(t TestingT, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(t.Context(), timeout)
+	}
+
+	if d, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := d.Deadline(); ok {
+			// keep some time to report the failure, and run cleanups, before the test binary panics
+			return context.WithDeadline(t.Context(), deadline.Add(-time.Until(deadline)/10))
+		}
+	}
+
+	return context.WithTimeout(t.Context(), defaultEventuallyTimeout)
+}
+
+// poll evaluates the condition until it is true or the context expires, waiting between evaluations
+// for a duration starting at `interval` and doubling up to `maxInterval`.
+// It returns whether the condition became true, and the number of evaluations.
+func poll(ctx context.Context, cond func() bool, interval, maxInterval time.Duration) (bool, int) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for evaluations := 1; ; evaluations++ {
+		if cond() {
+			return true, evaluations
+		}
+
+		timer.Reset(interval)
+		interval = min(2*interval, maxInterval)
+
+		select {
+		case <-ctx.Done():
+			return false, evaluations
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Eventually(t *testing.T) {
+	t.Run("condition becomes true", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		var evaluations int
+		if !Eventually(spiedT, func() bool { evaluations++; return evaluations == 3 }, WithEventuallyBackoff(time.Millisecond, time.Millisecond)) {
+			t.Error("expected Eventually to return true")
+		}
+
+		if evaluations != 3 {
+			t.Errorf("expected the condition to be evaluated until it is true, got %d evaluations", evaluations)
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("condition never becomes true", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		ready := false
+		if Eventually(spiedT, func() bool { return ready }, WithEventuallyTimeout(20*time.Millisecond)) {
+			t.Error("expected Eventually to return false")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: func() bool { return ready } did not become true within ", "ms, after ")
+	})
+
+	t.Run("options apply to the failure", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		Eventually(spiedT, func() bool { return false }, WithEventuallyTimeout(time.Millisecond), WithFailFast())
+
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+	})
+
+	t.Run("test deadline", func(t *testing.T) {
+		deadline := time.Now().Add(50 * time.Millisecond)
+		deadlineT := &deadlineTestingT{TestingT: double.NewFake(), deadline: deadline}
+
+		ctx, cancel := eventuallyContext(deadlineT, 0)
+		defer cancel()
+
+		if d, ok := ctx.Deadline(); !ok || !d.Before(deadline) {
+			t.Errorf("expected the context deadline to be before the test deadline, got %v", d)
+		}
+
+		ctx, cancel = eventuallyContext(double.NewFake(), 0)
+		defer cancel()
+
+		if d, ok := ctx.Deadline(); !ok || time.Until(d) > defaultEventuallyTimeout {
+			t.Errorf("expected the context deadline to be set from the default timeout, got %v", d)
+		}
+	})
+}
+
+func Test_WithEventuallyBackoff(t *testing.T) {
+	var o options
+
+	WithEventuallyBackoff(0, 0)(&o)
+	if o.eventuallyInterval != time.Millisecond || o.eventuallyMaxInterval != time.Millisecond {
+		t.Errorf("expected intervals to be raised to 1ms, got %v and %v", o.eventuallyInterval, o.eventuallyMaxInterval)
+	}
+
+	WithEventuallyBackoff(20*time.Millisecond, 5*time.Millisecond)(&o)
+	if o.eventuallyInterval != 20*time.Millisecond || o.eventuallyMaxInterval != 20*time.Millisecond {
+		t.Errorf("expected the maximum interval to be at least the initial one, got %v and %v", o.eventuallyInterval, o.eventuallyMaxInterval)
+	}
+}
+
+// deadlineTestingT is a TestingT providing a deadline, like *testing.T.
+type deadlineTestingT struct {
+	TestingT
+
+	deadline time.Time
+}
+
+func (d *deadlineTestingT) Deadline() (time.Time, bool) { return d.deadline, true }
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/krostar/test/internal/message"
+	"github.com/krostar/test/testingt"
+)
+
+// Failure describes a failed assertion, as given to the hooks registered with OnFailure.
+type Failure struct {
+	Test       string       // name of the test, if t provides it
+	File       string       // file of the failed assertion
+	Line       int          // line of the failed assertion
+	Expression string       // source of the asserted expression, if available
+	Message    string       // message logged by the assertion
+	Values     []NamedValue // values attached to the assertion, see Values
+}
+
+// OnFailure registers a hook called with the details of every assertion failing on t, for the rest of the test.
+//
+// It is meant to gather what helps understanding failures at the time they happen, like attaching artifacts,
+// dumping the state of a system, or taking database snapshots. Hooks are called in the order they were registered,
+// right after the failure message is built and before the test is failed.
+//
+// Example:
+//
+//	test.OnFailure(t, func(failure test.Failure) {
+//		dumpDatabase(t, fmt.Sprintf("%s-%d.sql", filepath.Base(failure.File), failure.Line))
+//	})
+func OnFailure(t TestingT, hook func(failure Failure)) {
+	t.Helper()
+
+	state := stateOf(t)
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	state.failureHooks = append(state.failureHooks, hook)
+}
+
+// recordFailure handles the failure of the assertion made by the caller, see notifyFailure.
+// `argIndex` is the position of the argument of the assertion call holding the failed condition,
+// or is negative for the asserted argument of Assert-like calls.
+// `values` are the rendered values attached to the assertion, if any, and `opts` are the options of the assertion.
+func recordFailure(t TestingT, opts options, callerStackIndex, argIndex int, msg string, values []NamedValue) {
+	t.Helper()
+
+	failure := Failure{Test: testingt.Innermost(t).Name(), Message: msg, Values: values}
+
+	_, failure.File, failure.Line, _ = runtime.Caller(callerStackIndex + 1)
+	if opts.sourceAnalysis {
+		failure.Expression, _ = message.ExpressionArg(callerStackIndex+1, argIndex)
+	}
+
+	notifyFailure(t, opts, failure)
+}
+
+// notifyFailure calls the hooks registered with OnFailure with the failure, writes its replay file, if enabled,
+// and logs it with the options of the assertion, see logFailure.
+func notifyFailure(t TestingT, opts options, failure Failure) {
+	t.Helper()
+
+	var hooks []func(Failure)
+	if state, ok := lookupState(t); ok {
+		state.m.Lock()
+		hooks = append(hooks, state.failureHooks...)
+		state.m.Unlock()
+	}
+
+	for _, hook := range hooks {
+		hook(failure)
+	}
+
+	writeReplay(t, opts, failure)
+	logFailure(t, opts, failure)
+}
+
+// logFailure logs the message of the failure, colored according to the options of the assertion, see WithColor.
+//
+// Failures are handed to t if it reports them itself (see failureReporter).
+// If t supports structured output, like *testing.T since Go 1.25, the location and expression of the failure
+// are emitted as test attributes, for tools like test2json consumers, and the message is written to the output of t,
+// prefixed by its location as Logf would do. Otherwise, the message is logged with Logf.
+func logFailure(t TestingT, opts options, failure Failure) {
+	t.Helper()
+
+	if r, ok := t.(failureReporter); ok {
+		r.reportFailure(opts, failure)
+		return
+	}
+
+	if a, ok := testingt.AsAttr(t); ok {
+		a.Attr("assertion.file", failure.File)
+		a.Attr("assertion.line", strconv.Itoa(failure.Line))
+		if failure.Expression != "" {
+			a.Attr("assertion.expression", strings.Join(strings.Fields(failure.Expression), " "))
+		}
+	}
+
+	msg := colorizeMessage(opts.color, failure.Message)
+
+	if o, ok := testingt.AsOutput(t); ok && failure.File != "" {
+		_, _ = fmt.Fprintf(o.Output(), "%s:%d: Error: %s\n", filepath.Base(failure.File), failure.Line, msg)
+		return
+	}
+
+	t.Logf("Error: %s", msg)
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_OnFailure(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake(double.FakeWithName("Test_Something")))
+
+	var failures []Failure
+	OnFailure(spiedT, func(failure Failure) { failures = append(failures, failure) })
+	OnFailure(spiedT, func(Failure) { spiedT.Log("second hook") })
+
+	got, want := 1, 2
+	Assert(spiedT, got != want)
+	Assert(spiedT, got == want)
+	NoError(spiedT, nil)
+	Equal(spiedT, got, want)
+
+	if len(failures) != 2 {
+		t.Fatalf("expected exactly two failures, got %d", len(failures))
+	}
+
+	if f := failures[0]; f.Test != "Test_Something" || filepath.Base(f.File) != "failure_test.go" || f.Line != 22 ||
+		f.Expression != "got == want" || f.Message != "got is not equal to want" {
+		t.Errorf("unexpected failure %+v", f)
+	}
+
+	if f := failures[1]; f.Line != 24 || f.Expression != "got" {
+		t.Errorf("unexpected failure %+v", f)
+	}
+
+	spiedT.ExpectLogsToContain(t, "second hook")
+
+	other := double.NewSpy(double.NewFake())
+	Assert(other, false)
+
+	if len(failures) != 2 {
+		t.Error("expected hooks to only be called for failures of the test they are registered on")
+	}
+}
+
+func Test_logFailure(t *testing.T) {
+	t.Run("structured output", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		structuredT := &structuredTestingT{TestingT: spiedT}
+
+		got, want := 1, 2
+		Assert(structuredT, got ==
+			want)
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectNoLogs(t)
+
+		if len(structuredT.attrs) != 3 || structuredT.attrs[0] != "assertion.file="+filepath.Join(mustGetwd(t), "failure_test.go") ||
+			structuredT.attrs[1] != "assertion.line=55" || structuredT.attrs[2] != "assertion.expression=got == want" {
+			t.Errorf("unexpected attributes %q", structuredT.attrs)
+		}
+
+		if output := structuredT.output.String(); output != "failure_test.go:55: Error: got is not equal to want\n" {
+			t.Errorf("unexpected output %q", output)
+		}
+	})
+
+	t.Run("failure reporter", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		c := Collect(spiedT)
+
+		Assert(c, false)
+
+		spiedT.ExpectNoLogs(t)
+		c.Report()
+		spiedT.ExpectLogsToContain(t, "Error: 1 collected failures:\n  - literal false")
+	})
+}
+
+// structuredTestingT is a TestingT supporting attributes and output, like *testing.T since Go 1.25.
+type structuredTestingT struct {
+	TestingT
+
+	attrs  []string
+	output bytes.Buffer
+}
+
+func (s *structuredTestingT) Attr(key, value string) { s.attrs = append(s.attrs, key+"="+value) }
+func (s *structuredTestingT) Output() io.Writer      { return &s.output }
+
+func mustGetwd(t *testing.T) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unable to get working directory: %v", err)
+	}
+	return wd
+}
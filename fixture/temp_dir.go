@@ -0,0 +1,73 @@
+// Package fixture provides helpers to set up resources needed by tests,
+// and to tear them down once tests are over.
+package fixture
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/krostar/test"
+)
+
+// TempDir creates a new temporary directory, and registers its removal in t.Cleanup.
+//
+// Unlike testing.T.TempDir, the directory is kept when the test failed,
+// and its path is logged to help understand what went wrong.
+// This is only possible if `t` provides a Failed() method, otherwise the directory is always removed.
+//
+// The test is stopped if the directory cannot be created.
+func TempDir(t test.TestingT) string {
+	t.Helper()
+
+	name := t.Name()
+
+	pattern := "krostar-test-*"
+	if name != "" {
+		pattern = sanitizeTempDirPattern(name) + "-*"
+	}
+
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		t.Logf("unable to create temporary directory: %v", err)
+		t.FailNow()
+		return ""
+	}
+
+	t.Cleanup(func() {
+		if f, ok := t.(interface{ Failed() bool }); ok && f.Failed() {
+			if name != "" {
+				t.Logf("test %s failed, keeping temporary directory %s", name, dir)
+			} else {
+				t.Logf("test failed, keeping temporary directory %s", dir)
+			}
+			return
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Logf("unable to remove temporary directory %s: %v", dir, err)
+			t.Fail()
+		}
+	})
+
+	return dir
+}
+
+// sanitizeTempDirPattern returns a string usable as a temporary directory name pattern,
+// by replacing characters which are not letters, digits, dashes or underscores.
+func sanitizeTempDirPattern(name string) string {
+	const maxLength = 64
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+
+	if runes := []rune(name); len(runes) > maxLength {
+		name = string(runes[:maxLength])
+	}
+
+	return name
+}
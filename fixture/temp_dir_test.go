@@ -0,0 +1,117 @@
+package fixture
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_TempDir(t *testing.T) {
+	t.Run("removed at cleanup", func(t *testing.T) {
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))
+
+		dir := TempDir(spiedT)
+		if stat, err := os.Stat(dir); err != nil || !stat.IsDir() {
+			t.Fatalf("expected %s to be a directory: %v", dir, err)
+		}
+
+		if !strings.HasPrefix(filepath.Base(dir), "krostar-test-") {
+			t.Errorf("expected unnamed test directory to be prefixed with krostar-test-, got %s", dir)
+		}
+
+		cleanup()
+
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", dir, err)
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("named after the test", func(t *testing.T) {
+		dir := TempDir(t)
+		if base := filepath.Base(dir); !strings.HasPrefix(base, "Test_TempDir_named_after_the_test-") {
+			t.Errorf("expected directory to be named after the test, got %s", base)
+		}
+	})
+
+	t.Run("kept when test failed", func(t *testing.T) {
+		var cleanup func()
+
+		failingT := &failedT{Spy: double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))}
+
+		dir := TempDir(failingT)
+		t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+		failingT.failed = true
+		cleanup()
+
+		if _, err := os.Stat(dir); err != nil {
+			t.Errorf("expected %s to be kept, got %v", dir, err)
+		}
+
+		failingT.ExpectLogsToContain(t, "test failed, keeping temporary directory "+dir)
+	})
+
+	t.Run("kept when named test failed", func(t *testing.T) {
+		var cleanup func()
+
+		failingT := &failedT{Spy: double.NewSpy(double.NewFake(
+			double.FakeWithName("Test_Foo/bar"),
+			double.FakeWithRegisterCleanup(func(f func()) { cleanup = f }),
+		))}
+
+		dir := TempDir(failingT)
+		t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+		if base := filepath.Base(dir); !strings.HasPrefix(base, "Test_Foo_bar-") {
+			t.Errorf("expected directory to be named after the test, got %s", base)
+		}
+
+		failingT.failed = true
+		cleanup()
+
+		failingT.ExpectLogsToContain(t, "test Test_Foo/bar failed, keeping temporary directory "+dir)
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		t.Setenv("TMPDIR", filepath.Join(t.TempDir(), "notexisting"))
+
+		spiedT := double.NewSpy(double.NewFake())
+		if dir := TempDir(spiedT); dir != "" {
+			t.Errorf("expected no directory, got %s", dir)
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+		spiedT.ExpectLogsToContain(t, "unable to create temporary directory")
+	})
+}
+
+func Test_sanitizeTempDirPattern(t *testing.T) {
+	for input, expected := range map[string]string{
+		"Test_Foo":                     "Test_Foo",
+		"Test_Foo/sub test/with-dash":  "Test_Foo_sub_test_with-dash",
+		"Test_Foo/*?":                  "Test_Foo___",
+		"Test_Ünïcode":                 "Test_Ünïcode",
+		strings.Repeat("a", 100):       strings.Repeat("a", 64),
+		strings.Repeat("é", 100) + "b": strings.Repeat("é", 64),
+	} {
+		if got := sanitizeTempDirPattern(input); got != expected {
+			t.Errorf("expected sanitizeTempDirPattern(%q) to be %q, got %q", input, expected, got)
+		}
+	}
+}
+
+type failedT struct {
+	*double.Spy
+	failed bool
+}
+
+func (f *failedT) Failed() bool { return f.failed }
@@ -0,0 +1,36 @@
+package fixture
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/krostar/test"
+)
+
+// Tx begins a new transaction on the database, and registers its rollback in t.Cleanup.
+//
+// It allows tests to freely modify the database, without impacting other tests,
+// as long as the code under test uses the returned transaction.
+// The transaction is started with the values of t.Context(), but not its cancellation:
+// the test context is canceled before cleanups run, which would otherwise roll the transaction back on its own.
+// The test is stopped if the transaction cannot be started.
+func Tx(t test.TestingT, db *sql.DB) *sql.Tx {
+	t.Helper()
+
+	tx, err := db.BeginTx(context.WithoutCancel(t.Context()), nil)
+	if err != nil {
+		t.Logf("unable to begin transaction: %v", err)
+		t.FailNow()
+		return nil
+	}
+
+	t.Cleanup(func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("unable to rollback transaction: %v", err)
+			t.Fail()
+		}
+	})
+
+	return tx
+}
@@ -0,0 +1,135 @@
+package fixture
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Tx(t *testing.T) {
+	t.Run("rolled back at cleanup", func(t *testing.T) {
+		var cleanup func()
+
+		conn := new(fakeTxConn)
+		db := sql.OpenDB(conn)
+		t.Cleanup(func() { _ = db.Close() })
+
+		spiedT := double.NewSpy(double.NewFake(
+			double.FakeWithContext(t.Context()),
+			double.FakeWithRegisterCleanup(func(f func()) { cleanup = f }),
+		))
+
+		if tx := Tx(spiedT, db); tx == nil {
+			t.Fatal("expected a transaction")
+		}
+
+		if !conn.begun || conn.rolledBack {
+			t.Fatalf("expected transaction to be begun and not rolled back yet")
+		}
+
+		cleanup()
+
+		if !conn.rolledBack {
+			t.Error("expected transaction to be rolled back")
+		}
+
+		spiedT.ExpectTestToPass(t)
+	})
+
+	t.Run("rolled back at cleanup of a real test", func(t *testing.T) {
+		conn := new(fakeTxConn)
+		db := sql.OpenDB(conn)
+		t.Cleanup(func() { _ = db.Close() })
+
+		t.Run("sub", func(t *testing.T) {
+			if tx := Tx(t, db); tx == nil {
+				t.Fatal("expected a transaction")
+			}
+		})
+
+		if !conn.rolledBack {
+			t.Error("expected transaction to be rolled back")
+		}
+
+		if conn.rollbacks != 1 {
+			t.Errorf("expected transaction to be rolled back once, got %d", conn.rollbacks)
+		}
+
+		if err := conn.txCtx.Err(); err != nil {
+			t.Errorf("expected transaction context to outlive the test context, got %v", err)
+		}
+	})
+
+	t.Run("already committed", func(t *testing.T) {
+		var cleanup func()
+
+		db := sql.OpenDB(new(fakeTxConn))
+		t.Cleanup(func() { _ = db.Close() })
+
+		spiedT := double.NewSpy(double.NewFake(
+			double.FakeWithContext(t.Context()),
+			double.FakeWithRegisterCleanup(func(f func()) { cleanup = f }),
+		))
+
+		if err := Tx(spiedT, db).Commit(); err != nil {
+			t.Fatalf("unexpected commit error: %v", err)
+		}
+
+		cleanup()
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("begin failure", func(t *testing.T) {
+		db := sql.OpenDB(&fakeTxConn{beginErr: errors.New("boom")})
+		t.Cleanup(func() { _ = db.Close() })
+
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithContext(t.Context())))
+		if tx := Tx(spiedT, db); tx != nil {
+			t.Error("expected no transaction")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "unable to begin transaction: boom")
+	})
+}
+
+// fakeTxConn is a database connection only able to manage transactions.
+type fakeTxConn struct {
+	beginErr   error
+	txCtx      context.Context
+	begun      bool
+	rolledBack bool
+	rollbacks  int
+}
+
+func (c *fakeTxConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (*fakeTxConn) Driver() driver.Driver                          { return nil }
+func (*fakeTxConn) Close() error                                   { return nil }
+func (*fakeTxConn) Prepare(string) (driver.Stmt, error)            { return nil, errors.New("not supported") }
+
+func (c *fakeTxConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeTxConn) BeginTx(ctx context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	if c.beginErr != nil {
+		return nil, c.beginErr
+	}
+	c.txCtx = ctx
+	c.begun = true
+	return c, nil
+}
+
+func (*fakeTxConn) Commit() error { return nil }
+
+func (c *fakeTxConn) Rollback() error {
+	c.rolledBack = true
+	c.rollbacks++
+	return nil
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/krostar/test/testingt"
+)
+
+// AssertionResult describes the result of an assertion, as given to a Formatter to render its message.
+type AssertionResult struct {
+	Passed      bool
+	Test        string       // name of the test, if t provides it
+	Case        string       // name of the subtest and index of the assertion in the subtest, like "case_a #2", empty outside subtests
+	Expression  string       // source of the asserted expression, if available
+	Description string       // description of the result, built from the asserted expression, like "got is not equal to want"
+	Values      []NamedValue // values attached with Values
+	Message     string       // custom message provided to the assertion
+	Annotations []string     // annotations registered with Annotate
+	Stack       string       // trimmed stack trace of the failed assertion, if enabled with WithStackTraces
+	Location    string       // location of the assertion, like "foo_test.go:42", if available
+	Verbosity   Verbosity    // level of details expected in the message, see WithVerbosity
+}
+
+// NamedValue is a value attached to an assertion with Values, named after the expression it comes from.
+type NamedValue struct {
+	Name  string
+	Value any
+	Repr  string // representation of the value, like the %#v verb renders it but with large and deep values elided
+}
+
+// Formatter renders the message of assertions results, logged after the "Error: ", "Warning: " or "Success: " labels.
+//
+// Custom formatters can be used to standardize the output of tests across teams,
+// or to integrate with in-house reporting tools. See SetFormatter.
+type Formatter interface {
+	Format(result AssertionResult) string
+}
+
+// FormatterFunc is a function implementing the Formatter interface.
+type FormatterFunc func(result AssertionResult) string
+
+// Format implements the Formatter interface.
+func (f FormatterFunc) Format(result AssertionResult) string { return f(result) }
+
+// DefaultFormatter is the formatter used unless another one is set with SetFormatter.
+//
+// It renders the case, the description, the values, the custom message, the annotations, and the stack trace, like:
+//
+//	[case_a #2] got is not equal to want; got=1, want=2 [custom message] (annotation)
+//	stack trace:
+//	  github.com/foo/bar.Test_Something
+//	      /src/bar/bar_test.go:42
+//
+// In quiet verbosity, only the first line of the description is rendered, without the details following it, like diffs.
+// In verbose verbosity, the source of the asserted expression and the location of the assertion are added.
+type DefaultFormatter struct{}
+
+// Format implements the Formatter interface.
+func (DefaultFormatter) Format(result AssertionResult) string {
+	if result.Verbosity == VerbosityQuiet {
+		description, _, _ := strings.Cut(result.Description, "\n")
+		return description
+	}
+
+	var sb strings.Builder
+
+	if result.Case != "" {
+		sb.WriteString("[" + result.Case + "] ")
+	}
+
+	sb.WriteString(result.Description)
+
+	for i, value := range result.Values {
+		if i == 0 {
+			sb.WriteString("; ")
+		} else {
+			sb.WriteString(", ")
+		}
+		if value.Repr != "" {
+			_, _ = fmt.Fprintf(&sb, "%s=%s", value.Name, value.Repr)
+		} else {
+			_, _ = fmt.Fprintf(&sb, "%s=%#v", value.Name, value.Value)
+		}
+	}
+
+	if result.Message != "" {
+		sb.WriteString(" [" + result.Message + "]")
+	}
+
+	if len(result.Annotations) > 0 {
+		sb.WriteString(" (" + strings.Join(result.Annotations, ", ") + ")")
+	}
+
+	if result.Verbosity == VerbosityVerbose {
+		if result.Expression != "" {
+			sb.WriteString("\nexpression: " + result.Expression)
+		}
+		if result.Location != "" {
+			sb.WriteString("\nlocation: " + result.Location)
+		}
+	}
+
+	if result.Stack != "" {
+		sb.WriteString("\nstack trace:\n" + result.Stack)
+	}
+
+	return sb.String()
+}
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	_formatter      Formatter = DefaultFormatter{}
+	_formatterMutex sync.RWMutex
+)
+
+// SetFormatter sets the formatter rendering the messages of all assertions, and returns the previous one.
+// Setting a nil formatter restores the DefaultFormatter.
+//
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//		test.SetFormatter(test.FormatterFunc(func(r test.AssertionResult) string {
+//			return fmt.Sprintf("%s: %s", r.Expression, r.Description)
+//		}))
+//		os.Exit(m.Run())
+//	}
+func SetFormatter(formatter Formatter) Formatter {
+	if formatter == nil {
+		formatter = DefaultFormatter{}
+	}
+
+	_formatterMutex.Lock()
+	defer _formatterMutex.Unlock()
+
+	previous := _formatter
+	_formatter = formatter
+
+	return previous
+}
+
+// formatResult completes the result with the details held by t, and renders it with the formatter of the provided options.
+// Values are rendered, and the message is truncated, with the limits of the provided options.
+func formatResult(t TestingT, opts options, result AssertionResult) string {
+	result.Test = testingt.Innermost(t).Name()
+	result.Annotations = annotationTexts(t)
+	result.Location = callerLocation()
+	result.Verbosity = opts.verbosity
+
+	for i, value := range result.Values {
+		result.Values[i].Repr = renderValue(value.Value, opts)
+	}
+
+	if !result.Passed && opts.stackTraces {
+		result.Stack = stackTrace()
+	}
+
+	return truncateMessage(opts.formatter.Format(result), opts.maxMessageLength)
+}
+
+// currentFormatter returns the formatter set with SetFormatter.
+func currentFormatter() Formatter {
+	_formatterMutex.RLock()
+	defer _formatterMutex.RUnlock()
+
+	return _formatter
+}
+
+// customMessage formats the custom message provided to an assertion, either as a format and its arguments,
+// or as a list of values.
+func customMessage(msgAndArgs []any) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprint(msgAndArgs[0])
+	}
+
+	if format, ok := msgAndArgs[0].(string); ok {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+
+	return strings.TrimSuffix(fmt.Sprintln(msgAndArgs...), "\n")
+}
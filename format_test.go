@@ -0,0 +1,77 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_DefaultFormatter(t *testing.T) {
+	for result, expected := range map[*AssertionResult]string{
+		{Description: "got is not equal to want"}: "got is not equal to want",
+		{
+			Case:        "case_a #2",
+			Description: "got is not equal to want",
+			Values:      []NamedValue{{Name: "got", Value: 1}, {Name: "want", Value: "2"}},
+			Message:     "custom message",
+			Annotations: []string{"attempt 3", "user 42"},
+		}: `[case_a #2] got is not equal to want; got=1, want="2" [custom message] (attempt 3, user 42)`,
+	} {
+		if got := (DefaultFormatter{}).Format(*result); got != expected {
+			t.Errorf("unexpected formatted result:\n got: %s\nwant: %s", got, expected)
+		}
+	}
+}
+
+func Test_SetFormatter(t *testing.T) {
+	var results []AssertionResult
+
+	previous := SetFormatter(FormatterFunc(func(r AssertionResult) string {
+		results = append(results, r)
+		return fmt.Sprintf("%s => %s", r.Expression, r.Description)
+	}))
+	t.Cleanup(func() { SetFormatter(previous) })
+
+	spiedT := double.NewSpy(double.NewFake(double.FakeWithName("Test_Something/case_a")))
+	remove := Annotate(spiedT, "attempt %d", 3)
+	got, want := 1, 2
+	Assert(spiedT, got == want, "hello %s", "world", Values(got))
+	remove()
+
+	spiedT.ExpectLogsToContain(t, "Error: got == want => got is not equal to want")
+
+	if len(results) != 1 {
+		t.Fatalf("expected exactly one formatted result, got %d", len(results))
+	}
+
+	if r := results[0]; r.Passed || r.Test != "Test_Something/case_a" || r.Case != "case_a #1" ||
+		r.Message != "hello world" || len(r.Values) != 1 || r.Values[0] != (NamedValue{Name: "got", Value: 1, Repr: "1"}) ||
+		len(r.Annotations) != 1 || r.Annotations[0] != "attempt 3" {
+		t.Errorf("unexpected result %+v", r)
+	}
+
+	if _, ok := SetFormatter(nil).(FormatterFunc); !ok {
+		t.Error("expected the previous formatter to be returned")
+	}
+
+	if _, ok := SetFormatter(previous).(DefaultFormatter); !ok {
+		t.Error("expected a nil formatter to restore the default formatter")
+	}
+}
+
+func Test_customMessage(t *testing.T) {
+	for _, tt := range []struct {
+		msgAndArgs []any
+		expected   string
+	}{
+		{msgAndArgs: nil, expected: ""},
+		{msgAndArgs: []any{42}, expected: "42"},
+		{msgAndArgs: []any{"hello %s", "world"}, expected: "hello world"},
+		{msgAndArgs: []any{42, "hello"}, expected: "42 hello"},
+	} {
+		if got := customMessage(tt.msgAndArgs); got != tt.expected {
+			t.Errorf("unexpected custom message for %v: got %q, want %q", tt.msgAndArgs, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/krostar/test/internal/goroutine"
+	"github.com/krostar/test/testingt"
+)
+
+// GoroutineT is a TestingT safe to use from goroutines spawned by a test.
+// It is created with Go.
+type GoroutineT struct {
+	TestingT
+
+	testGoroutine string // header of the goroutine that created it, see goroutine.Header
+
+	m      sync.Mutex
+	queue  []func(t TestingT)
+	closed bool
+}
+
+// Go returns a GoroutineT, on which goroutines spawned by the test can make assertions.
+//
+// Logs and failures made on it are queued, and replayed on t from the test goroutine, by Flush,
+// which is automatically called when the test completes. It avoids the panics and lost failures
+// of goroutines failing a test from outside of its goroutine, or after it completed.
+// Logs and failures happening once the test completed are dropped.
+//
+// FailNow stops the calling goroutine, and fails the test once flushed.
+// Called from the test goroutine, FailNow flushes the queue, and stops the test with t.FailNow.
+// Other methods, like Cleanup or Context, are forwarded to t.
+//
+// Example:
+//
+//	func Test_Workers(t *testing.T) {
+//		g := test.Go(t)
+//		var wg sync.WaitGroup
+//		for _, job := range jobs {
+//			wg.Go(func() { test.Assert(g, process(job) == nil) })
+//		}
+//		wg.Wait()
+//		g.Flush()
+//	}
+func Go(t TestingT) *GoroutineT {
+	g := &GoroutineT{TestingT: t, testGoroutine: goroutine.Header()}
+	t.Cleanup(func() {
+		g.Flush()
+
+		g.m.Lock()
+		g.closed = true
+		g.m.Unlock()
+	})
+	return g
+}
+
+// Helper does nothing, as the location of the queued logs is recorded when they are made.
+func (*GoroutineT) Helper() {}
+
+// Log queues the log, prefixed by its location.
+func (g *GoroutineT) Log(args ...any) {
+	msg := callerLocation() + ": " + strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	g.enqueue(func(t TestingT) {
+		t.Helper()
+		t.Log(msg)
+	})
+}
+
+// Logf queues the log, prefixed by its location.
+func (g *GoroutineT) Logf(format string, args ...any) {
+	msg := callerLocation() + ": " + fmt.Sprintf(format, args...)
+	g.enqueue(func(t TestingT) {
+		t.Helper()
+		t.Log(msg)
+	})
+}
+
+// reportFailure queues the log of the failure, like Logf does.
+// The failure is not logged by logFailure, as the capabilities of t, like its output, cannot be used from goroutines.
+func (g *GoroutineT) reportFailure(opts options, failure Failure) {
+	g.Logf("Error: %s", colorizeMessage(opts.color, failure.Message))
+}
+
+// Fail queues the failure of the test.
+func (g *GoroutineT) Fail() {
+	g.enqueue(func(t TestingT) { t.Fail() })
+}
+
+// FailNow queues the failure of the test, and stops the calling goroutine.
+// On the test goroutine, the queue is flushed and the test is stopped by t.FailNow,
+// for the test to be stopped the way t stops it, like *testing.T which also runs its cleanups.
+func (g *GoroutineT) FailNow() {
+	if goroutine.Header() == g.testGoroutine {
+		g.Flush()
+		g.TestingT.FailNow()
+		return
+	}
+
+	g.Fail()
+	runtime.Goexit()
+}
+
+// Flush replays the queued logs and failures on the test. It must be called from the test goroutine.
+func (g *GoroutineT) Flush() {
+	g.TestingT.Helper()
+
+	g.m.Lock()
+	queue := g.queue
+	g.queue = nil
+	g.m.Unlock()
+
+	for _, f := range queue {
+		f(g.TestingT)
+	}
+}
+
+// Unwrap returns the TestingT the logs and failures are replayed on, see testingt.Unwrap.
+func (g *GoroutineT) Unwrap() testingt.TestingT { return g.TestingT }
+
+// enqueue adds f to the queue, unless the test completed.
+func (g *GoroutineT) enqueue(f func(t TestingT)) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if !g.closed {
+		g.queue = append(g.queue, f)
+	}
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Go(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+	g := Go(spiedT)
+
+	var (
+		wg   sync.WaitGroup
+		done atomic.Int32
+	)
+	for i := range 3 {
+		wg.Go(func() {
+			g.Log("hello from", i)
+			Require(g, i != 1, "worker %d", i)
+			g.Logf("worker %d done", i)
+			done.Add(1)
+		})
+	}
+	wg.Wait()
+
+	if done.Load() != 2 {
+		t.Errorf("expected the failing worker to be stopped, %d workers completed", done.Load())
+	}
+
+	spiedT.ExpectTestToPass(t)
+	spiedT.ExpectNoLogs(t)
+
+	g.Flush()
+
+	spiedT.ExpectTestToFail(t)
+	spiedT.ExpectLogsToContain(t,
+		"goroutine_test.go:21: hello from 1",
+		"goroutine_test.go:22: Error: i is equal to 1 [worker 1]",
+		"goroutine_test.go:23: worker 0 done",
+		"goroutine_test.go:23: worker 2 done",
+	)
+
+	t.Run("logs are attributed to the caller of Flush", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake(), double.SpyWithHelperChainRecording())
+		g := Go(spiedT)
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			g.Log("hello")
+			g.Logf("hello %s", "world")
+		})
+		wg.Wait()
+
+		g.Flush()
+
+		spiedT.ExpectHelperChain(t, 2) // the queued log, and Flush
+	})
+
+	t.Run("fail now on the test goroutine", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		g := Go(spiedT)
+
+		var wg sync.WaitGroup
+		wg.Go(func() { g.Log("from worker") })
+		wg.Wait()
+
+		Require(g, false)
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false,
+			double.SpyTestingTRecord{Method: "Log", Inputs: []any{double.SpyTestingTRecordIgnoreParam}},
+			double.SpyTestingTRecord{Method: "Log", Inputs: []any{double.SpyTestingTRecordIgnoreParam}},
+			double.SpyTestingTRecord{Method: "FailNow"},
+		)
+		spiedT.ExpectLogsToContain(t, "from worker", "Error: literal false")
+	})
+
+	t.Run("structured test", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		structuredT := &structuredTestingT{TestingT: spiedT}
+		g := Go(structuredT)
+
+		var wg sync.WaitGroup
+		wg.Go(func() { Assert(g, false) })
+		wg.Wait()
+
+		if structuredT.output.Len() != 0 || len(structuredT.attrs) != 0 {
+			t.Errorf("expected the failure not to be written from the goroutine, got output %q and attributes %v", structuredT.output.String(), structuredT.attrs)
+		}
+
+		g.Flush()
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "goroutine_test.go:", ": Error: literal false")
+	})
+
+	t.Run("test completed", func(t *testing.T) {
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanup = f })))
+		g := Go(spiedT)
+
+		cleanup()
+		g.Log("too late")
+		g.Fail()
+		g.Flush()
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"runtime"
+
+	"github.com/krostar/test/internal/message"
+	"github.com/krostar/test/testingt"
+)
+
+// AssertAt behaves like Assert, for assertion helpers built on top of it: the assertion is described and located
+// as the call made `depth` frames above the caller of AssertAt, instead of the call to AssertAt itself.
+// With a depth of 1, the assertion points at the call of the helper calling AssertAt.
+//
+// The call is described by its boolean argument, if it has exactly one, or as a whole otherwise.
+// Values attached with Values, and the custom message, are the ones provided to AssertAt.
+// Like with Assert, helpers should call t.Helper, for the failure to be logged at the location of their caller.
+//
+// Example:
+//
+//	func assertAdult(t test.TestingT, u User) bool {
+//		t.Helper()
+//		return test.AssertAt(t, 1, u.Age >= 18, test.Values(u.Age))
+//	}
+//
+//	assertAdult(t, bob)
+//
+// -> Error: assertAdult(t, bob) failed; u.Age=12
+func AssertAt(t TestingT, depth int, result bool, msgAndArgs ...any) bool {
+	t.Helper()
+
+	logResultAt(t, result, 1, depth, msgAndArgs)
+
+	if !result {
+		failAssertion(t, msgAndArgs)
+	}
+
+	return result
+}
+
+// RequireAt stops the test execution immediately if `result` is false.
+// Otherwise, it behaves the same as AssertAt.
+func RequireAt(t TestingT, depth int, result bool, msgAndArgs ...any) {
+	t.Helper()
+
+	logResultAt(t, result, 1, depth, msgAndArgs)
+
+	if !result {
+		t.FailNow()
+	}
+}
+
+// logResultAt behaves like logResult, for assertions located `depth` frames above the AssertAt-like call of the caller.
+// The values attached to the assertion are named after the arguments of the AssertAt-like call.
+func logResultAt(t TestingT, passed bool, callerStackIndex, depth int, msgAndArgs []any) {
+	t.Helper()
+
+	locationStackIndex := callerStackIndex + max(depth, 0)
+
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+	recordAssertion(t, opts, locationStackIndex+1, passed)
+	message.RecordCallSite(locationStackIndex + 1)
+
+	result := AssertionResult{Passed: passed, Case: caseName(t)}
+
+	if passed && !opts.successMessages {
+		return
+	}
+
+	if opts.sourceAnalysis {
+		var err error
+
+		result.Description, err = message.FromCall(locationStackIndex+1, passed)
+		if err != nil {
+			t.Logf("krostar/test internal failure: unable to get assertion message: %v", err)
+		}
+		if result.Description == "" {
+			result.Description = genericDescription(passed)
+		}
+
+		result.Expression, _ = message.CallExpression(locationStackIndex + 1)
+	} else {
+		result.Description = genericDescription(passed)
+	}
+
+	msgAndArgs, result.Values = extractValues(callerStackIndex+1, 3, msgAndArgs, opts.sourceAnalysis)
+	result.Message = customMessage(msgAndArgs)
+
+	msg := formatResult(t, opts, result)
+
+	if passed {
+		logSuccess(t, msg)
+		return
+	}
+
+	failure := Failure{Test: testingt.Innermost(t).Name(), Message: msg, Expression: result.Expression, Values: result.Values}
+	_, failure.File, failure.Line, _ = runtime.Caller(locationStackIndex + 1)
+
+	notifyFailure(t, opts, failure)
+}
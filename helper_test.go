@@ -0,0 +1,62 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+type helperTestingUser struct{ Age int }
+
+func assertAdult(t TestingT, u helperTestingUser) bool {
+	t.Helper()
+	return AssertAt(t, 1, u.Age >= 18, "too young", Values(u.Age))
+}
+
+func requireTrue(t TestingT, ok bool) {
+	t.Helper()
+	RequireAt(t, 1, ok)
+}
+
+func Test_AssertAt(t *testing.T) {
+	t.Run("described by the call", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		var failure Failure
+		OnFailure(spiedT, func(f Failure) { failure = f })
+
+		bob := helperTestingUser{Age: 12}
+		if assertAdult(spiedT, bob) {
+			t.Error("AssertAt should return false when result is false")
+		}
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: assertAdult(spiedT, bob) failed; u.Age=12 [too young]")
+
+		if filepath.Base(failure.File) != "helper_test.go" || failure.Line != 30 || failure.Expression != "assertAdult(spiedT, bob)" {
+			t.Errorf("unexpected failure %+v", failure)
+		}
+	})
+
+	t.Run("described by the boolean argument", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 1, 2
+
+		requireTrue(spiedT, got == want)
+
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+		spiedT.ExpectLogsToContain(t, "Error: got is not equal to want")
+	})
+
+	t.Run("no depth", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		if !AssertAt(spiedT, 0, true, WithSuccessMessages()) {
+			t.Error("AssertAt should return true when result is true")
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectLogsToContain(t, "Success: literal true")
+	})
+}
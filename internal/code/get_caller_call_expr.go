@@ -4,7 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
-	"go/token"
+	"go/types"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -14,17 +15,21 @@ import (
 // `pkgs` is a map of package paths to *packages.Package, representing the parsed ASTs.
 // `callerFile` is the filename of the caller's source file.
 // `callerLine` is the line number in the caller's source file where the call expression is located.
+// `callee` is the name of the called function, as reported by runtime.Frame.Function, used to tell apart
+// the calls found on the same line, like nested calls; it can be empty to get the first call of the line.
+// `occurrence` is the position of the call among the calls to `callee` found on the line, in source order,
+// to tell apart identical calls made on the same line.
 //
 // It returns the *ast.CallExpr, the *ast.File containing the expression, the *packages.Package
 // to which the file belongs, and an error if any occurred during the process.
 // Returns nil values if the package, file or expression is not found.
-func GetCallerCallExpr(pkgs map[string]*packages.Package, callerFile string, callerLine int) (*ast.CallExpr, *ast.File, *packages.Package, error) {
+func GetCallerCallExpr(pkgs map[string]*packages.Package, callerFile string, callerLine int, callee string, occurrence int) (*ast.CallExpr, *ast.File, *packages.Package, error) {
 	pkg, file := findCallerPackageAndASTFile(pkgs, callerFile)
 	if pkg == nil || file == nil {
 		return nil, nil, nil, fmt.Errorf("unable to find ast file and package for %s", callerFile)
 	}
 
-	expr, err := getASTCallExprAtLine(pkg.Fset, file, callerLine)
+	expr, err := getASTCallExprAtLine(pkg, file, callerLine, callee, occurrence)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("unable to get call expression: %v", err)
 	}
@@ -48,34 +53,110 @@ func findCallerPackageAndASTFile(pkgs map[string]*packages.Package, callerFile s
 
 // getASTCallExprAtLine retrieves the *ast.CallExpr at a specified line within an *ast.File.
 //
-// `fset` is the *token.FileSet used for position information.
+// `pkg` provides the position and type information of the file.
 // `file` is the *ast.File to search within.
 // `line` is the target line number.
+// `callee` is the runtime name of the called function, and `occurrence` the position of the call among the calls
+// to `callee` on the line, see GetCallerCallExpr.
+//
+// When several calls are found on the line, the call at position `occurrence` among the ones calling `callee` is returned,
+// or the first one calling `callee` if there are not that many, or the first one of the line if none of them does,
+// like for calls of function values.
 //
 // Returns the *ast.CallExpr if found on the specified line, an error otherwise.
-func getASTCallExprAtLine(fset *token.FileSet, file *ast.File, line int) (*ast.CallExpr, error) {
-	var callExpr *ast.CallExpr
+func getASTCallExprAtLine(pkg *packages.Package, file *ast.File, line int, callee string, occurrence int) (*ast.CallExpr, error) {
+	var calls []*ast.CallExpr
 
 	ast.Inspect(file, func(node ast.Node) bool {
-		if node == nil || callExpr != nil {
+		if node == nil {
 			return false
 		}
 
-		if fset.Position(node.Pos()).Line != line {
-			return true
+		if pkg.Fset.Position(node.Pos()).Line != line {
+			return pkg.Fset.Position(node.Pos()).Line <= line && pkg.Fset.Position(node.End()).Line >= line
 		}
 
 		if call, ok := node.(*ast.CallExpr); ok {
-			callExpr = call
-			return false
+			calls = append(calls, call)
 		}
 
 		return true
 	})
 
-	if callExpr == nil {
+	if len(calls) == 0 {
 		return nil, errors.New("ast inspection did not return a node")
 	}
 
-	return callExpr, nil
+	if callee != "" && len(calls) > 1 {
+		callee = stripTypeParameters(callee)
+
+		var calleeCalls []*ast.CallExpr
+		for _, call := range calls {
+			if calledFunctionRuntimeName(pkg, call) == callee {
+				calleeCalls = append(calleeCalls, call)
+			}
+		}
+
+		switch {
+		case occurrence >= 0 && occurrence < len(calleeCalls):
+			return calleeCalls[occurrence], nil
+		case len(calleeCalls) > 0:
+			return calleeCalls[0], nil
+		}
+	}
+
+	return calls[0], nil
+}
+
+// calledFunctionRuntimeName returns the name of the function called by `call`, formatted like runtime.Frame.Function,
+// or an empty string if it is not a statically known function.
+func calledFunctionRuntimeName(pkg *packages.Package, call *ast.CallExpr) string {
+	fun := ast.Unparen(call.Fun)
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return ""
+	}
+
+	obj, ok := pkg.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok || obj.Pkg() == nil {
+		return ""
+	}
+
+	recv := obj.Signature().Recv()
+	if recv == nil {
+		return obj.Pkg().Path() + "." + obj.Name()
+	}
+
+	typ, pointer := recv.Type(), false
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ, pointer = ptr.Elem(), true
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return ""
+	}
+
+	if pointer {
+		return obj.Pkg().Path() + ".(*" + named.Obj().Name() + ")." + obj.Name()
+	}
+	return obj.Pkg().Path() + "." + named.Obj().Name() + "." + obj.Name()
+}
+
+// stripTypeParameters removes the type parameters of generic function names reported by the runtime,
+// like "pkg.Assert[...]" or "pkg.(*T[...]).M".
+func stripTypeParameters(name string) string {
+	return strings.ReplaceAll(name, "[...]", "")
 }
@@ -2,6 +2,7 @@ package code
 
 import (
 	"go/ast"
+	"go/types"
 	"strings"
 	"testing"
 )
@@ -15,7 +16,7 @@ func Test_GetCallerCallExpr(t *testing.T) {
 	ok := pkgs["github.com/krostar/test/internal/code/testdata/ok"]
 
 	t.Run("ok", func(t *testing.T) {
-		expr, file, pkg, err := GetCallerCallExpr(pkgs, ok.CompiledGoFiles[0], 13)
+		expr, file, pkg, err := GetCallerCallExpr(pkgs, ok.CompiledGoFiles[0], 13, "", 0)
 		if err != nil {
 			t.Fatalf("failed to get caller expr: %v", err)
 		}
@@ -37,9 +38,39 @@ func Test_GetCallerCallExpr(t *testing.T) {
 		}
 	})
 
+	t.Run("callee", func(t *testing.T) {
+		for callee, expected := range map[string]string{
+			"": "wrap",
+			"github.com/krostar/test/internal/code/testdata/ok.launch":  "launch",
+			"github.com/krostar/test/internal/code/testdata/ok.unknown": "wrap",
+		} {
+			expr, _, _, err := GetCallerCallExpr(pkgs, ok.CompiledGoFiles[0], 17, callee, 0)
+			if err != nil {
+				t.Fatalf("failed to get caller expr: %v", err)
+			}
+
+			if fun := expr.Fun.(*ast.Ident).Name; fun != expected {
+				t.Errorf("expected function of callee %q to be %s, got %s", callee, expected, fun)
+			}
+		}
+	})
+
+	t.Run("occurrence", func(t *testing.T) {
+		for occurrence, expected := range map[int]string{0: "new(firework)", 1: "nil", 2: "new(firework)"} {
+			expr, _, _, err := GetCallerCallExpr(pkgs, ok.CompiledGoFiles[0], 23, "github.com/krostar/test/internal/code/testdata/ok.launch", occurrence)
+			if err != nil {
+				t.Fatalf("failed to get caller expr: %v", err)
+			}
+
+			if arg := types.ExprString(expr.Args[0]); arg != expected {
+				t.Errorf("expected call at occurrence %d to have argument %s, got %s", occurrence, expected, arg)
+			}
+		}
+	})
+
 	t.Run("ko", func(t *testing.T) {
 		t.Run("pkg not found", func(t *testing.T) {
-			expr, file, pkg, err := GetCallerCallExpr(pkgs, "./notexisting.go", 1043)
+			expr, file, pkg, err := GetCallerCallExpr(pkgs, "./notexisting.go", 1043, "", 0)
 			if err == nil {
 				t.Error("expected failure")
 			}
@@ -54,7 +85,7 @@ func Test_GetCallerCallExpr(t *testing.T) {
 		})
 
 		t.Run("expr not found", func(t *testing.T) {
-			expr, file, pkg, err := GetCallerCallExpr(pkgs, ok.CompiledGoFiles[0], 5)
+			expr, file, pkg, err := GetCallerCallExpr(pkgs, ok.CompiledGoFiles[0], 5, "", 0)
 			if err == nil {
 				t.Error("expected failure")
 			}
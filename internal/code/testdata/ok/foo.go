@@ -12,3 +12,13 @@ func Awesome() error {
 	f := new(firework)
 	return launch(f)
 }
+
+func Nested() error {
+	return wrap(launch(new(firework)))
+}
+
+func wrap(err error) error { return err }
+
+func Twice() error {
+	return errors.Join(launch(new(firework)), launch(nil))
+}
@@ -10,6 +10,7 @@ import (
 	gotestassert "gotest.tools/v3/assert"
 
 	"github.com/krostar/test"
+	"github.com/krostar/test/check"
 	"github.com/krostar/test/double"
 )
 
@@ -214,6 +215,52 @@ func Benchmark_SimpleEquality_Failure(b *testing.B) {
 	})
 }
 
+func Benchmark_DeepEquality_Success(b *testing.B) {
+	fakeT := double.NewFake()
+
+	type item struct {
+		ID     int
+		Name   string
+		Labels map[string]string
+	}
+
+	items1 := make([]item, 1000)
+	for i := range items1 {
+		items1[i] = item{ID: i, Name: "item", Labels: map[string]string{"env": "prod", "team": "core"}}
+	}
+	items2 := slices.Clone(items1)
+
+	b.Run("krostar/test", func(b *testing.B) {
+		for b.Loop() {
+			test.Assert(check.Compare(fakeT, items1, items2))
+		}
+	})
+
+	b.Run("testify", func(b *testing.B) {
+		adapter := &testifyAdapter{fakeT}
+
+		for b.Loop() {
+			assert.Equal(adapter, items1, items2)
+		}
+	})
+
+	b.Run("matryer/is", func(b *testing.B) {
+		is := is.New(fakeT)
+
+		for b.Loop() {
+			is.Equal(items1, items2)
+		}
+	})
+
+	b.Run("gotesttools", func(b *testing.B) {
+		adapter := &gotestAdapter{fakeT}
+
+		for b.Loop() {
+			gotestassert.DeepEqual(adapter, items1, items2)
+		}
+	})
+}
+
 // testifyAdapter adapts double.TestingT to work with testify's assert.TestingT interface
 type testifyAdapter struct{ double.TestingT }
 
@@ -0,0 +1,47 @@
+package diff
+
+import (
+	"strings"
+)
+
+// Inline returns a character-level difference between `a` and `b`, as a single text where
+// runs of characters only in `a` are wrapped in [-...-], and runs of characters only in `b` are wrapped in {+...+},
+// like git diff --word-diff does for words.
+func Inline(a, b string) string {
+	var (
+		sb   strings.Builder
+		kind = EditEqual
+	)
+
+	closeRun := func() {
+		switch kind {
+		case EditDelete:
+			sb.WriteString("-]")
+		case EditInsert:
+			sb.WriteString("+}")
+		case EditEqual:
+		}
+	}
+
+	for _, edit := range Lines(strings.Split(a, ""), strings.Split(b, "")) {
+		if edit.Kind != kind {
+			closeRun()
+
+			switch edit.Kind {
+			case EditDelete:
+				sb.WriteString("[-")
+			case EditInsert:
+				sb.WriteString("{+")
+			case EditEqual:
+			}
+
+			kind = edit.Kind
+		}
+
+		sb.WriteString(edit.Line)
+	}
+
+	closeRun()
+
+	return sb.String()
+}
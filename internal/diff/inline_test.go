@@ -0,0 +1,23 @@
+package diff
+
+import "testing"
+
+func Test_Inline(t *testing.T) {
+	for name, tc := range map[string]struct {
+		a, b     string
+		expected string
+	}{
+		"equal":     {a: "hello", b: "hello", expected: "hello"},
+		"empty":     {a: "", b: "", expected: ""},
+		"replaced":  {a: "connection refused", b: "connection reset", expected: "connection re[-fu-]se[-d-]{+t+}"},
+		"inserted":  {a: "not found", b: "user not found", expected: "{+user +}not found"},
+		"deleted":   {a: "file not found", b: "file found", expected: "file [-not -]found"},
+		"multibyte": {a: "café", b: "cafe", expected: "caf[-é-]{+e+}"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := Inline(tc.a, tc.b); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package diff computes line-based and character-based differences between texts.
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EditKind is the kind of operation an Edit represents.
+type EditKind uint8
+
+// Kinds of edits.
+const (
+	EditEqual  EditKind = iota // the line is in both texts
+	EditDelete                 // the line is only in the first text
+	EditInsert                 // the line is only in the second text
+)
+
+// Edit is a single line operation transforming the first text into the second one.
+type Edit struct {
+	Kind EditKind
+	Line string
+}
+
+// Lines returns the shortest list of edits transforming the lines of `a` into the lines of `b`.
+// It is an implementation of the Myers' diff algorithm.
+//
+// As the backtracking needs the furthest reaching paths of every step, each of them is kept,
+// but only for the diagonals the step can reach: memory grows with the square of the number of edits,
+// not with the product of the lengths of the texts.
+func Lines(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+
+	v := make([]int, 2*offset+1)
+
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		// diagonals -d to d, the only ones step d reads from the previous steps
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+
+	return nil // unreachable, there is at most n+m edits
+}
+
+// backtrack walks the trace of the Myers' algorithm to build the list of edits.
+// The trace of step d holds the furthest reaching paths of diagonals -d to d, before the step.
+func backtrack(trace [][]int, a, b []string) []Edit {
+	var edits []Edit
+
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		// furthest reaching path of diagonal k, paths outside the band are still at their initial position
+		furthest := func(k int) int {
+			if k < -d || k > d {
+				return 0
+			}
+			return trace[d][d+k]
+		}
+
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && furthest(k-1) < furthest(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := furthest(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, Edit{Kind: EditEqual, Line: a[x-1]})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, Edit{Kind: EditInsert, Line: b[y-1]})
+				y--
+			} else {
+				edits = append(edits, Edit{Kind: EditDelete, Line: a[x-1]})
+				x--
+			}
+		}
+	}
+
+	slices.Reverse(edits)
+
+	return edits
+}
+
+// Unified returns the differences between the lines of `a` and `b` in the unified diff format,
+// with `contextLines` unchanged lines around each change. Headers naming the texts are not included.
+// It returns an empty string if there are no differences.
+func Unified(a, b []string, contextLines int) string {
+	edits := Lines(a, b)
+
+	// position of each edit in both texts
+	type position struct{ a, b int }
+
+	positions := make([]position, len(edits)+1)
+	for i, edit := range edits {
+		positions[i+1] = positions[i]
+		switch edit.Kind {
+		case EditEqual:
+			positions[i+1].a++
+			positions[i+1].b++
+		case EditDelete:
+			positions[i+1].a++
+		case EditInsert:
+			positions[i+1].b++
+		}
+	}
+
+	var buf strings.Builder
+
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == EditEqual {
+			i++
+			continue
+		}
+
+		start, end := max(0, i-contextLines), i
+		for {
+			for end < len(edits) && edits[end].Kind != EditEqual {
+				end++
+			}
+
+			unchanged := end
+			for unchanged < len(edits) && edits[unchanged].Kind == EditEqual {
+				unchanged++
+			}
+
+			if unchanged < len(edits) && unchanged-end <= 2*contextLines {
+				end = unchanged
+				continue
+			}
+
+			end = min(end+contextLines, len(edits))
+
+			break
+		}
+
+		from, to := positions[start], positions[end]
+		_, _ = fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(from.a, to.a-from.a), hunkRange(from.b, to.b-from.b))
+
+		for _, edit := range edits[start:end] {
+			switch edit.Kind {
+			case EditEqual:
+				buf.WriteString(" ")
+			case EditDelete:
+				buf.WriteString("-")
+			case EditInsert:
+				buf.WriteString("+")
+			}
+			buf.WriteString(edit.Line)
+			buf.WriteString("\n")
+		}
+
+		i = end
+	}
+
+	return buf.String()
+}
+
+// hunkRange formats the range of lines of a hunk, like the unified diff format does.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
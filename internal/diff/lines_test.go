@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Lines(t *testing.T) {
+	for name, tt := range map[string]struct {
+		a, b     string
+		expected []Edit
+	}{
+		"empty": {
+			a: "", b: "",
+			expected: nil,
+		},
+		"identical": {
+			a: "a b c", b: "a b c",
+			expected: []Edit{{EditEqual, "a"}, {EditEqual, "b"}, {EditEqual, "c"}},
+		},
+		"insertions": {
+			a: "a c", b: "a b c d",
+			expected: []Edit{{EditEqual, "a"}, {EditInsert, "b"}, {EditEqual, "c"}, {EditInsert, "d"}},
+		},
+		"deletions": {
+			a: "a b c d", b: "b d",
+			expected: []Edit{{EditDelete, "a"}, {EditEqual, "b"}, {EditDelete, "c"}, {EditEqual, "d"}},
+		},
+		"replacement": {
+			a: "a b c", b: "a x c",
+			expected: []Edit{{EditEqual, "a"}, {EditDelete, "b"}, {EditInsert, "x"}, {EditEqual, "c"}},
+		},
+		"everything differs": {
+			a: "a b", b: "c",
+			expected: []Edit{{EditDelete, "a"}, {EditDelete, "b"}, {EditInsert, "c"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := Lines(strings.Fields(tt.a), strings.Fields(tt.b)); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected edits %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_Lines_largeTexts(t *testing.T) {
+	a := make([]string, 100_000)
+	for i := range a {
+		a[i] = strconv.Itoa(i)
+	}
+
+	b := slices.Clone(a)
+	b[50_000] = "changed"
+
+	edits := Lines(a, b)
+	if len(edits) != len(a)+1 {
+		t.Fatalf("expected %d edits, got %d", len(a)+1, len(edits))
+	}
+
+	if expected := []Edit{{EditDelete, "50000"}, {EditInsert, "changed"}}; !slices.Equal(edits[50_000:50_002], expected) {
+		t.Errorf("expected edits %v, got %v", expected, edits[50_000:50_002])
+	}
+}
+
+func Test_Unified(t *testing.T) {
+	for name, tt := range map[string]struct {
+		a, b     string
+		context  int
+		expected string
+	}{
+		"no differences": {
+			a: "a b c", b: "a b c", context: 3,
+			expected: "",
+		},
+		"single hunk": {
+			a: "1 2 3 4 5 6 7 8 9", b: "1 2 3 4 x 6 7 8 9", context: 2,
+			expected: "@@ -3,5 +3,5 @@\n 3\n 4\n-5\n+x\n 6\n 7\n",
+		},
+		"merged hunks": {
+			a: "1 2 3 4 5 6 7 8 9", b: "1 x 3 4 5 6 7 y 9", context: 3,
+			expected: "@@ -1,9 +1,9 @@\n 1\n-2\n+x\n 3\n 4\n 5\n 6\n 7\n-8\n+y\n 9\n",
+		},
+		"separated hunks": {
+			a: "1 2 3 4 5 6 7 8 9", b: "1 x 3 4 5 6 7 y 9", context: 1,
+			expected: "@@ -1,3 +1,3 @@\n 1\n-2\n+x\n 3\n@@ -7,3 +7,3 @@\n 7\n-8\n+y\n 9\n",
+		},
+		"from empty": {
+			a: "", b: "a b", context: 3,
+			expected: "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := Unified(strings.Fields(tt.a), strings.Fields(tt.b), tt.context); got != tt.expected {
+				t.Errorf("unexpected unified diff, got:\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
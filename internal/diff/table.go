@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table renders a numbered, side-by-side table of the `got` and `want` entries, rendered with `render`.
+// Each row is flagged with the status of the comparison, made with `equal`, of the entries at that position:
+// ok, mismatch, unexpected when there is no wanted entry, or missing when there is no got entry.
+// Rows are numbered from 1.
+func Table[T any](got, want []T, equal func(got, want T) bool, render func(T) string) string {
+	var buf strings.Builder
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "#\tstatus\tgot\twant")
+
+	for i := range max(len(got), len(want)) {
+		var status, g, e string
+
+		switch {
+		case i >= len(want):
+			status, g, e = "unexpected", render(got[i]), "-"
+		case i >= len(got):
+			status, g, e = "missing", "-", render(want[i])
+		case equal(got[i], want[i]):
+			status, g, e = "ok", render(got[i]), render(want[i])
+		default:
+			status, g, e = "mismatch", render(got[i]), render(want[i])
+		}
+
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, status, g, e)
+	}
+
+	_ = w.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// List renders a numbered table of the `got` entries, rendered with `render`, for when there is nothing to compare them to.
+// Rows are numbered from 1, like the ones of Table.
+func List[T any](got []T, render func(T) string) string {
+	var buf strings.Builder
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "#\tgot")
+
+	for i, entry := range got {
+		_, _ = fmt.Fprintf(w, "%d\t%s\n", i+1, render(entry))
+	}
+
+	_ = w.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
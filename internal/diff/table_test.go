@@ -0,0 +1,47 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Table(t *testing.T) {
+	equal := func(a, b int) bool { return a == b }
+
+	for name, tc := range map[string]struct {
+		got, want []int
+		expected  []string
+	}{
+		"mismatch and missing": {
+			got:  []int{1, 2},
+			want: []int{1, 3, 4},
+			expected: []string{
+				"#  status    got  want",
+				"1  ok        1    1",
+				"2  mismatch  2    3",
+				"3  missing   -    4",
+			},
+		},
+		"unexpected": {
+			got:  []int{1},
+			want: nil,
+			expected: []string{
+				"#  status      got  want",
+				"1  unexpected  1    -",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got, expected := Table(tc.got, tc.want, equal, strconv.Itoa), strings.Join(tc.expected, "\n"); got != expected {
+				t.Errorf("unexpected rendering, got:\n%s\nwant:\n%s", got, expected)
+			}
+		})
+	}
+}
+
+func Test_List(t *testing.T) {
+	if got, expected := List([]int{4, 2}, strconv.Itoa), "#  got\n1  4\n2  2"; got != expected {
+		t.Errorf("unexpected rendering, got:\n%s\nwant:\n%s", got, expected)
+	}
+}
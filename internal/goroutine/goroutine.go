@@ -0,0 +1,39 @@
+// Package goroutine inspects the running goroutines, to describe where assertions are stuck.
+package goroutine
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Dump returns the stack traces of all the running goroutines, separated by empty lines.
+func Dump() string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return strings.TrimSpace(string(buf[:n]))
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// Header returns the beginning of the stack header of the current goroutine, like "goroutine 42 [".
+// It identifies the goroutine in dumps, and can be compared with the header of other goroutines.
+func Header() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	header, _, _ := strings.Cut(string(buf), "[")
+	return header + "["
+}
+
+// StackOf returns the stack trace of the goroutine identified by `header` in `dump`, or `dump` if it is not found.
+func StackOf(dump, header string) string {
+	for stack := range strings.SplitSeq(dump, "\n\n") {
+		if strings.HasPrefix(stack, header) {
+			return stack
+		}
+	}
+	return dump
+}
@@ -0,0 +1,41 @@
+package goroutine
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Dump(t *testing.T) {
+	if dump := Dump(); !strings.Contains(dump, "goroutine.Test_Dump(") {
+		t.Errorf("expected dump to contain the current goroutine, got %s", dump)
+	}
+}
+
+func Test_Header(t *testing.T) {
+	header := Header()
+	if !strings.HasPrefix(header, "goroutine ") || !strings.HasSuffix(header, " [") {
+		t.Fatalf("unexpected header %q", header)
+	}
+
+	if again := Header(); again != header {
+		t.Errorf("expected the header of the same goroutine to be stable, got %q and %q", header, again)
+	}
+
+	other := make(chan string)
+	go func() { other <- Header() }()
+
+	if otherHeader := <-other; otherHeader == header {
+		t.Errorf("expected goroutines to have different headers, got %q", header)
+	}
+}
+
+func Test_StackOf(t *testing.T) {
+	stack := StackOf(Dump(), Header())
+	if !strings.HasPrefix(stack, Header()) || !strings.Contains(stack, "goroutine.Test_StackOf(") || strings.Contains(stack, "\n\n") {
+		t.Errorf("expected the stack of the current goroutine only, got %s", stack)
+	}
+
+	if dump := "goroutine 1 [running]:\nmain()"; StackOf(dump, "goroutine 2 [") != dump {
+		t.Error("expected the whole dump when the goroutine is not found")
+	}
+}
@@ -0,0 +1,101 @@
+package message
+
+import (
+	"go/ast"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// CallRenderer describes the result of a call to a function returning a boolean.
+// `args` are the sources of the arguments of the call, preceded by the source of the receiver for methods,
+// and `result` is the result of the assertion the call is part of.
+type CallRenderer func(args []string, result bool) string
+
+//nolint:gochecknoglobals // renderers are registered for the whole test binary
+var (
+	_callRenderers      = make(map[string]CallRenderer)
+	_callRenderersMutex sync.RWMutex
+)
+
+// RegisterCallRenderer registers the renderer describing calls to the function `name` of the package `pkgPath`.
+// Methods are named after their receiver type, like "Type.Method".
+// It returns a function restoring the previously registered renderer, if any.
+func RegisterCallRenderer(pkgPath, name string, render CallRenderer) func() {
+	key := pkgPath + "." + name
+
+	_callRenderersMutex.Lock()
+	defer _callRenderersMutex.Unlock()
+
+	previous, existed := _callRenderers[key]
+	_callRenderers[key] = render
+
+	return func() {
+		_callRenderersMutex.Lock()
+		defer _callRenderersMutex.Unlock()
+
+		if existed {
+			_callRenderers[key] = previous
+		} else {
+			delete(_callRenderers, key)
+		}
+	}
+}
+
+// registeredCallRepr returns the representation of the call built by its registered renderer, if any.
+func registeredCallRepr(pkg *packages.Package, call *ast.CallExpr, result bool) (string, bool) {
+	var (
+		fun  *ast.Ident
+		recv ast.Expr
+	)
+
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		fun = f
+	case *ast.SelectorExpr:
+		fun = f.Sel
+		if selection := pkg.TypesInfo.Selections[f]; selection != nil && selection.Kind() == types.MethodVal {
+			recv = f.X
+		}
+	default:
+		return "", false
+	}
+
+	obj, ok := pkg.TypesInfo.ObjectOf(fun).(*types.Func)
+	if !ok || obj.Pkg() == nil {
+		return "", false
+	}
+
+	name := obj.Name()
+	if recvType := obj.Signature().Recv(); recvType != nil {
+		typ := recvType.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		named, ok := typ.(*types.Named)
+		if !ok {
+			return "", false
+		}
+		name = named.Obj().Name() + "." + name
+	}
+
+	_callRenderersMutex.RLock()
+	render, ok := _callRenderers[obj.Pkg().Path()+"."+name]
+	_callRenderersMutex.RUnlock()
+
+	if !ok {
+		return "", false
+	}
+
+	args := make([]string, 0, len(call.Args)+1)
+	if recv != nil {
+		args = append(args, genericASTExprToString(pkg, recv))
+	}
+	for _, arg := range call.Args {
+		args = append(args, genericASTExprToString(pkg, arg))
+	}
+
+	return render(args, result), true
+}
@@ -0,0 +1,75 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testingUser struct{ name string }
+
+func (u *testingUser) IsAdmin(scope string) bool { return u.name == "root" && scope != "" }
+
+func testingIsValid(name string) bool { return name != "" }
+
+func Test_RegisterCallRenderer(t *testing.T) {
+	const pkgPath = "github.com/krostar/test/internal/message"
+
+	t.Run("function", func(t *testing.T) {
+		name := ""
+
+		restore := RegisterCallRenderer(pkgPath, "testingIsValid", func(args []string, result bool) string {
+			return fmt.Sprintf("%s validity is %t", args[0], result)
+		})
+
+		pkg, expr := getTestingExpr[bool](t, testingIsValid(name))
+		if msg, err := customizeASTExprRepr(pkg, false, expr); err != nil || msg != "name validity is false" {
+			t.Errorf("unexpected message %q: %v", msg, err)
+		}
+
+		restore()
+
+		if msg, err := customizeASTExprRepr(pkg, false, expr); err != nil || msg != "function testingIsValid(name) returned false" {
+			t.Errorf("unexpected message once restored %q: %v", msg, err)
+		}
+	})
+
+	t.Run("method", func(t *testing.T) {
+		bob := &testingUser{name: "bob"}
+
+		restore := RegisterCallRenderer(pkgPath, "testingUser.IsAdmin", func(args []string, result bool) string {
+			if result {
+				return args[0] + " is admin of " + args[1]
+			}
+			return args[0] + " is not admin of " + strings.Join(args[1:], ", ")
+		})
+		defer restore()
+
+		pkg, expr := getTestingExpr[bool](t, bob.IsAdmin("billing"))
+		if msg, err := customizeASTExprRepr(pkg, false, expr); err != nil || msg != `bob is not admin of "billing"` {
+			t.Errorf("unexpected message %q: %v", msg, err)
+		}
+	})
+
+	t.Run("overrides well-known functions", func(t *testing.T) {
+		previous := RegisterCallRenderer("strings", "Contains", func([]string, bool) string { return "first" })
+		restore := RegisterCallRenderer("strings", "Contains", func([]string, bool) string { return "second" })
+
+		pkg, expr := getTestingExpr[bool](t, strings.Contains("foo", "bar"))
+		if msg, err := customizeASTExprRepr(pkg, false, expr); err != nil || msg != "second" {
+			t.Errorf("unexpected message %q: %v", msg, err)
+		}
+
+		restore()
+
+		if msg, err := customizeASTExprRepr(pkg, false, expr); err != nil || msg != "first" {
+			t.Errorf("unexpected message once restored %q: %v", msg, err)
+		}
+
+		previous()
+
+		if msg, err := customizeASTExprRepr(pkg, false, expr); err != nil || msg != `"foo" does not contain "bar"` {
+			t.Errorf("unexpected message once restored %q: %v", msg, err)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package message
+
+import (
+	"encoding/binary"
+	"runtime"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+//nolint:gochecknoglobals // call sites are the same for the whole test binary
+var (
+	_callSitesSeen  sync.Map // program counters of the recorded call sites
+	_callSites      = make(map[callSiteLine]lineCallSites)
+	_callSitesMutex sync.Mutex
+)
+
+// callSiteLine identifies the calls made to a function on a line.
+type callSiteLine struct {
+	file   string
+	line   int
+	callee string
+}
+
+// lineCallSites are the program counters of the calls made to a function on a line, in the order of the machine code.
+type lineCallSites struct {
+	pcs []uintptr
+
+	// complete is true when the calls were found in the machine code of the caller, see callInstructions,
+	// in which case they are all known, even the ones not executed yet.
+	complete bool
+}
+
+// RecordCallSite records the call made from the frame at the provided depth of the call stack of the caller,
+// like runtime.Caller would locate it, to tell apart identical calls made on the same line, see callSite.
+// It is meant to be called by every assertion, whether it passes or not.
+func RecordCallSite(callerStackIndex int) {
+	callSite(callerStackIndex + 1)
+}
+
+// callSite returns the name of the function called from the frame at the provided depth of the call stack of the caller,
+// like runtime.Caller would locate it, along with the position of the call among the calls made to this function
+// on the same line.
+//
+// As the runtime does not report columns, calls are ordered by their program counter, which follows their order
+// in the source. The calls of the line are found in the machine code of the caller when possible, see callInstructions,
+// which does not hold calls the compiler removed, like the ones behind a constant condition.
+// Otherwise, like for inlined functions, only the calls executed so far are known, usually recorded by RecordCallSite,
+// and calls of the line that are not executed before the first message is built may shift the position of the others.
+func callSite(callerStackIndex int) (string, int) {
+	pcs := make([]uintptr, 2)
+	pcs = pcs[:runtime.Callers(callerStackIndex+1, pcs)]
+	if len(pcs) == 0 {
+		return "", 0
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	called, more := frames.Next()
+	if !more {
+		return called.Function, 0
+	}
+	caller, _ := frames.Next()
+
+	key := callSiteLine{file: caller.File, line: caller.Line, callee: called.Function}
+
+	// the call is identified by the program counter of the call instruction,
+	// which is in the body of the called function when it is inlined
+	pc := caller.PC
+	if called.Func == nil {
+		pc = called.PC
+	}
+
+	_, seen := _callSitesSeen.LoadOrStore(pc, struct{}{})
+
+	_callSitesMutex.Lock()
+	defer _callSitesMutex.Unlock()
+
+	sites, ok := _callSites[key]
+	if !ok && called.Func != nil {
+		if calls := callInstructions(caller, called.Entry); slices.Contains(calls, pc) {
+			sites = lineCallSites{pcs: calls, complete: true}
+		}
+	}
+
+	if !seen && !sites.complete {
+		sites.pcs = append(sites.pcs, pc)
+		slices.Sort(sites.pcs)
+	}
+
+	_callSites[key] = sites
+
+	return called.Function, max(slices.Index(sites.pcs, pc), 0)
+}
+
+// callInstructions returns the program counters of the instructions of the function of the caller frame,
+// calling the function starting at `calleeEntry` from the line of the caller frame, in the order of the machine code.
+// Program counters are the ones of the frames of those calls, as reported by runtime.CallersFrames.
+//
+// Only direct calls of the architectures the encoding of is known are found: it returns nil for other architectures.
+func callInstructions(caller runtime.Frame, calleeEntry uintptr) []uintptr {
+	const maxFuncSize = 1 << 20
+
+	// decode returns the size of the call instruction at pc, if it is a direct call to the callee
+	var (
+		decode func(pc uintptr) (int, bool)
+		step   uintptr
+	)
+
+	switch runtime.GOARCH {
+	case "amd64": // CALL rel32: E8 followed by the little-endian offset from the next instruction
+		step = 1
+		decode = func(pc uintptr) (int, bool) {
+			code := codeAt(pc, 5)
+			offset := int32(binary.LittleEndian.Uint32(code[1:])) //nolint:gosec // offsets are signed
+			return 5, code[0] == 0xE8 && pc+5+uintptr(offset) == calleeEntry
+		}
+	case "arm64": // BL imm26: 100101 followed by the signed offset from the instruction, in words
+		step = 4
+		decode = func(pc uintptr) (int, bool) {
+			insn := binary.LittleEndian.Uint32(codeAt(pc, 4))
+			offset := int32(insn<<6) >> 4 //nolint:gosec // offsets are signed
+			return 4, insn>>26 == 0b100101 && pc+uintptr(offset) == calleeEntry
+		}
+	default:
+		return nil
+	}
+
+	fn := runtime.FuncForPC(caller.PC)
+	if fn == nil {
+		return nil
+	}
+
+	var calls []uintptr
+
+	for pc := fn.Entry(); pc < fn.Entry()+maxFuncSize; pc += step {
+		if f := runtime.FuncForPC(pc); f == nil || f.Entry() != fn.Entry() {
+			break
+		}
+
+		size, ok := decode(pc)
+		if !ok {
+			continue
+		}
+
+		// the frame of the call is the one of its return address, which also resolves the functions inlined in the caller
+		returnPC := pc + uintptr(size)
+		frame, _ := runtime.CallersFrames([]uintptr{returnPC}).Next()
+		if frame.Function == caller.Function && frame.File == caller.File && frame.Line == caller.Line {
+			calls = append(calls, frame.PC)
+		}
+	}
+
+	return calls
+}
+
+// codeAt returns the `n` bytes of machine code starting at pc.
+func codeAt(pc uintptr, n int) []byte {
+	return unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&pc))), n)
+}
@@ -0,0 +1,66 @@
+package message
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_callSite(t *testing.T) {
+	t.Run("function", func(t *testing.T) {
+		var occurrences []int
+
+		for range 2 {
+			func() { occurrences = append(occurrences, callSiteOfCaller(), callSiteOfCaller(), callSiteOfCaller()) }()
+		}
+		occurrences = append(occurrences, callSiteOfCaller())
+
+		if expected := []int{0, 1, 2, 0, 1, 2, 0}; !slices.Equal(occurrences, expected) {
+			t.Errorf("expected occurrences %v, got %v", expected, occurrences)
+		}
+	})
+
+	t.Run("conditional call", func(t *testing.T) {
+		var occurrences []int
+
+		for _, first := range []bool{false, true} {
+			_ = (!first || recordCallSiteOfCaller(&occurrences)) && recordCallSiteOfCaller(&occurrences)
+		}
+
+		if expected := []int{1, 0, 1}; !slices.Equal(occurrences, expected) {
+			t.Errorf("expected occurrences %v, got %v", expected, occurrences)
+		}
+	})
+
+	t.Run("inlined function", func(t *testing.T) {
+		var occurrences []int
+
+		record := func() {
+			callee, occurrence := callSite(1)
+			if callee != "github.com/krostar/test/internal/message.Test_callSite.func3.1" {
+				t.Errorf("unexpected callee %s", callee)
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+
+		for range 2 {
+			func() { record(); record() }()
+		}
+
+		if expected := []int{0, 1, 0, 1}; !slices.Equal(occurrences, expected) {
+			t.Errorf("expected occurrences %v, got %v", expected, occurrences)
+		}
+	})
+}
+
+//go:noinline
+func callSiteOfCaller() int {
+	_, occurrence := callSite(1)
+	return occurrence
+}
+
+//go:noinline
+func recordCallSiteOfCaller(occurrences *[]int) bool {
+	_, occurrence := callSite(1)
+	*occurrences = append(*occurrences, occurrence)
+	return true
+}
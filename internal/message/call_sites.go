@@ -0,0 +1,134 @@
+package message
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/krostar/test/internal/code"
+)
+
+// CallSite describes a call to an assertion function found in the source code.
+type CallSite struct {
+	File           string `json:"file"`
+	Line           int    `json:"line"`
+	Column         int    `json:"column"`
+	Function       string `json:"function"`        // name of the assertion function, like test.Assert
+	Expression     string `json:"expression"`      // source of the asserted expression
+	FailureMessage string `json:"failure_message"` // message that would be logged if the assertion failed
+}
+
+// CallSites returns every call to the provided assertion functions of the package located at pkgDir, test files included.
+// `funcs` are the full names of the assertion functions, like github.com/krostar/test.Assert.
+// Call sites are sorted by position.
+func CallSites(pkgDir string, funcs ...string) ([]CallSite, error) {
+	absDir, err := filepath.Abs(pkgDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get absolute path of %s: %v", pkgDir, err)
+	}
+
+	pkgPathToPkg, err := code.GetPackageAST(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get package AST: %v", err)
+	}
+
+	var (
+		callSites []CallSite
+		seen      = make(map[string]struct{}) // the same files can be part of multiple packages, like the test variant
+	)
+
+	for _, pkg := range pkgPathToPkg {
+		for _, file := range pkg.Syntax {
+			if filepath.Dir(pkg.Fset.Position(file.Pos()).Filename) != absDir {
+				continue
+			}
+
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				function, ok := calledFunctionName(pkg, call, funcs)
+				if !ok {
+					return true
+				}
+
+				position := pkg.Fset.Position(call.Pos())
+
+				key := position.String()
+				if _, exists := seen[key]; exists {
+					return true
+				}
+				seen[key] = struct{}{}
+
+				callSite := CallSite{
+					File:     position.Filename,
+					Line:     position.Line,
+					Column:   position.Column,
+					Function: function,
+				}
+
+				if arg, err := assertedArg(call); err == nil {
+					callSite.Expression = genericASTExprToString(pkg, arg)
+					if callSite.FailureMessage, err = customizeASTExprRepr(pkg, false, arg); err != nil {
+						callSite.FailureMessage = callSite.Expression
+					}
+				}
+
+				callSites = append(callSites, callSite)
+
+				return true
+			})
+		}
+	}
+
+	slices.SortFunc(callSites, func(a, b CallSite) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		if c := a.Line - b.Line; c != 0 {
+			return c
+		}
+		return a.Column - b.Column
+	})
+
+	return callSites, nil
+}
+
+// calledFunctionName returns the short name, like test.Assert, of the function called, if it is one of funcs.
+func calledFunctionName(pkg *packages.Package, call *ast.CallExpr, funcs []string) (string, bool) {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return "", false
+	}
+
+	fn, ok := pkg.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || !slices.Contains(funcs, fn.Pkg().Path()+"."+fn.Name()) {
+		return "", false
+	}
+
+	return fn.Pkg().Name() + "." + fn.Name(), true
+}
+
+// assertedArg returns the argument of an assertion call that holds the result of the assertion.
+func assertedArg(call *ast.CallExpr) (ast.Expr, error) {
+	switch l := len(call.Args); {
+	case l == 1: // interpret as custom checker like Assert(checker(t, ...))
+		return call.Args[0], nil
+	case l >= 2: // interpret as regular call like Assert(t, bool, msg...)
+		return call.Args[1], nil
+	default:
+		return nil, fmt.Errorf("unexpected call expr arguments number %d", l)
+	}
+}
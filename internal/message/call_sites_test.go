@@ -0,0 +1,36 @@
+package message
+
+import (
+	"path/filepath"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func Test_CallSites(t *testing.T) {
+	file, err := filepath.Abs("testdata/callsites/callsites_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callSites, err := CallSites("testdata/callsites",
+		"github.com/krostar/test.Assert",
+		"github.com/krostar/test.Require",
+		"github.com/krostar/test.Warn",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := gocmp.Diff([]CallSite{
+		{File: file, Line: 13, Column: 2, Function: "test.Assert", Expression: "got == want", FailureMessage: "got is not equal to want"},
+		{File: file, Line: 16, Column: 2, Function: "test.Require", Expression: "err == nil", FailureMessage: "err is not nil"},
+		{File: file, Line: 18, Column: 2, Function: "test.Warn", Expression: "check.Compare(t, got, want)", FailureMessage: "function check.Compare(t, got, want) returned false"},
+	}, callSites); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+
+	if _, err := CallSites("testdata/notexisting"); err == nil {
+		t.Error("expected an error for a not existing package")
+	}
+}
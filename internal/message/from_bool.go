@@ -12,6 +12,8 @@ import (
 	"go/types"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 
@@ -27,37 +29,145 @@ import (
 // It returns a formatted message string and an error if one occurred during the process.
 // The message string will be tailored based on the expression used in the assertion.
 func FromBool(callerStackIndex int, result bool) (string, error) {
+	return FromBoolArg(callerStackIndex+1, -1, result)
+}
+
+// FromBoolArg behaves like FromBool, but generates the message of the argument at position `argIndex` (0-based)
+// of the caller's call, which is useful for assertions taking multiple conditions.
+// A negative `argIndex` designates the asserted argument, like for FromBool.
+func FromBoolArg(callerStackIndex, argIndex int, result bool) (string, error) {
+	return FromBoolArgWithValues(callerStackIndex+1, argIndex, result, nil)
+}
+
+// FromBoolArgWithValues behaves like FromBoolArg, using the runtime values of some of the expressions of the argument,
+// indexed by their source, to be more precise, like "a has length 3, expected 2" for len(a) == 2 if len(a) is known.
+func FromBoolArgWithValues(callerStackIndex, argIndex int, result bool, values map[string]any) (string, error) {
+	pkg, expr, arg, err := callerArg(callerStackIndex+1, argIndex)
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := customizeASTExprReprWithValues(pkg, values, result, arg)
+	if err != nil {
+		return genericASTExprToString(pkg, expr), fmt.Errorf("unable to get arg repr: %v", err)
+	}
+
+	return msg, nil
+}
+
+// Expression returns the source of the expression asserted by the caller,
+// like `got == want` for `Assert(t, got == want)`.
+//
+// `callerStackIndex` specifies the depth in the call stack to retrieve the caller information, like for FromBool.
+func Expression(callerStackIndex int) (string, error) {
+	return ExpressionArg(callerStackIndex+1, -1)
+}
+
+// ExpressionArg behaves like Expression, but returns the source of the argument at position `argIndex` (0-based)
+// of the caller's call. A negative `argIndex` designates the asserted argument, like for Expression.
+func ExpressionArg(callerStackIndex, argIndex int) (string, error) {
+	pkg, _, arg, err := callerArg(callerStackIndex+1, argIndex)
+	if err != nil {
+		return "", err
+	}
+
+	return genericASTExprToString(pkg, arg), nil
+}
+
+// FromCall generates the message of the call found at the caller location, which is a call to an assertion helper.
+// If exactly one argument of the call is a boolean, like `ok` for `assertNoDiff(t, ok)`, it is described like
+// FromBoolArg would; otherwise, the call itself is described, like "assertAdult(t, bob) failed".
+func FromCall(callerStackIndex int, result bool) (string, error) {
+	pkg, expr, _, err := callerArg(callerStackIndex+1, 0)
+	if err != nil {
+		return "", err
+	}
+
+	var boolArgs []ast.Expr
+	for _, arg := range expr.Args {
+		if isExprBool(pkg, arg) {
+			boolArgs = append(boolArgs, arg)
+		}
+	}
+
+	if len(boolArgs) == 1 {
+		msg, err := customizeASTExprRepr(pkg, result, boolArgs[0])
+		if err != nil {
+			return genericASTExprToString(pkg, expr), fmt.Errorf("unable to get arg repr: %v", err)
+		}
+		return msg, nil
+	}
+
+	if result {
+		return genericASTExprToString(pkg, expr) + " passed", nil
+	}
+	return genericASTExprToString(pkg, expr) + " failed", nil
+}
+
+// CallExpression returns the source of the call found at the caller location, like `assertAdult(t, bob)`.
+func CallExpression(callerStackIndex int) (string, error) {
+	pkg, expr, _, err := callerArg(callerStackIndex+1, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return genericASTExprToString(pkg, expr), nil
+}
+
+// CallArgsExpressions returns the source of every argument of the call passed as argument at position `argIndex` (0-based)
+// of the call found at the caller location, like `a` and `b` for `test.Values(a, b)`.
+func CallArgsExpressions(callerStackIndex, argIndex int) ([]string, error) {
+	pkg, _, arg, err := callerArg(callerStackIndex+1, argIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	call, ok := ast.Unparen(arg).(*ast.CallExpr)
+	if !ok {
+		return nil, fmt.Errorf("argument at index %d is not a call expression", argIndex)
+	}
+
+	exprs := make([]string, len(call.Args))
+	for i, callArg := range call.Args {
+		exprs[i] = genericASTExprToString(pkg, callArg)
+	}
+
+	return exprs, nil
+}
+
+// callerArg returns the call found at the caller location, along with its package and its argument at position `argIndex`,
+// or its asserted argument if `argIndex` is negative.
+func callerArg(callerStackIndex, argIndex int) (*packages.Package, *ast.CallExpr, ast.Expr, error) {
 	_, callerFile, callerLine, ok := runtime.Caller(callerStackIndex + 1)
 	if !ok {
-		return "", errors.New("no caller information available")
+		return nil, nil, nil, errors.New("no caller information available")
 	}
 
 	pkgPathToPkg, err := code.GetPackageAST(filepath.Clean(filepath.Dir(callerFile)))
 	if err != nil {
-		return "", fmt.Errorf("unable to get package AST: %v", err)
+		return nil, nil, nil, fmt.Errorf("unable to get package AST: %v", err)
 	}
 
-	expr, _, pkg, err := code.GetCallerCallExpr(pkgPathToPkg, callerFile, callerLine)
+	callee, occurrence := callSite(callerStackIndex + 1)
+
+	expr, _, pkg, err := code.GetCallerCallExpr(pkgPathToPkg, callerFile, callerLine, callee, occurrence)
 	if err != nil {
-		return "", fmt.Errorf("unable to get call expr from caller: %v", err)
+		return nil, nil, nil, fmt.Errorf("unable to get call expr from caller: %v", err)
 	}
 
-	var arg ast.Expr
-	switch l := len(expr.Args); {
-	case l == 1: // interpret as custom checker like Assert(checker(t, ...))
-		arg = expr.Args[0]
-	case l >= 2: // interpret as regular call like Assert(t, bool, msg...)
-		arg = expr.Args[1]
-	default:
-		return "", fmt.Errorf("unexpected call expr arguments number %d", l)
+	if argIndex < 0 {
+		arg, err := assertedArg(expr)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return pkg, expr, arg, nil
 	}
 
-	msg, err := customizeASTExprRepr(pkg, result, arg)
-	if err != nil {
-		return genericASTExprToString(pkg, expr), fmt.Errorf("unable to get arg repr: %v", err)
+	if argIndex >= len(expr.Args) {
+		return nil, nil, nil, fmt.Errorf("call expr has %d arguments, no argument at index %d", len(expr.Args), argIndex)
 	}
 
-	return msg, nil
+	return pkg, expr, expr.Args[argIndex], nil
 }
 
 // customizeASTExprRepr generates a representation of an AST expression,
@@ -69,23 +179,37 @@ func FromBool(callerStackIndex int, result bool) (string, error) {
 //
 // It returns the formatted string representation of the expression and
 // an error if any occurred during the processing.
+func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (string, error) {
+	return customizeASTExprReprWithValues(pkg, nil, result, expr)
+}
+
+// customizeASTExprReprWithValues behaves like customizeASTExprRepr, using the known runtime values of expressions,
+// indexed by their source, see FromBoolArgWithValues.
 //
 //nolint:goconst // some hardcoded values could be consts, but for readability reasons it seems better to keep them raw
-func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (string, error) {
+func customizeASTExprReprWithValues(pkg *packages.Package, values map[string]any, result bool, expr ast.Expr) (string, error) {
 	typ := pkg.TypesInfo.TypeOf(expr)
 
 	switch expr := expr.(type) {
 	case *ast.BinaryExpr:
 		x, y := genericASTExprToString(pkg, expr.X), genericASTExprToString(pkg, expr.Y)
 
+		if repr, ok := lenCapComparisonRepr(pkg, values, expr, result); ok {
+			return repr, nil
+		}
+
+		if repr, ok := elapsedComparisonRepr(pkg, expr, result); ok {
+			return repr, nil
+		}
+
 		switch {
 		case expr.Op == token.LAND || expr.Op == token.LOR:
 			var err error
-			if x, err = customizeASTExprRepr(pkg, result, expr.X); err != nil {
+			if x, err = customizeASTExprReprWithValues(pkg, values, result, expr.X); err != nil {
 				return "", fmt.Errorf("unable to get LAND/LOR %T.X custom repr: %v", expr, err)
 			}
 
-			if y, err = customizeASTExprRepr(pkg, result, expr.Y); err != nil {
+			if y, err = customizeASTExprReprWithValues(pkg, values, result, expr.Y); err != nil {
 				return "", fmt.Errorf("unable to get LAND/LOR %T.Y custom repr: %v", expr, err)
 			}
 
@@ -115,6 +239,10 @@ func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (st
 			yIsNil := isExprNil(pkg, expr.Y)
 			yIsBool, yBoolValue := isExprBool(pkg, expr.Y), getExprBoolValue(pkg, expr.Y)
 
+			if repr, ok := slicesIndexNotFoundRepr(pkg, expr.X, expr.Y, resultIsEqual); ok {
+				return repr, nil
+			}
+
 			switch {
 			case xIsFunc && xIsFuncRetuningError && yIsNil && resultIsEqual:
 				return x + " returned no error", nil
@@ -157,6 +285,10 @@ func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (st
 		}
 
 	case *ast.CallExpr:
+		if repr, ok := registeredCallRepr(pkg, expr, result); ok {
+			return repr, nil
+		}
+
 		var p, t string
 		switch fun := expr.Fun.(type) {
 		case *ast.FuncLit:
@@ -196,6 +328,25 @@ func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (st
 				return fmt.Sprintf("%s can be defined as %s", genericASTExprToString(pkg, expr.Args[0]), pkg.TypesInfo.TypeOf(expr.Args[1])), nil
 			}
 			return fmt.Sprintf("%s cannot be defined as %s", genericASTExprToString(pkg, expr.Args[0]), pkg.TypesInfo.TypeOf(expr.Args[1])), nil
+		case p == "slices" && t == "ContainsFunc":
+			return slicesContainsFuncRepr(pkg, expr.Args[0], expr.Args[1], result), nil
+		case p == "slices" && t == "IsSorted":
+			if result {
+				return genericASTExprToString(pkg, expr.Args[0]) + " is sorted", nil
+			}
+			return genericASTExprToString(pkg, expr.Args[0]) + " is not sorted", nil
+		case p == "slices" && t == "IsSortedFunc":
+			if result {
+				return fmt.Sprintf("%s is sorted according to %s", genericASTExprToString(pkg, expr.Args[0]), genericASTExprToString(pkg, expr.Args[1])), nil
+			}
+			return fmt.Sprintf("%s is not sorted according to %s", genericASTExprToString(pkg, expr.Args[0]), genericASTExprToString(pkg, expr.Args[1])), nil
+		case p == "time" && (t == "Before" || t == "After" || t == "Equal" || t == "IsZero"):
+			if repr, ok := timeMethodRepr(pkg, expr, t, result); ok {
+				return repr, nil
+			}
+			return fmt.Sprintf("function %s returned %t", genericASTExprToString(pkg, expr), result), nil
+		case p == "regexp" && (t == "MatchString" || t == "Match"):
+			return regexpMatchRepr(pkg, expr, result), nil
 		default:
 			return fmt.Sprintf("function %s returned %t", genericASTExprToString(pkg, expr), result), nil
 		}
@@ -204,6 +355,12 @@ func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (st
 		obj := pkg.TypesInfo.ObjectOf(expr)
 		switch obj := obj.(type) {
 		case *types.Var:
+			if typeAssert, ok := commaOkTypeAssertion(pkg, obj); ok {
+				return typeAssertionRepr(pkg, typeAssert, result), nil
+			}
+			if match, ok := regexpMatchAssignment(pkg, obj); ok {
+				return regexpMatchRepr(pkg, match, result), nil
+			}
 			return fmt.Sprintf("var %s is %t", obj.Name(), result), nil
 		case *types.Const:
 			if typ, ok := typ.(*types.Basic); ok && (typ.Kind() == types.Bool || typ.Kind() == types.UntypedBool) && obj.Parent() == types.Universe {
@@ -215,22 +372,22 @@ func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (st
 		}
 
 	case *ast.ParenExpr:
-		return customizeASTExprRepr(pkg, result, expr.X)
+		return customizeASTExprReprWithValues(pkg, values, result, expr.X)
 
-	case *ast.SelectorExpr:
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.TypeAssertExpr:
 		return fmt.Sprintf("%s is %t", genericASTExprToString(pkg, expr), result), nil
 
 	case *ast.UnaryExpr:
 		switch op := expr.Op; op {
 		case token.NOT:
 			switch expr.X.(type) {
-			case *ast.CallExpr, *ast.Ident, *ast.ParenExpr, *ast.UnaryExpr:
-				return customizeASTExprRepr(pkg, !result, expr.X)
+			case *ast.CallExpr, *ast.Ident, *ast.IndexExpr, *ast.ParenExpr, *ast.SelectorExpr, *ast.UnaryExpr:
+				return customizeASTExprReprWithValues(pkg, values, !result, expr.X)
 			default:
 				return "", fmt.Errorf("unhandled unary expr operator %T", expr.X)
 			}
 		case token.ARROW:
-			return customizeASTExprRepr(pkg, result, expr.X)
+			return customizeASTExprReprWithValues(pkg, values, result, expr.X)
 		default:
 			return "", fmt.Errorf("unhandled unary operator %T", expr.Op)
 		}
@@ -240,6 +397,466 @@ func customizeASTExprRepr(pkg *packages.Package, result bool, expr ast.Expr) (st
 	}
 }
 
+// timeMethodRepr returns the representation of calls to the comparison methods of time.Time,
+// like "t1 is not before t2" for t1.Before(t2), or "t1 is zero" for t1.IsZero().
+func timeMethodRepr(pkg *packages.Package, call *ast.CallExpr, method string, result bool) (string, bool) {
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || pkg.TypesInfo.Selections[fun] == nil {
+		return "", false
+	}
+
+	recv := genericASTExprToString(pkg, fun.X)
+
+	if method == "IsZero" {
+		if result {
+			return recv + " is zero", true
+		}
+		return recv + " is not zero", true
+	}
+
+	if len(call.Args) != 1 {
+		return "", false
+	}
+
+	relation := map[string]string{"Before": "before", "After": "after", "Equal": "equal to"}[method]
+	if result {
+		return fmt.Sprintf("%s is %s %s", recv, relation, genericASTExprToString(pkg, call.Args[0])), true
+	}
+	return fmt.Sprintf("%s is not %s %s", recv, relation, genericASTExprToString(pkg, call.Args[0])), true
+}
+
+// elapsedComparisonRepr returns the representation of comparisons of durations measured with time.Since or time.Until,
+// like "time since start exceeds timeout" for time.Since(start) < timeout.
+// It returns false if the left operand of the comparison is not a call to one of them, or if the comparison is an equality.
+func elapsedComparisonRepr(pkg *packages.Package, expr *ast.BinaryExpr, result bool) (string, bool) {
+	call, ok := ast.Unparen(expr.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+
+	var fun *ast.Ident
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		fun = f
+	case *ast.SelectorExpr:
+		fun = f.Sel
+	default:
+		return "", false
+	}
+
+	p, t, err := getIdentSelector(pkg, fun)
+	if err != nil || p != "time" || (t != "Since" && t != "Until") {
+		return "", false
+	}
+
+	op := expr.Op
+	if !result {
+		negated := map[token.Token]token.Token{token.GTR: token.LEQ, token.LEQ: token.GTR, token.LSS: token.GEQ, token.GEQ: token.LSS}
+		if op, ok = negated[op]; !ok {
+			return "", false
+		}
+	}
+
+	subject := fmt.Sprintf("time %s %s", strings.ToLower(t), genericASTExprToString(pkg, call.Args[0]))
+	y := genericASTExprToString(pkg, expr.Y)
+
+	// durations are hardly ever exactly equal, so reaching a duration is phrased as exceeding it
+	switch op { //nolint:exhaustive // other operators are not handled
+	case token.GTR, token.GEQ:
+		return subject + " exceeds " + y, true
+	case token.LEQ:
+		return subject + " does not exceed " + y, true
+	case token.LSS:
+		return subject + " is less than " + y, true
+	default:
+		return "", false
+	}
+}
+
+// lenCapComparisonRepr returns the representation of comparisons of the length or capacity of a value,
+// like "a does not have length 2" for len(a) == 2, or "a is empty" for len(a) == 0.
+// If the comparison failed and the length is one of the known `values`, it is part of the representation,
+// like "a has length 3, expected 2".
+// It returns false if the left operand of the comparison is not a call to len or cap.
+func lenCapComparisonRepr(pkg *packages.Package, values map[string]any, expr *ast.BinaryExpr, result bool) (string, bool) {
+	x, noun, ok := lenCapArg(pkg, expr.X)
+	if !ok {
+		return "", false
+	}
+
+	if !result {
+		if repr, ok := knownLenCapComparisonRepr(pkg, values, expr, x, noun); ok {
+			return repr, true
+		}
+	}
+
+	op := expr.Op
+	if !result {
+		negated := map[token.Token]token.Token{
+			token.EQL: token.NEQ, token.NEQ: token.EQL,
+			token.GTR: token.LEQ, token.LEQ: token.GTR,
+			token.LSS: token.GEQ, token.GEQ: token.LSS,
+		}
+		if op, ok = negated[op]; !ok {
+			return "", false
+		}
+	}
+
+	var (
+		subject = genericASTExprToString(pkg, x)
+		y       = genericASTExprToString(pkg, expr.Y)
+		isZero  = false
+	)
+
+	if yArg, yNoun, ok := lenCapArg(pkg, expr.Y); ok && yNoun == noun && (op == token.EQL || op == token.NEQ) {
+		if op == token.EQL {
+			return fmt.Sprintf("%s has the same %s as %s", subject, noun, genericASTExprToString(pkg, yArg)), true
+		}
+		return fmt.Sprintf("%s does not have the same %s as %s", subject, noun, genericASTExprToString(pkg, yArg)), true
+	}
+
+	if tv, ok := pkg.TypesInfo.Types[expr.Y]; ok && tv.Value != nil {
+		isZero = tv.Value.String() == "0"
+	}
+
+	switch {
+	case op == token.EQL && isZero && noun == "length":
+		return subject + " is empty", true
+	case op == token.NEQ && isZero && noun == "length":
+		return subject + " is not empty", true
+	case op == token.EQL:
+		return fmt.Sprintf("%s has %s %s", subject, noun, y), true
+	case op == token.NEQ:
+		return fmt.Sprintf("%s does not have %s %s", subject, noun, y), true
+	case op == token.GTR:
+		return fmt.Sprintf("%s has a %s greater than %s", subject, noun, y), true
+	case op == token.GEQ:
+		return fmt.Sprintf("%s has a %s greater than or equal to %s", subject, noun, y), true
+	case op == token.LSS:
+		return fmt.Sprintf("%s has a %s less than %s", subject, noun, y), true
+	case op == token.LEQ:
+		return fmt.Sprintf("%s has a %s less than or equal to %s", subject, noun, y), true
+	default:
+		return "", false
+	}
+}
+
+// knownLenCapComparisonRepr returns the representation of the failed comparison of the length or capacity `noun`
+// of `x` with a constant, when the length or capacity is one of the known `values`, like "a has length 3, expected 2".
+// It returns false if the value is not known, or does not contradict the comparison.
+func knownLenCapComparisonRepr(pkg *packages.Package, values map[string]any, expr *ast.BinaryExpr, x ast.Expr, noun string) (string, bool) {
+	n, ok := values[genericASTExprToString(pkg, expr.X)].(int)
+	if !ok {
+		return "", false
+	}
+
+	tv, ok := pkg.TypesInfo.Types[expr.Y]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int || constant.Compare(constant.MakeInt64(int64(n)), expr.Op, tv.Value) {
+		return "", false
+	}
+
+	y := genericASTExprToString(pkg, expr.Y)
+
+	var expected string
+	switch expr.Op { //nolint:exhaustive // other operators are not comparisons
+	case token.EQL:
+		expected = y
+	case token.NEQ:
+		expected = fmt.Sprintf("a %s other than %s", noun, y)
+	case token.GTR:
+		expected = fmt.Sprintf("a %s greater than %s", noun, y)
+	case token.GEQ:
+		expected = fmt.Sprintf("a %s greater than or equal to %s", noun, y)
+	case token.LSS:
+		expected = fmt.Sprintf("a %s less than %s", noun, y)
+	case token.LEQ:
+		expected = fmt.Sprintf("a %s less than or equal to %s", noun, y)
+	default:
+		return "", false
+	}
+
+	return fmt.Sprintf("%s has %s %d, expected %s", genericASTExprToString(pkg, x), noun, n, expected), true
+}
+
+// lenCapArg returns the argument of a call to the len or cap builtin functions,
+// along with the name of what is measured, "length" or "capacity".
+func lenCapArg(pkg *packages.Package, expr ast.Expr) (ast.Expr, string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, "", false
+	}
+
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return nil, "", false
+	}
+
+	if _, ok := pkg.TypesInfo.ObjectOf(ident).(*types.Builtin); !ok {
+		return nil, "", false
+	}
+
+	switch ident.Name {
+	case "len":
+		return call.Args[0], "length", true
+	case "cap":
+		return call.Args[0], "capacity", true
+	default:
+		return nil, "", false
+	}
+}
+
+// typeAssertionRepr returns the representation of the success of the type assertion,
+// like "v does not implement io.Reader" for interfaces, or "v is not of type *os.File".
+func typeAssertionRepr(pkg *packages.Package, typeAssert *ast.TypeAssertExpr, result bool) string {
+	x, typ := genericASTExprToString(pkg, typeAssert.X), genericASTExprToString(pkg, typeAssert.Type)
+
+	if t := pkg.TypesInfo.TypeOf(typeAssert.Type); t != nil && types.IsInterface(t) {
+		if result {
+			return x + " implements " + typ
+		}
+		return x + " does not implement " + typ
+	}
+
+	if result {
+		return x + " is of type " + typ
+	}
+	return x + " is not of type " + typ
+}
+
+// commaOkTypeAssertion returns the type assertion whose success is stored in the variable,
+// like `v.(io.Reader)` for `_, ok := v.(io.Reader)`, if it is the only assignment of the variable.
+func commaOkTypeAssertion(pkg *packages.Package, obj *types.Var) (*ast.TypeAssertExpr, bool) {
+	rhs, position, ok := pairAssignment(pkg, obj)
+	if !ok || position != 1 {
+		return nil, false
+	}
+
+	typeAssert, ok := ast.Unparen(rhs).(*ast.TypeAssertExpr)
+	return typeAssert, ok
+}
+
+// regexpMatchAssignment returns the call to regexp.MatchString or regexp.Match whose result is stored in the variable,
+// like `regexp.MatchString(pattern, s)` for `ok, _ := regexp.MatchString(pattern, s)`,
+// if it is the only assignment of the variable. As these functions also return an error,
+// their result cannot be asserted directly.
+func regexpMatchAssignment(pkg *packages.Package, obj *types.Var) (*ast.CallExpr, bool) {
+	rhs, position, ok := pairAssignment(pkg, obj)
+	if !ok || position != 0 {
+		return nil, false
+	}
+
+	call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return nil, false
+	}
+
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || pkg.TypesInfo.Selections[fun] != nil { // methods of regexp.Regexp return a single value
+		return nil, false
+	}
+
+	p, t, err := getIdentSelector(pkg, fun.Sel)
+	return call, err == nil && p == "regexp" && (t == "MatchString" || t == "Match")
+}
+
+// pairAssignment returns the expression assigned to a pair of variables the variable is part of,
+// like `v.(io.Reader)` for `_, ok := v.(io.Reader)`, along with the position of the variable in the pair,
+// if it is the only assignment of the variable.
+func pairAssignment(pkg *packages.Package, obj *types.Var) (ast.Expr, int, bool) {
+	var (
+		assigned    ast.Expr
+		position    int
+		assignments int
+	)
+
+	isObj := func(expr ast.Expr) bool {
+		ident, ok := expr.(*ast.Ident)
+		return ok && pkg.TypesInfo.ObjectOf(ident) == obj
+	}
+
+	for _, file := range pkg.Syntax {
+		if obj.Pos() < file.Pos() || obj.Pos() > file.End() {
+			continue
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			var lhs, rhs []ast.Expr
+
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				lhs, rhs = node.Lhs, node.Rhs
+			case *ast.ValueSpec:
+				if len(node.Values) == 0 { // declaration without assignment
+					return true
+				}
+				for _, name := range node.Names {
+					lhs = append(lhs, name)
+				}
+				rhs = node.Values
+			default:
+				return true
+			}
+
+			for i, expr := range lhs {
+				if !isObj(expr) {
+					continue
+				}
+
+				assignments++
+				if len(lhs) == 2 && len(rhs) == 1 {
+					assigned, position = rhs[0], i
+				}
+			}
+
+			return true
+		})
+	}
+
+	return assigned, position, assignments == 1 && assigned != nil
+}
+
+// slicesContainsFuncRepr returns the representation of slices.ContainsFunc(x, f), or its equivalents.
+func slicesContainsFuncRepr(pkg *packages.Package, x, f ast.Expr, result bool) string {
+	if result {
+		return fmt.Sprintf("an element of %s satisfies %s", genericASTExprToString(pkg, x), genericASTExprToString(pkg, f))
+	}
+	return fmt.Sprintf("no element of %s satisfies %s", genericASTExprToString(pkg, x), genericASTExprToString(pkg, f))
+}
+
+// slicesIndexNotFoundRepr returns the representation of comparisons like slices.Index(x, v) == -1
+// or slices.IndexFunc(x, f) != -1, which are equivalent to Contains and ContainsFunc calls.
+// `notFound` is whether the index was found to be -1.
+// It returns false if the comparison is not one of them.
+func slicesIndexNotFoundRepr(pkg *packages.Package, x, y ast.Expr, notFound bool) (string, bool) {
+	call, ok := x.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return "", false
+	}
+
+	if tv, ok := pkg.TypesInfo.Types[y]; !ok || tv.Value == nil || tv.Value.String() != "-1" {
+		return "", false
+	}
+
+	var fun *ast.Ident
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		fun = f
+	case *ast.SelectorExpr:
+		fun = f.Sel
+	default:
+		return "", false
+	}
+
+	switch p, t, err := getIdentSelector(pkg, fun); {
+	case err != nil || p != "slices":
+		return "", false
+	case t == "Index" && notFound:
+		return fmt.Sprintf("%s does not contain %s", genericASTExprToString(pkg, call.Args[0]), genericASTExprToString(pkg, call.Args[1])), true
+	case t == "Index":
+		return fmt.Sprintf("%s contains %s", genericASTExprToString(pkg, call.Args[0]), genericASTExprToString(pkg, call.Args[1])), true
+	case t == "IndexFunc":
+		return slicesContainsFuncRepr(pkg, call.Args[0], call.Args[1], !notFound), true
+	default:
+		return "", false
+	}
+}
+
+// regexpMatchRepr describes the result of a regexp matching call, see regexpMatchOperands.
+func regexpMatchRepr(pkg *packages.Package, call *ast.CallExpr, result bool) string {
+	subject, pattern := regexpMatchOperands(pkg, call)
+	if result {
+		return fmt.Sprintf("%s matches pattern %s", subject, pattern)
+	}
+	return fmt.Sprintf("%s does not match pattern %s", subject, pattern)
+}
+
+// regexpMatchOperands returns the representations of the matched value and of the pattern of a regexp matching call,
+// either regexp.MatchString(pattern, s) or re.MatchString(s), and their Match equivalents.
+// For methods, the pattern is the source of the regular expression when the receiver is a package-level variable
+// initialized from a constant pattern, like regexp.MustCompile(`^\d+$`), or the receiver itself otherwise.
+func regexpMatchOperands(pkg *packages.Package, call *ast.CallExpr) (string, string) {
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || pkg.TypesInfo.Selections[fun] == nil {
+		return genericASTExprToString(pkg, call.Args[1]), regexpPatternRepr(pkg, call.Args[0])
+	}
+
+	pattern := genericASTExprToString(pkg, fun.X)
+	if source, ok := regexpVarSource(pkg, fun.X); ok {
+		pattern = regexpPatternRepr(pkg, source)
+	}
+
+	return genericASTExprToString(pkg, call.Args[0]), pattern
+}
+
+// regexpPatternRepr returns the representation of a pattern: its value if it is a named constant, or its source.
+func regexpPatternRepr(pkg *packages.Package, expr ast.Expr) string {
+	if _, isLit := expr.(*ast.BasicLit); !isLit {
+		if tv, ok := pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return strconv.Quote(constant.StringVal(tv.Value))
+		}
+	}
+
+	return genericASTExprToString(pkg, expr)
+}
+
+// regexpVarSource returns the pattern a package-level regular expression variable is compiled from,
+// if it is initialized by one of the regexp.Compile functions, with a constant pattern.
+func regexpVarSource(pkg *packages.Package, expr ast.Expr) (ast.Expr, bool) {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	obj, ok := pkg.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return nil, false
+	}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec, ok := spec.(*ast.ValueSpec)
+				if !ok || len(spec.Names) != len(spec.Values) {
+					continue
+				}
+
+				for i, name := range spec.Names {
+					if pkg.TypesInfo.Defs[name] != obj {
+						continue
+					}
+
+					compile, ok := spec.Values[i].(*ast.CallExpr)
+					if !ok || len(compile.Args) != 1 {
+						return nil, false
+					}
+
+					sel, ok := compile.Fun.(*ast.SelectorExpr)
+					if !ok {
+						return nil, false
+					}
+
+					if p, t, err := getIdentSelector(pkg, sel.Sel); err != nil || p != "regexp" || !strings.Contains(t, "Compile") {
+						return nil, false
+					}
+
+					if tv, ok := pkg.TypesInfo.Types[compile.Args[0]]; !ok || tv.Value == nil {
+						return nil, false
+					}
+
+					return compile.Args[0], true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
 func genericASTExprToString(pkg *packages.Package, expr ast.Expr) string {
 	var buf bytes.Buffer
 	if err := format.Node(&buf, pkg.Fset, expr); err != nil {
@@ -324,6 +941,10 @@ func getIdentSelector(pkg *packages.Package, expr *ast.Ident) (string, string, e
 		return "", "", errors.New("ident object is nil")
 	}
 
+	if obj.Pkg() == nil { // builtin functions, like min, are in no package
+		return "", obj.Name(), nil
+	}
+
 	return obj.Pkg().Path(), obj.Name(), nil
 }
 
@@ -5,20 +5,25 @@ import (
 	"context"
 	"errors"
 	"go/ast"
+	"io"
 	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/tools/go/packages"
 
 	"github.com/krostar/test/internal/code"
 )
 
+var _testingDigitsRegexp = regexp.MustCompile(`^\d+$`)
+
 func TestMain(m *testing.M) {
 	code.InitPackageASTCache(".")
 	m.Run()
@@ -43,6 +48,14 @@ func Test_FromBool(t *testing.T) {
 			},
 			expectedError: "no caller information available",
 		},
+		"arg": {
+			getResult: func() (string, error) {
+				var err error
+				fromSecondArg := func(_, _ bool) (string, error) { return FromBoolArg(1, 1, false) }
+				return fromSecondArg(true, err != nil)
+			},
+			expectedMessage: "err is nil",
+		},
 	}
 
 	for name, tt := range tests {
@@ -61,6 +74,43 @@ func Test_FromBool(t *testing.T) {
 	}
 }
 
+func Test_Expression(t *testing.T) {
+	assert := func(_ any, _ bool) (string, error) { return Expression(1) }
+	got, want := 1, 2
+
+	if expr, err := assert(t, got == want); err != nil || expr != "got == want" {
+		t.Errorf("unexpected expression %q: %v", expr, err)
+	}
+
+	assertAll := func(_ any, _ ...bool) (string, error) { return ExpressionArg(1, 2) }
+
+	if expr, err := assertAll(t, got == want, got < want); err != nil || expr != "got < want" {
+		t.Errorf("unexpected expression %q: %v", expr, err)
+	}
+
+	if expr, err := assertAll(t, got == want); err == nil || !strings.Contains(err.Error(), "call expr has 2 arguments, no argument at index 2") {
+		t.Errorf("expected error about missing argument, got %q and %v", expr, err)
+	}
+
+	if _, err := Expression(100); err == nil || !strings.Contains(err.Error(), "no caller information available") {
+		t.Errorf("expected error about caller information, got %v", err)
+	}
+}
+
+func Test_CallArgsExpressions(t *testing.T) {
+	values := func(v ...any) []any { return v }
+	assert := func(_ any, _ bool, _ ...any) ([]string, error) { return CallArgsExpressions(1, 2) }
+	got, want := 1, 2
+
+	if exprs, err := assert(t, got == want, values(got, want+1)); err != nil || strings.Join(exprs, ", ") != "got, want + 1" {
+		t.Errorf("unexpected expressions %q: %v", exprs, err)
+	}
+
+	if exprs, err := assert(t, got == want, got); err == nil || !strings.Contains(err.Error(), "argument at index 2 is not a call expression") {
+		t.Errorf("expected error about call expression, got %q and %v", exprs, err)
+	}
+}
+
 func Test_customizeASTExprRepr(t *testing.T) {
 	anError := errors.New("bim")
 	errBoom := errors.New("boom")
@@ -71,6 +121,37 @@ func Test_customizeASTExprRepr(t *testing.T) {
 		expectedError   string
 	}{
 		"BinaryExpr": {
+			"slices.Index_not_found": {
+				getResult: func(t *testing.T) (string, error) {
+					ids := []int{1, 2}
+					pkg, expr := getTestingExpr[bool](t, slices.Index(ids, 3) == -1)
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "ids does not contain 3",
+			},
+			"slices.Index_found": {
+				getResult: func(t *testing.T) (string, error) {
+					ids := []int{1, 2}
+					pkg, expr := getTestingExpr[bool](t, slices.Index(ids, 3) == -1)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "ids contains 3",
+			},
+			"slices.IndexFunc_not_found": {
+				getResult: func(t *testing.T) (string, error) {
+					isNegative := func(i int) bool { return i < 0 }
+					pkg, expr := getTestingExpr[bool](t, slices.IndexFunc([]int{1}, isNegative) != -1)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "no element of []int{1} satisfies isNegative",
+			},
+			"builtin_call": {
+				getResult: func(t *testing.T) (string, error) {
+					pkg, expr := getTestingExpr[bool](t, min(1, 2) == -1)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "min(1, 2) is not equal to -1",
+			},
 			// AND / OR
 			"AND_true": {
 				getResult: func(t *testing.T) (string, error) {
@@ -541,6 +622,111 @@ func Test_customizeASTExprRepr(t *testing.T) {
 				},
 				expectedMessage: "anError cannot be defined as *github.com/krostar/test/internal/message.boomErr",
 			},
+			"slices.ContainsFunc_true": {
+				getResult: func(t *testing.T) (string, error) {
+					isEmpty := func(s string) bool { return s == "" }
+					pkg, expr := getTestingExpr[bool](t, slices.ContainsFunc([]string{""}, isEmpty))
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: `an element of []string{""} satisfies isEmpty`,
+			},
+			"slices.ContainsFunc_false": {
+				getResult: func(t *testing.T) (string, error) {
+					isEmpty := func(s string) bool { return s == "" }
+					pkg, expr := getTestingExpr[bool](t, slices.ContainsFunc([]string{"a"}, isEmpty))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: `no element of []string{"a"} satisfies isEmpty`,
+			},
+			"slices.IsSorted_true": {
+				getResult: func(t *testing.T) (string, error) {
+					ids := []int{1, 2}
+					pkg, expr := getTestingExpr[bool](t, slices.IsSorted(ids))
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "ids is sorted",
+			},
+			"slices.IsSorted_false": {
+				getResult: func(t *testing.T) (string, error) {
+					ids := []int{2, 1}
+					pkg, expr := getTestingExpr[bool](t, slices.IsSorted(ids))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "ids is not sorted",
+			},
+			"slices.IsSortedFunc_false": {
+				getResult: func(t *testing.T) (string, error) {
+					ids := []string{"b", "a"}
+					pkg, expr := getTestingExpr[bool](t, slices.IsSortedFunc(ids, strings.Compare))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "ids is not sorted according to strings.Compare",
+			},
+			"time.Time.Before_false": {
+				getResult: func(t *testing.T) (string, error) {
+					t1, t2 := time.Unix(2, 0), time.Unix(1, 0)
+					pkg, expr := getTestingExpr[bool](t, t1.Before(t2))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "t1 is not before t2",
+			},
+			"time.Time.After_true": {
+				getResult: func(t *testing.T) (string, error) {
+					t1, t2 := time.Unix(2, 0), time.Unix(1, 0)
+					pkg, expr := getTestingExpr[bool](t, t1.After(t2))
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "t1 is after t2",
+			},
+			"time.Time.Equal_false": {
+				getResult: func(t *testing.T) (string, error) {
+					t1, t2 := time.Unix(2, 0), time.Unix(1, 0)
+					pkg, expr := getTestingExpr[bool](t, t1.Equal(t2))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "t1 is not equal to t2",
+			},
+			"time.Time.IsZero_false": {
+				getResult: func(t *testing.T) (string, error) {
+					createdAt := time.Unix(1, 0)
+					pkg, expr := getTestingExpr[bool](t, createdAt.IsZero())
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "createdAt is not zero",
+			},
+			"regexp.MatchString_true": {
+				getResult: func(t *testing.T) (string, error) {
+					version := "v1.2.3"
+					matched, _ := regexp.MatchString(`^v\d`, version)
+					pkg, expr := getTestingExpr[bool](t, matched)
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "version matches pattern `^v\\d`",
+			},
+			"regexp.Match_false": {
+				getResult: func(t *testing.T) (string, error) {
+					matched, _ := regexp.Match(`^\d+$`, []byte("abc"))
+					pkg, expr := getTestingExpr[bool](t, matched)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "[]byte(\"abc\") does not match pattern `^\\d+$`",
+			},
+			"regexp.Regexp.MatchString_false": {
+				getResult: func(t *testing.T) (string, error) {
+					id := "abc"
+					pkg, expr := getTestingExpr[bool](t, _testingDigitsRegexp.MatchString(id))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "id does not match pattern `^\\d+$`",
+			},
+			"regexp.Regexp.Match_unresolved": {
+				getResult: func(t *testing.T) (string, error) {
+					re := regexp.MustCompile("^a")
+					pkg, expr := getTestingExpr[bool](t, re.Match([]byte("b")))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: `[]byte("b") does not match pattern re`,
+			},
 		},
 		"Ident": {
 			"literal_true": {
@@ -625,6 +811,198 @@ func Test_customizeASTExprRepr(t *testing.T) {
 				},
 				expectedMessage: "foo.value is true",
 			},
+			"IndexExpr": {
+				getResult: func(t *testing.T) (string, error) {
+					items := []struct{ Ready bool }{{Ready: false}}
+					pkg, expr := getTestingExpr[bool](t, items[0].Ready)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "items[0].Ready is false",
+			},
+		},
+		"len-cap": {
+			"len_equal_false": {
+				getResult: func(t *testing.T) (string, error) {
+					a := []int{1}
+					pkg, expr := getTestingExpr[bool](t, len(a) == 2)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "a does not have length 2",
+			},
+			"len_not_equal_false": {
+				getResult: func(t *testing.T) (string, error) {
+					a := []int{1, 2}
+					pkg, expr := getTestingExpr[bool](t, len(a) != 2)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "a has length 2",
+			},
+			"len_zero": {
+				getResult: func(t *testing.T) (string, error) {
+					a := []int{1}
+					pkg, expr := getTestingExpr[bool](t, len(a) == 0)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "a is not empty",
+			},
+			"len_greater_false": {
+				getResult: func(t *testing.T) (string, error) {
+					a := "ab"
+					pkg, expr := getTestingExpr[bool](t, len(a) > 3)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "a has a length less than or equal to 3",
+			},
+			"len_same": {
+				getResult: func(t *testing.T) (string, error) {
+					a, b := []int{1}, []int{1, 2}
+					pkg, expr := getTestingExpr[bool](t, len(a) == len(b))
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "a does not have the same length as b",
+			},
+			"cap_less_true": {
+				getResult: func(t *testing.T) (string, error) {
+					a := make([]int, 0, 2)
+					pkg, expr := getTestingExpr[bool](t, cap(a) < 3)
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "a has a capacity less than 3",
+			},
+			"len_equal_known": {
+				getResult: func(t *testing.T) (string, error) {
+					a := []int{1, 2, 3}
+					pkg, expr := getTestingExpr[bool](t, len(a) == 2)
+					return customizeASTExprReprWithValues(pkg, map[string]any{"len(a)": len(a)}, false, expr)
+				},
+				expectedMessage: "a has length 3, expected 2",
+			},
+			"len_zero_known": {
+				getResult: func(t *testing.T) (string, error) {
+					a := []int{1}
+					pkg, expr := getTestingExpr[bool](t, len(a) == 0)
+					return customizeASTExprReprWithValues(pkg, map[string]any{"len(a)": len(a)}, false, expr)
+				},
+				expectedMessage: "a has length 1, expected 0",
+			},
+			"cap_greater_known": {
+				getResult: func(t *testing.T) (string, error) {
+					a := make([]int, 0, 2)
+					pkg, expr := getTestingExpr[bool](t, cap(a) > 3)
+					return customizeASTExprReprWithValues(pkg, map[string]any{"cap(a)": cap(a)}, false, expr)
+				},
+				expectedMessage: "a has capacity 2, expected a capacity greater than 3",
+			},
+			"len_known_in_conjunction": {
+				getResult: func(t *testing.T) (string, error) {
+					a, ok := []int{1, 2}, false
+					pkg, expr := getTestingExpr[bool](t, len(a) == 2 && ok)
+					return customizeASTExprReprWithValues(pkg, map[string]any{"len(a)": len(a)}, false, expr)
+				},
+				expectedMessage: "a does not have length 2, or var ok is false",
+			},
+		},
+		"elapsed": {
+			"since_less_false": {
+				getResult: func(t *testing.T) (string, error) {
+					start, timeout := time.Unix(0, 0), time.Second
+					pkg, expr := getTestingExpr[bool](t, time.Since(start) < timeout)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "time since start exceeds timeout",
+			},
+			"since_greater_false": {
+				getResult: func(t *testing.T) (string, error) {
+					start := time.Now()
+					pkg, expr := getTestingExpr[bool](t, time.Since(start) > time.Hour)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "time since start does not exceed time.Hour",
+			},
+			"until_less_true": {
+				getResult: func(t *testing.T) (string, error) {
+					deadline := time.Now()
+					pkg, expr := getTestingExpr[bool](t, time.Until(deadline) < time.Minute)
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "time until deadline is less than time.Minute",
+			},
+			"since_equal": {
+				getResult: func(t *testing.T) (string, error) {
+					start := time.Now()
+					pkg, expr := getTestingExpr[bool](t, time.Since(start) == 0)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "time.Since(start) is not equal to 0",
+			},
+		},
+		"TypeAssertExpr": {
+			"comma-ok_interface": {
+				getResult: func(t *testing.T) (string, error) {
+					var v any = 42
+					_, ok := v.(io.Reader)
+					pkg, expr := getTestingExpr[bool](t, ok)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "v does not implement io.Reader",
+			},
+			"comma-ok_type": {
+				getResult: func(t *testing.T) (string, error) {
+					var (
+						v  any = 42
+						ok bool
+					)
+					_, ok = v.(int)
+					pkg, expr := getTestingExpr[bool](t, ok)
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "v is of type int",
+			},
+			"comma-ok_negated": {
+				getResult: func(t *testing.T) (string, error) {
+					var v any = 42
+					_, isString := v.(string)
+					pkg, expr := getTestingExpr[bool](t, !isString)
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "v is not of type string",
+			},
+			"comma-ok_reassigned": {
+				getResult: func(t *testing.T) (string, error) {
+					var v any = 42
+					_, ok := v.(string)
+					ok = ok || v == nil
+					pkg, expr := getTestingExpr[bool](t, ok)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "var ok is false",
+			},
+			"inline": {
+				getResult: func(t *testing.T) (string, error) {
+					var v any = true
+					pkg, expr := getTestingExpr[bool](t, v.(bool))
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "v.(bool) is true",
+			},
+		},
+		"IndexExpr": {
+			"map": {
+				getResult: func(t *testing.T) (string, error) {
+					flags := map[string]bool{}
+					pkg, expr := getTestingExpr[bool](t, flags["debug"])
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: `flags["debug"] is false`,
+			},
+			"slice": {
+				getResult: func(t *testing.T) (string, error) {
+					ready := []bool{true}
+					pkg, expr := getTestingExpr[bool](t, ready[0])
+					return customizeASTExprRepr(pkg, true, expr)
+				},
+				expectedMessage: "ready[0] is true",
+			},
 		},
 		"UnaryExpr": {
 			"NOT-CallExpr": {
@@ -649,6 +1027,22 @@ func Test_customizeASTExprRepr(t *testing.T) {
 				},
 				expectedMessage: "21 is less than or equal to 42",
 			},
+			"NOT-IndexExpr": {
+				getResult: func(t *testing.T) (string, error) {
+					flags := map[string]bool{"debug": true}
+					pkg, expr := getTestingExpr[bool](t, !flags["debug"])
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: `flags["debug"] is true`,
+			},
+			"NOT-SelectorExpr": {
+				getResult: func(t *testing.T) (string, error) {
+					item := struct{ Ready bool }{Ready: true}
+					pkg, expr := getTestingExpr[bool](t, !item.Ready)
+					return customizeASTExprRepr(pkg, false, expr)
+				},
+				expectedMessage: "item.Ready is true",
+			},
 			"NOT-UnaryExpr": {
 				getResult: func(t *testing.T) (string, error) {
 					i := true
@@ -985,7 +1379,7 @@ func rawGetTestingExpr[T any](t *testing.T, stack int, funcName string, _ T) (*p
 		t.Fatalf("unable to get package AST: %v", err)
 	}
 
-	_, file, pkg, err := code.GetCallerCallExpr(pkgPathToPkg, callerFile, callerLine)
+	_, file, pkg, err := code.GetCallerCallExpr(pkgPathToPkg, callerFile, callerLine, "", 0)
 	if err != nil {
 		t.Fatalf("unable to get call expr from caller: %v", err)
 	}
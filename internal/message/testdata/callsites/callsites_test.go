@@ -0,0 +1,19 @@
+package callsites
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/check"
+)
+
+func Test_Something(t *testing.T) {
+	got, want := 42, 24
+	test.Assert(t, got == want)
+
+	err := errors.New("boom")
+	test.Require(t, err == nil, "no error expected")
+
+	test.Warn(check.Compare(t, got, want))
+}
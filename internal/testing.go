@@ -1,21 +1,10 @@
 package internal
 
 import (
-	"context"
+	"github.com/krostar/test/testingt"
 )
 
 // TestingT is an interface for testing types.
 // It mimics the standard library's *testing.T.
-// It is placed here to avoid imports cycles.
-type TestingT interface {
-	Helper()
-	Cleanup(f func())
-
-	Fail()
-	FailNow()
-
-	Log(args ...any)
-	Logf(format string, args ...any)
-
-	Context() context.Context
-}
+// It is kept for compatibility, the definition now lives in the public testingt package.
+type TestingT = testingt.TestingT
@@ -0,0 +1,79 @@
+package test
+
+// Must requires `err` to be nil, and returns `v`.
+//
+// It collapses the check of the error of a call with the use of its result, stopping the test if the error is not nil.
+// The message of a failing Must includes the error, like NoError.
+//
+// Example:
+//
+//	u, err := url.Parse(raw)
+//	query := test.Must(t, u, err).Query()
+//
+// -> Error: err is a non-nil *url.Error: parse ":": missing protocol scheme
+func Must[T any](t TestingT, v T, err error) T {
+	t.Helper()
+	return must(t, v, err)
+}
+
+// Must2 behaves like Must, for calls returning two values along with an error.
+func Must2[T1, T2 any](t TestingT, v1 T1, v2 T2, err error) (T1, T2) {
+	t.Helper()
+	return must2(t, v1, v2, err)
+}
+
+// Must3 behaves like Must, for calls returning three values along with an error.
+func Must3[T1, T2, T3 any](t TestingT, v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	t.Helper()
+	requireNoError(t, 2, 4, err)
+	return v1, v2, v3
+}
+
+// Require1 is an alias of Must, for codebases preferring the Require naming for setup calls.
+//
+// Example:
+//
+//	srv, err := server.New(cfg)
+//	addr := test.Require1(t, srv, err).Addr()
+func Require1[T any](t TestingT, v T, err error) T {
+	t.Helper()
+	return must(t, v, err)
+}
+
+// Require2 is an alias of Must2, see Require1.
+func Require2[T1, T2 any](t TestingT, v1 T1, v2 T2, err error) (T1, T2) {
+	t.Helper()
+	return must2(t, v1, v2, err)
+}
+
+// must implements Must and its aliases, called with the arguments of the caller's call.
+func must[T any](t TestingT, v T, err error) T {
+	t.Helper()
+	requireNoError(t, 3, 2, err)
+	return v
+}
+
+// must2 implements Must2 and its aliases, called with the arguments of the caller's call.
+func must2[T1, T2 any](t TestingT, v1 T1, v2 T2, err error) (T1, T2) {
+	t.Helper()
+	requireNoError(t, 3, 3, err)
+	return v1, v2
+}
+
+// requireNoError stops the test if `err`, the argument at position `argIndex` of the assertion call
+// made `callerStackIndex` frames above, is not nil.
+func requireNoError(t TestingT, callerStackIndex, argIndex int, err error) {
+	t.Helper()
+
+	passed := err == nil
+	logDescribedResult(t, optionsOf(t), passed, callerStackIndex, argIndex, func(argExpr func(int, string) string) string {
+		if passed {
+			return argExpr(argIndex, "err") + " is nil"
+		}
+		return describeError(argExpr(argIndex, "err"), err)
+	}, argIndex+1)
+
+	if !passed {
+		t.FailNow()
+	}
+}
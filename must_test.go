@@ -0,0 +1,55 @@
+package test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Must(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		n, err := strconv.Atoi("42")
+		if v := Must(spiedT, n, err); v != 42 {
+			t.Errorf("unexpected value %d", v)
+		}
+
+		a, b := Must2(spiedT, 1, "a", nil)
+		c, d, e := Must3(spiedT, 1, "a", true, nil)
+		if a != 1 || b != "a" || c != 1 || d != "a" || !e {
+			t.Errorf("unexpected values %v %v %v %v %v", a, b, c, d, e)
+		}
+
+		f := Require1(spiedT, n, err)
+		g, h := Require2(spiedT, 1, "a", nil)
+		if f != 42 || g != 1 || h != "a" {
+			t.Errorf("unexpected values %v %v %v", f, g, h)
+		}
+
+		spiedT.ExpectTestToPass(t)
+		spiedT.ExpectNoLogs(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		n, err := strconv.Atoi("nope")
+		Must(spiedT, n, err)
+		Must2(spiedT, 1, 2, errors.New("boom"))
+		Must3(spiedT, 1, 2, 3, errors.ErrUnsupported)
+		b, err := strconv.ParseBool("nope")
+		Require1(spiedT, b, err)
+		Require2(spiedT, 1, 2, errors.New("bam"))
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+		spiedT.ExpectLogsToContain(t, `Error: err is a non-nil *strconv.NumError: strconv.Atoi: parsing "nope": invalid syntax`)
+		spiedT.ExpectLogsToContain(t, `Error: errors.New("boom") is a non-nil *errors.errorString: boom`)
+		spiedT.ExpectLogsToContain(t, `Error: errors.ErrUnsupported is a non-nil *errors.errorString: unsupported operation`)
+		spiedT.ExpectLogsToContain(t, `Error: err is a non-nil *strconv.NumError: strconv.ParseBool: parsing "nope": invalid syntax`)
+		spiedT.ExpectLogsToContain(t, `Error: errors.New("bam") is a non-nil *errors.errorString: bam`)
+	})
+}
@@ -0,0 +1,214 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// options holds the settings of assertions, see Option.
+type options struct {
+	successMessages bool
+	formatter       Formatter
+	failFast        bool
+	stackTraces     bool
+	checkTimeout    time.Duration
+	verbosity       Verbosity
+	color           ColorMode
+	sourceAnalysis  bool
+
+	stats          bool
+	skipReportPath string
+	replayDir      string
+
+	eventuallyTimeout     time.Duration
+	eventuallyInterval    time.Duration
+	eventuallyMaxInterval time.Duration
+
+	maxMessageLength     int
+	maxCollectionPreview int
+	maxDepth             int
+}
+
+// Option configures how assertions behave.
+//
+// Options can be given to New, to configure all the assertions made on the asserter,
+// or to a single assertion call, along with its custom message:
+//
+//	test.Assert(t, got == want, "user %d", id, test.WithSuccessMessages())
+type Option func(o *options)
+
+// WithSuccessMessages enables the logging of messages of passing assertions,
+// like the global SuccessMessageEnabled does for all assertions.
+func WithSuccessMessages() Option {
+	return func(o *options) { o.successMessages = true }
+}
+
+// WithFormatter sets the formatter rendering the messages of assertions,
+// like SetFormatter does for all assertions.
+func WithFormatter(formatter Formatter) Option {
+	return func(o *options) {
+		if formatter != nil {
+			o.formatter = formatter
+		}
+	}
+}
+
+// WithSourceAnalysis sets whether the source of assertions is analyzed to describe their expressions, which is the default.
+//
+// When disabled, messages only state whether assertions passed or failed, along with their custom message and values,
+// which saves the cost of parsing the source of tests, and suits binaries running without their sources.
+func WithSourceAnalysis(enabled bool) Option {
+	return func(o *options) { o.sourceAnalysis = enabled }
+}
+
+// WithFailFast stops the test at the first failed assertion, making Assert behave like Require.
+// It can be enabled for all assertions with Configure, or by running tests with the -check.fail-fast flag.
+func WithFailFast() Option {
+	return func(o *options) { o.failFast = true }
+}
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	_flagFailFast = flag.Bool("check.fail-fast", false, "Whether to stop tests at their first failed assertion, making Assert behave like Require")
+
+	_configuredOptions      []Option
+	_configuredOptionsMutex sync.RWMutex
+)
+
+// enumFlag is a flag only accepting one of its allowed values, for invalid values to be rejected
+// when flags are parsed, failing the test binary, instead of being silently ignored.
+type enumFlag[T ~string] struct {
+	value   T
+	allowed []T
+}
+
+// newEnumFlag defines a flag with the provided name and usage, whose value can only be one of the allowed ones.
+// It defaults to an empty value, meaning the flag is not set.
+func newEnumFlag[T ~string](name, usage string, allowed ...T) *enumFlag[T] {
+	f := &enumFlag[T]{allowed: allowed}
+	flag.Var(f, name, usage)
+	return f
+}
+
+// String implements the flag.Value interface.
+func (f *enumFlag[T]) String() string { return string(f.value) }
+
+// Set implements the flag.Value interface.
+func (f *enumFlag[T]) Set(value string) error {
+	if !slices.Contains(f.allowed, T(value)) {
+		return fmt.Errorf("expected one of %v", f.allowed)
+	}
+	f.value = T(value)
+	return nil
+}
+
+// Configure sets the options applied to all assertions, and returns a function restoring the previous ones.
+// Options of asserters created with New, and options given to assertion calls, take precedence over them.
+// It is the single entry point to configure assertions, superseding the package globals, like SuccessMessageEnabled.
+//
+// It is meant to be called once, from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		test.Configure(test.WithFailFast(), test.WithStackTraces())
+//		os.Exit(m.Run())
+//	}
+//
+// Options can also be set without changing the code, through environment variables, read once per run,
+// which override the globals, flags, and options given to Configure, but not the options of asserters and calls:
+//
+//	KROSTAR_TEST_SUCCESS_MESSAGES=true         see WithSuccessMessages
+//	KROSTAR_TEST_FAIL_FAST=true                see WithFailFast
+//	KROSTAR_TEST_STACK_TRACES=true             see WithStackTraces
+//	KROSTAR_TEST_SOURCE_ANALYSIS=false         see WithSourceAnalysis
+//	KROSTAR_TEST_COLOR=auto                    see WithColor
+//	KROSTAR_TEST_VERBOSITY=verbose             see WithVerbosity
+//	KROSTAR_TEST_MAX_MESSAGE_LENGTH=4096       see WithMaxMessageLength
+//	KROSTAR_TEST_MAX_COLLECTION_PREVIEW=10     see WithMaxCollectionPreview
+//	KROSTAR_TEST_MAX_DEPTH=3                   see WithMaxDepth
+//	KROSTAR_TEST_CHECK_TIMEOUT=30s             see WithCheckTimeout
+//	KROSTAR_TEST_EVENTUALLY_TIMEOUT=10s        see WithEventuallyTimeout
+//	KROSTAR_TEST_STATS=true                    see WithStats
+//	KROSTAR_TEST_SKIP_REPORT=/abs/skips.jsonl  see WithSkipReport
+//	KROSTAR_TEST_REPLAY_DIR=/abs/replays       see WithReplayDir
+//
+// Invalid values are ignored, and reported once in the logs of the first assertion.
+func Configure(opts ...Option) func() {
+	_configuredOptionsMutex.Lock()
+	defer _configuredOptionsMutex.Unlock()
+
+	previous := _configuredOptions
+	_configuredOptions = opts
+
+	return func() {
+		_configuredOptionsMutex.Lock()
+		defer _configuredOptionsMutex.Unlock()
+
+		_configuredOptions = previous
+	}
+}
+
+// optionsHolder is implemented by testing types carrying their own assertion options, like Asserter.
+type optionsHolder interface {
+	assertionOptions() []Option
+}
+
+// optionsOf returns the settings of the assertions made on t: the global settings and flags,
+// overridden by the options set with Configure, then by the environment, then by the options carried by t, if any,
+// and finally by the options of the assertion call.
+func optionsOf(t TestingT, callOpts ...Option) options {
+	o := options{
+		successMessages: SuccessMessageEnabled || *_flagEnableSuccessMessage,
+		stackTraces:     *_flagEnableStackTraces,
+		failFast:        *_flagFailFast,
+		checkTimeout:    *_flagCheckTimeout,
+		formatter:       currentFormatter(),
+		verbosity:       defaultVerbosity(),
+		color:           defaultColorMode(),
+		sourceAnalysis:  true,
+
+		stats:          *_flagEnableStats,
+		skipReportPath: *_flagSkipReportPath,
+		replayDir:      *_flagReplayDir,
+
+		eventuallyInterval:    10 * time.Millisecond,
+		eventuallyMaxInterval: time.Second,
+
+		maxMessageLength:     defaultMaxMessageLength,
+		maxCollectionPreview: defaultMaxCollectionPreview,
+		maxDepth:             defaultMaxDepth,
+	}
+
+	_configuredOptionsMutex.RLock()
+	for _, opt := range _configuredOptions {
+		opt(&o)
+	}
+	_configuredOptionsMutex.RUnlock()
+
+	applyEnvConfiguration(t, &o)
+
+	if h, ok := t.(optionsHolder); ok {
+		for _, opt := range h.assertionOptions() {
+			opt(&o)
+		}
+	}
+
+	for _, opt := range callOpts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// callOptions returns the options provided to an assertion call, among its custom message and arguments.
+func callOptions(msgAndArgs []any) []Option {
+	var opts []Option
+	for _, arg := range msgAndArgs {
+		if opt, ok := arg.(Option); ok {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_callOptions(t *testing.T) {
+	t.Run("success messages", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 1, 2
+
+		Assert(spiedT, got != want)
+		spiedT.ExpectNoLogs(t)
+
+		Assert(spiedT, got != want, "hello %s", "world", WithSuccessMessages())
+		spiedT.ExpectLogsToContain(t, "Success: got is not equal to want [hello world]")
+	})
+
+	t.Run("formatter and values", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got, want := 1, 2
+
+		Assert(spiedT, got == want, WithFormatter(FormatterFunc(func(r AssertionResult) string {
+			return "custom: " + r.Description
+		})), Values(got))
+		spiedT.ExpectLogsToContain(t, "Error: custom: got is not equal to want")
+
+		NoError(spiedT, nil, Values(got), WithSuccessMessages())
+		spiedT.ExpectLogsToContain(t, "Success: nil is nil; got=1")
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		Assert(spiedT, false)
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "Fail"})
+
+		spiedT = double.NewSpy(double.NewFake())
+		New(spiedT).Assert(false, WithFailFast())
+		spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "FailNow"})
+	})
+
+	t.Run("color", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		Assert(spiedT, false, WithColor(ColorAlways))
+		spiedT.ExpectLogsToContain(t, "Error: \x1b[1mliteral false\x1b[0m")
+
+		Errorf(spiedT, "status %d", 500, WithColor(ColorAlways))
+		spiedT.ExpectLogsToContain(t, "Error: \x1b[1mstatus 500\x1b[0m")
+
+		c := Collect(spiedT)
+		c.Assert(false, WithColor(ColorAlways))
+		c.Report()
+		spiedT.ExpectLogsToContain(t, "Error: 1 collected failures:\n  - \x1b[1mliteral false\x1b[0m")
+	})
+
+	t.Run("source analysis", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		var failures []Failure
+		OnFailure(spiedT, func(failure Failure) { failures = append(failures, failure) })
+
+		got, want := 1, 2
+		Assert(spiedT, got == want, WithSourceAnalysis(false))
+		NoError(spiedT, errors.New("boom"), WithSourceAnalysis(false))
+
+		spiedT.ExpectLogsToContain(t, "Error: assertion failed")
+
+		if len(failures) != 2 || failures[0].Expression != "" || failures[1].Expression != "" {
+			t.Errorf("expected failures to have no expression, got %+v", failures)
+		}
+	})
+
+	t.Run("call options override instance options", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a := New(spiedT, WithFormatter(FormatterFunc(func(AssertionResult) string { return "instance" })))
+
+		a.Assert(false)
+		a.Assert(false, WithFormatter(FormatterFunc(func(AssertionResult) string { return "call" })))
+
+		spiedT.ExpectLogsToContain(t, "Error: instance")
+		spiedT.ExpectLogsToContain(t, "Error: call")
+	})
+}
+
+func Test_Configure(t *testing.T) {
+	restore := Configure(WithFailFast())
+
+	spiedT := double.NewSpy(double.NewFake())
+	Assert(spiedT, false)
+	Equal(spiedT, 1, 2)
+	spiedT.ExpectRecords(t, false,
+		double.SpyTestingTRecord{Method: "Logf", Inputs: []any{"Error: %s", double.SpyTestingTRecordIgnoreParam}},
+		double.SpyTestingTRecord{Method: "FailNow"},
+		double.SpyTestingTRecord{Method: "Logf", Inputs: []any{"Error: %s", double.SpyTestingTRecordIgnoreParam}},
+		double.SpyTestingTRecord{Method: "FailNow"},
+	)
+
+	restore()
+
+	spiedT = double.NewSpy(double.NewFake())
+	Assert(spiedT, false)
+	spiedT.ExpectRecords(t, false, double.SpyTestingTRecord{Method: "Fail"})
+}
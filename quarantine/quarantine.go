@@ -0,0 +1,186 @@
+// Package quarantine provides a way to quarantine flaky tests.
+//
+// Failures of quarantined tests are logged, but instead of failing, the tests are skipped.
+// This allows to keep running flaky tests, and to keep track of their failures,
+// without breaking the test suite while they are being fixed.
+package quarantine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/testingt"
+)
+
+// List holds the patterns of the quarantined tests names.
+type List struct {
+	patterns []*regexp.Regexp
+}
+
+// Load reads the quarantine list from the file at the provided path.
+// See Parse for the expected format.
+func Load(path string) (*List, error) {
+	f, err := os.Open(path) //nolint:gosec // path is provided by the test author
+	if err != nil {
+		return nil, fmt.Errorf("unable to open quarantine file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // file is only read
+
+	list, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse quarantine file %s: %w", path, err)
+	}
+
+	return list, nil
+}
+
+// Parse reads the quarantine list from the provided reader.
+//
+// Each line contains a regular expression matching the full name of the quarantined tests,
+// as returned by testing.T.Name(). Empty lines, and lines starting with # are ignored.
+//
+// Example:
+//
+//	# flaky since the database upgrade
+//	Test_Database/concurrent_writes
+//	Test_Cache/.*_eviction
+func Parse(r io.Reader) (*List, error) {
+	var list List
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern, err := regexp.Compile("^(?:" + line + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNumber, line, err)
+		}
+
+		list.patterns = append(list.patterns, pattern)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read quarantine list: %w", err)
+	}
+
+	return &list, nil
+}
+
+// IsQuarantined returns whether the provided test name matches any of the list patterns.
+func (l *List) IsQuarantined(name string) bool {
+	for _, pattern := range l.patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Wrap returns a TestingT that does not fail the test if it is quarantined.
+//
+// The name of the test is obtained through a Name() method, if `t` does not provide it,
+// or if the test is not quarantined, `t` is returned as is.
+//
+// Failures of quarantined tests are logged, and the test is skipped at the end of the test,
+// or immediately on FailNow calls. If `t` does not provide a Skip method, failures are ignored,
+// and FailNow only stops the test goroutine.
+//
+// Apart from failing the test, failures are reported like the ones of any other test: the hooks registered on `t`
+// with test.OnFailure are called, replay files are written, and test attributes are emitted, along with
+// a "quarantined" attribute, if `t` supports them. The returned TestingT exposes `t` with an Unwrap method,
+// see testingt.Unwrap.
+//
+// Example:
+//
+//	var quarantined = func() *quarantine.List {
+//		list, err := quarantine.Load("testdata/quarantine.txt")
+//		if err != nil {
+//			panic(err)
+//		}
+//		return list
+//	}()
+//
+//	func Test_Something(t *testing.T) {
+//		tt := quarantined.Wrap(t)
+//		test.Assert(tt, flakyCall())
+//	}
+func (l *List) Wrap(t test.TestingT) test.TestingT {
+	if !l.IsQuarantined(t.Name()) {
+		return t
+	}
+
+	q := &quarantinedT{TestingT: t}
+	t.Cleanup(q.skipIfFailed)
+
+	return q
+}
+
+// quarantinedT wraps a TestingT to turn failures into skips.
+type quarantinedT struct {
+	test.TestingT
+
+	m      sync.Mutex
+	failed bool
+}
+
+// Fail records the failure without failing the underlying test.
+func (q *quarantinedT) Fail() {
+	q.Helper()
+
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	if !q.failed {
+		q.Log("Quarantined: test failed, failure will be turned into a skip")
+		if a, ok := testingt.AsAttr(q.TestingT); ok {
+			a.Attr("quarantined", "true")
+		}
+	}
+
+	q.failed = true
+}
+
+// FailNow records the failure and stops the test by skipping it.
+func (q *quarantinedT) FailNow() {
+	q.Helper()
+	q.Fail()
+
+	if !q.skip() {
+		q.Log("Quarantined: test failed but cannot be skipped, test is stopped and the failure is ignored")
+		runtime.Goexit()
+	}
+}
+
+// Unwrap returns the quarantined TestingT, see testingt.Unwrap.
+func (q *quarantinedT) Unwrap() testingt.TestingT { return q.TestingT }
+
+// skipIfFailed skips the underlying test if a failure was recorded.
+func (q *quarantinedT) skipIfFailed() {
+	q.m.Lock()
+	failed := q.failed
+	q.m.Unlock()
+
+	if failed && !q.skip() {
+		q.Log("Quarantined: test failed but cannot be skipped, the failure is ignored")
+	}
+}
+
+// skip skips the underlying test, if it provides a Skip method.
+// It returns false if the test cannot be skipped.
+func (q *quarantinedT) skip() bool {
+	s, ok := testingt.AsSkipper(q.TestingT)
+	if ok {
+		s.Skip("Quarantined: test failed and has been skipped")
+	}
+	return ok
+}
@@ -0,0 +1,249 @@
+package quarantine
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/krostar/test"
+	"github.com/krostar/test/double"
+	"github.com/krostar/test/testingt"
+)
+
+func Test_Load(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		list, err := Load("testdata/quarantine.txt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(list.patterns) != 2 {
+			t.Errorf("expected 2 patterns, got %d", len(list.patterns))
+		}
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		if _, err := Load("testdata/notexisting.txt"); err == nil || !strings.Contains(err.Error(), "unable to open quarantine file") {
+			t.Errorf("expected open failure, got %v", err)
+		}
+	})
+}
+
+func Test_Parse(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		list, err := Parse(strings.NewReader("# comment\n\n  Test_A  \nTest_B/.*\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(list.patterns) != 2 {
+			t.Errorf("expected 2 patterns, got %d", len(list.patterns))
+		}
+	})
+
+	t.Run("ko", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("Test_A\nTest_B(\n"))
+		if err == nil || !strings.Contains(err.Error(), `line 2: invalid pattern "Test_B("`) {
+			t.Errorf("expected invalid pattern failure, got %v", err)
+		}
+	})
+}
+
+func Test_List_IsQuarantined(t *testing.T) {
+	list, err := Load("testdata/quarantine.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, expected := range map[string]bool{
+		"Test_Flaky":              true,
+		"Test_FlakyButNotReally":  false,
+		"Test_Suite/case_flaky":   true,
+		"Test_Suite/case_stable":  false,
+		"Test_Suite/case_flaky/x": false,
+	} {
+		if got := list.IsQuarantined(name); got != expected {
+			t.Errorf("expected IsQuarantined(%q) to be %t, got %t", name, expected, got)
+		}
+	}
+}
+
+func Test_List_Wrap(t *testing.T) {
+	list, err := Parse(strings.NewReader("Test_Flaky"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("unnamed test", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		if list.Wrap(spiedT) != spiedT {
+			t.Error("expected unnamed test to be returned as is")
+		}
+	})
+
+	t.Run("not quarantined", func(t *testing.T) {
+		namedT := newNamedT("Test_Stable")
+		if list.Wrap(namedT) != namedT {
+			t.Error("expected not quarantined test to be returned as is")
+		}
+	})
+
+	t.Run("quarantined", func(t *testing.T) {
+		t.Run("passing", func(t *testing.T) {
+			namedT := newSkippableT("Test_Flaky")
+			list.Wrap(namedT)
+			namedT.cleanup()
+
+			namedT.ExpectTestToPass(t)
+			namedT.ExpectNoLogs(t)
+
+			if namedT.skipped != nil {
+				t.Error("expected test not to be skipped")
+			}
+		})
+
+		t.Run("failing", func(t *testing.T) {
+			namedT := newSkippableT("Test_Flaky")
+			tt := list.Wrap(namedT)
+			tt.Fail()
+			tt.Fail()
+
+			namedT.ExpectTestToPass(t)
+			namedT.ExpectLogsToContain(t, "Quarantined: test failed, failure will be turned into a skip")
+
+			if namedT.skipped != nil {
+				t.Error("expected test not to be skipped before the end of the test")
+			}
+
+			namedT.cleanup()
+
+			if len(namedT.skipped) != 1 || namedT.skipped[0] != "Quarantined: test failed and has been skipped" {
+				t.Errorf("expected test to be skipped, got %v", namedT.skipped)
+			}
+		})
+
+		t.Run("failing assertion", func(t *testing.T) {
+			namedT := newSkippableT("Test_Flaky")
+
+			var failures []test.Failure
+			test.OnFailure(namedT, func(failure test.Failure) { failures = append(failures, failure) })
+
+			tt := list.Wrap(namedT)
+			if s, ok := testingt.AsSkipper(tt); !ok || s != namedT {
+				t.Error("expected the skipper of the quarantined test to be found")
+			}
+
+			test.Assert(tt, false, "flaky")
+
+			namedT.ExpectTestToPass(t)
+			namedT.ExpectLogsToContain(t, "Error: ", "[flaky]")
+
+			if len(failures) != 1 || failures[0].Test != "Test_Flaky" {
+				t.Errorf("expected the failure to be given to the hooks of the test, got %v", failures)
+			}
+
+			namedT.cleanup()
+
+			if namedT.skipped == nil {
+				t.Error("expected test to be skipped")
+			}
+		})
+
+		t.Run("failing with attributes", func(t *testing.T) {
+			attributedT := &attrT{skippableT: newSkippableT("Test_Flaky"), attrs: make(map[string]string)}
+			list.Wrap(attributedT).Fail()
+
+			if attributedT.attrs["quarantined"] != "true" {
+				t.Errorf("expected the quarantined attribute to be emitted, got %v", attributedT.attrs)
+			}
+		})
+
+		t.Run("failing now", func(t *testing.T) {
+			namedT := newSkippableT("Test_Flaky")
+			tt := list.Wrap(namedT)
+			runInGoroutine(tt.FailNow)
+
+			namedT.ExpectTestToPass(t)
+
+			if namedT.skipped == nil {
+				t.Error("expected test to be skipped")
+			}
+		})
+
+		t.Run("failing but not skippable", func(t *testing.T) {
+			namedT := newNamedT("Test_Flaky")
+			tt := list.Wrap(namedT)
+
+			runInGoroutine(func() {
+				tt.FailNow()
+				t.Error("expected FailNow to stop the goroutine")
+			})
+
+			namedT.cleanup()
+			namedT.ExpectTestToPass(t)
+			namedT.ExpectLogsToContain(t,
+				"Quarantined: test failed but cannot be skipped, test is stopped and the failure is ignored",
+				"Quarantined: test failed but cannot be skipped, the failure is ignored",
+			)
+		})
+	})
+}
+
+type namedT struct {
+	*double.Spy
+	cleanups []func()
+}
+
+func newNamedT(name string) *namedT {
+	n := new(namedT)
+	n.Spy = double.NewSpy(double.NewFake(
+		double.FakeWithName(name),
+		double.FakeWithRegisterCleanup(func(f func()) { n.cleanups = append(n.cleanups, f) }),
+	))
+	return n
+}
+
+func (n *namedT) cleanup() {
+	runInGoroutine(func() {
+		for _, f := range n.cleanups {
+			f()
+		}
+	})
+}
+
+type skippableT struct {
+	*namedT
+	skipped []any
+}
+
+func newSkippableT(name string) *skippableT {
+	return &skippableT{namedT: newNamedT(name)}
+}
+
+func (s *skippableT) Skip(args ...any) {
+	s.skipped = args
+	runtime.Goexit()
+}
+
+func (s *skippableT) SkipNow() { s.Skip() }
+
+type attrT struct {
+	*skippableT
+	attrs map[string]string
+}
+
+func (a *attrT) Attr(key, value string) { a.attrs[key] = value }
+
+// runInGoroutine runs f in a dedicated goroutine, as f may call runtime.Goexit.
+func runInGoroutine(f func()) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		f()
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default limits of the rendering of messages, see WithMaxMessageLength, WithMaxCollectionPreview and WithMaxDepth.
+const (
+	defaultMaxMessageLength     = 16 * 1024
+	defaultMaxCollectionPreview = 32
+	defaultMaxDepth             = 5
+)
+
+// WithMaxMessageLength sets the maximum length, in bytes, of assertion messages, including diffs;
+// longer messages are truncated. Zero or a negative value disables the limit. It defaults to 16KiB.
+func WithMaxMessageLength(n int) Option {
+	return func(o *options) { o.maxMessageLength = n }
+}
+
+// WithMaxCollectionPreview sets the maximum number of elements of slices, arrays and maps rendered in values
+// attached with Values; following elements are elided. Zero or a negative value disables the limit. It defaults to 32.
+func WithMaxCollectionPreview(n int) Option {
+	return func(o *options) { o.maxCollectionPreview = n }
+}
+
+// WithMaxDepth sets the maximum depth of nested structs, collections and pointers rendered in values
+// attached with Values; deeper ones are elided. Zero or a negative value disables the limit. It defaults to 5.
+func WithMaxDepth(n int) Option {
+	return func(o *options) { o.maxDepth = n }
+}
+
+// truncateMessage truncates `msg` to `maxLength` bytes, without splitting runes, if it is longer.
+func truncateMessage(msg string, maxLength int) string {
+	if maxLength <= 0 || len(msg) <= maxLength {
+		return msg
+	}
+
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s... (%d bytes truncated)", msg[:cut], len(msg)-cut)
+}
+
+// valueRenderer renders values like the %#v verb does, eliding the elements of large collections and deep values.
+type valueRenderer struct {
+	maxCollectionPreview int
+	maxDepth             int
+
+	sb      strings.Builder
+	visited map[uintptr]bool
+}
+
+// renderValue renders `v` with the limits of the provided options.
+func renderValue(v any, opts options) string {
+	r := valueRenderer{
+		maxCollectionPreview: opts.maxCollectionPreview,
+		maxDepth:             opts.maxDepth,
+		visited:              make(map[uintptr]bool),
+	}
+
+	if v == nil {
+		return "<nil>"
+	}
+
+	r.render(reflect.ValueOf(v), 0)
+
+	return r.sb.String()
+}
+
+// render renders `v`, found at `depth` levels of nesting, in the builder.
+func (r *valueRenderer) render(v reflect.Value, depth int) {
+	switch v.Kind() { //nolint:exhaustive // other kinds are rendered by fmt
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		if v.Kind() == reflect.Slice && v.IsNil() || v.Kind() == reflect.Map && v.IsNil() {
+			_, _ = fmt.Fprintf(&r.sb, "%#v", v)
+			return
+		}
+		if r.maxDepth > 0 && depth >= r.maxDepth {
+			r.sb.WriteString(v.Type().String() + "{...}")
+			return
+		}
+	case reflect.Pointer:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			_, _ = fmt.Fprintf(&r.sb, "%#v", v)
+			return
+		}
+		if r.visited[v.Pointer()] {
+			_, _ = fmt.Fprintf(&r.sb, "(%s)(%#x)", v.Type(), v.Pointer())
+			return
+		}
+		r.visited[v.Pointer()] = true
+		r.sb.WriteString("&")
+		r.render(v.Elem(), depth)
+		delete(r.visited, v.Pointer())
+		return
+	case reflect.Interface:
+		if v.IsNil() {
+			r.sb.WriteString("<nil>")
+			return
+		}
+		r.render(v.Elem(), depth)
+		return
+	default:
+		_, _ = fmt.Fprintf(&r.sb, "%#v", v)
+		return
+	}
+
+	r.sb.WriteString(v.Type().String() + "{")
+
+	switch v.Kind() { //nolint:exhaustive // only collections and structs reach here
+	case reflect.Slice, reflect.Array:
+		r.renderElements(v.Len(), func(i int) {
+			r.render(v.Index(i), depth+1)
+		})
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+		})
+		r.renderElements(len(keys), func(i int) {
+			r.render(keys[i], depth+1)
+			r.sb.WriteString(":")
+			r.render(v.MapIndex(keys[i]), depth+1)
+		})
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if i > 0 {
+				r.sb.WriteString(", ")
+			}
+			r.sb.WriteString(v.Type().Field(i).Name + ":")
+			r.render(v.Field(i), depth+1)
+		}
+	}
+
+	r.sb.WriteString("}")
+}
+
+// renderElements renders `n` elements with `renderElement`, eliding the ones exceeding the preview limit.
+func (r *valueRenderer) renderElements(n int, renderElement func(i int)) {
+	shown := n
+	if r.maxCollectionPreview > 0 && n > r.maxCollectionPreview {
+		shown = r.maxCollectionPreview
+	}
+
+	for i := range shown {
+		if i > 0 {
+			r.sb.WriteString(", ")
+		}
+		renderElement(i)
+	}
+
+	if shown < n {
+		_, _ = fmt.Fprintf(&r.sb, ", ... (%d more)", n-shown)
+	}
+}
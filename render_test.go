@@ -0,0 +1,76 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_truncateMessage(t *testing.T) {
+	for _, tt := range []struct {
+		msg       string
+		maxLength int
+		expected  string
+	}{
+		{msg: "hello", maxLength: 0, expected: "hello"},
+		{msg: "hello", maxLength: 5, expected: "hello"},
+		{msg: "hello world", maxLength: 5, expected: "hello... (6 bytes truncated)"},
+		{msg: "héllo", maxLength: 2, expected: "h... (5 bytes truncated)"},
+	} {
+		if got := truncateMessage(tt.msg, tt.maxLength); got != tt.expected {
+			t.Errorf("unexpected truncated message for %q: got %q, want %q", tt.msg, got, tt.expected)
+		}
+	}
+}
+
+func Test_renderValue(t *testing.T) {
+	type node struct {
+		Name     string
+		children []*node
+		Next     *node
+	}
+
+	loop := &node{Name: "loop"}
+	loop.Next = loop
+
+	for _, tt := range []struct {
+		value    any
+		opts     options
+		expected string
+	}{
+		{value: nil, expected: "<nil>"},
+		{value: 42, expected: "42"},
+		{value: "a", expected: `"a"`},
+		{value: []int(nil), expected: "[]int(nil)"},
+		{value: []int{1, 2, 3}, expected: "[]int{1, 2, 3}"},
+		{value: []int{1, 2, 3}, opts: options{maxCollectionPreview: 2}, expected: "[]int{1, 2, ... (1 more)}"},
+		{value: map[string]int{"b": 2, "a": 1}, expected: `map[string]int{"a":1, "b":2}`},
+		{value: [2]any{nil, true}, expected: "[2]interface {}{<nil>, true}"},
+		{
+			value:    node{Name: "root", children: []*node{{Name: "child"}}},
+			expected: `test.node{Name:"root", children:[]*test.node{&test.node{Name:"child", children:[]*test.node(nil), Next:(*test.node)(nil)}}, Next:(*test.node)(nil)}`,
+		},
+		{
+			value:    node{Name: "root", children: []*node{{Name: "child"}}},
+			opts:     options{maxDepth: 1},
+			expected: `test.node{Name:"root", children:[]*test.node{...}, Next:(*test.node)(nil)}`,
+		},
+		{value: loop, opts: options{maxDepth: 0}, expected: "&test.node{Name:\"loop\", children:[]*test.node(nil), Next:(*test.node)("},
+	} {
+		if got := renderValue(tt.value, tt.opts); !strings.HasPrefix(got, tt.expected) {
+			t.Errorf("unexpected rendering of %#v:\n got: %s\nwant: %s", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func Test_limits(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+
+	payload := make([]byte, 1024)
+	Assert(spiedT, len(payload) == 0, Values(payload), WithMaxCollectionPreview(2))
+	spiedT.ExpectLogsToContain(t, "Error: payload is not empty; payload=[]uint8{0x0, 0x0, ... (1022 more)}")
+
+	Assert(spiedT, false, strings.Repeat("a", 100), WithMaxMessageLength(20))
+	spiedT.ExpectLogsToContain(t, "Error: literal false [aaaaa... (96 bytes truncated)")
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var _flagReplayDir = flag.String("check.replay-dir", "", "Directory to write replay files of failed assertions to")
+
+// WithReplayDir sets the directory replay files of failed assertions are written to, see Replay.
+// It can be set for all assertions with Configure, or by running tests with the -check.replay-dir=<dir> flag.
+func WithReplayDir(dir string) Option {
+	return func(o *options) { o.replayDir = dir }
+}
+
+// Replay describes a failed assertion, to help debugging failures happening in environments
+// that are hard to reproduce locally, like CI.
+//
+// When a replay directory is set, see WithReplayDir, a replay file is written for every failed Assert and Require, as JSON.
+// The testreplay command pretty-prints them:
+//
+//	go run github.com/krostar/test/cmd/testreplay <dir>/*.json
+type Replay struct {
+	Test        string            `json:"test,omitempty"`
+	File        string            `json:"file"`
+	Line        int               `json:"line"`
+	Expression  string            `json:"expression"`         // source of the asserted expression
+	Message     string            `json:"message"`            // message logged by the assertion
+	Operands    map[string]string `json:"operands,omitempty"` // values of the operands of the expression, if they were captured
+	Environment ReplayEnvironment `json:"environment"`
+	RecordedAt  time.Time         `json:"recorded_at"`
+}
+
+// ReplayEnvironment describes the environment a replay was recorded in.
+type ReplayEnvironment struct {
+	GoVersion        string `json:"go_version"`
+	OS               string `json:"os"`
+	Arch             string `json:"arch"`
+	NumCPU           int    `json:"num_cpu"`
+	Hostname         string `json:"hostname,omitempty"`
+	WorkingDirectory string `json:"working_directory,omitempty"`
+}
+
+// writeReplay writes the replay file of the failure, if replays are enabled by the options of the assertion.
+func writeReplay(t TestingT, opts options, failure Failure) {
+	t.Helper()
+
+	dir := opts.replayDir
+	if dir == "" {
+		return
+	}
+
+	replay := Replay{
+		Test:       failure.Test,
+		File:       failure.File,
+		Line:       failure.Line,
+		Expression: failure.Expression,
+		Message:    failure.Message,
+		Operands:   replayOperands(failure.Values),
+		Environment: ReplayEnvironment{
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			NumCPU:    runtime.NumCPU(),
+		},
+		RecordedAt: time.Now(),
+	}
+
+	replay.Environment.Hostname, _ = os.Hostname()
+	replay.Environment.WorkingDirectory, _ = os.Getwd()
+
+	if err := writeReplayFile(dir, replay); err != nil {
+		t.Logf("krostar/test internal failure: unable to write replay file: %v", err)
+	}
+}
+
+// replayOperands returns the representation of the values attached to the failed assertion, by name,
+// or nil if there are none.
+func replayOperands(values []NamedValue) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	operands := make(map[string]string, len(values))
+	for _, value := range values {
+		operands[value.Name] = value.Repr
+	}
+
+	return operands
+}
+
+// writeReplayFile writes the replay as JSON in a new file of the provided directory.
+func writeReplayFile(dir string, replay Replay) error {
+	raw, err := json.MarshalIndent(replay, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode replay: %v", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("unable to create replay directory %s: %v", dir, err)
+	}
+
+	pattern := "replay-*.json"
+	if replay.Test != "" {
+		pattern = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+				return r
+			}
+			return '_'
+		}, replay.Test) + "-*.json"
+	}
+
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return fmt.Errorf("unable to create replay file: %v", err)
+	}
+
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to write replay file %s: %v", f.Name(), err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to close replay file %s: %v", f.Name(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_writeReplay(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "replays")
+
+	spiedT := double.NewSpy(double.NewFake(double.FakeWithName("Test_Something/case_a")))
+	got, want := 1, 2
+	Assert(spiedT, got == want, Values(got, want), WithReplayDir(dir))
+	Assert(spiedT, got != want, WithReplayDir(dir))
+	Assert(spiedT, got == want)
+
+	files, err := filepath.Glob(filepath.Join(dir, "Test_Something_case_a-*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected exactly one replay file, got %v: %v", files, err)
+	}
+
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("unable to read replay file: %v", err)
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(raw, &replay); err != nil {
+		t.Fatalf("unable to decode replay file: %v", err)
+	}
+
+	if replay.Test != "Test_Something/case_a" ||
+		filepath.Base(replay.File) != "replay_test.go" || replay.Line != 18 ||
+		replay.Expression != "got == want" ||
+		replay.Message != "[case_a #1] got is not equal to want; got=1, want=2" ||
+		len(replay.Operands) != 2 || replay.Operands["got"] != "1" || replay.Operands["want"] != "2" ||
+		replay.Environment.GoVersion != runtime.Version() || replay.RecordedAt.IsZero() {
+		t.Errorf("unexpected replay %+v", replay)
+	}
+}
+
+func Test_writeReplayFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(dir, nil, 0o600); err != nil {
+		t.Fatalf("unable to create file: %v", err)
+	}
+
+	if err := writeReplayFile(dir, Replay{}); err == nil {
+		t.Error("expected an error when the directory cannot be created")
+	}
+}
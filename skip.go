@@ -0,0 +1,133 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/krostar/test/testingt"
+)
+
+// SkipReason is a standard reason for skipping a test, see SkipBecause.
+type SkipReason string
+
+const (
+	// SkipMissingDependency is used when a test requires something unavailable in the environment,
+	// like a database, a binary, or credentials.
+	SkipMissingDependency SkipReason = "missing-dependency"
+	// SkipPlatform is used when a test does not apply to the current operating system or architecture.
+	SkipPlatform SkipReason = "platform"
+	// SkipFlaky is used when a test is known to be flaky and is quarantined until it is fixed.
+	SkipFlaky SkipReason = "flaky"
+	// SkipSlow is used when a test is too slow to run in the current mode, like with the -short flag.
+	SkipSlow SkipReason = "slow"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	_flagSkipReportPath = flag.String("check.skip-report", "", "Path of the file to append skipped tests and their reasons to, as JSON lines")
+
+	_skipReportMutex sync.Mutex
+)
+
+// SkipRecord describes a test skipped by SkipBecause, as written in the skip report.
+type SkipRecord struct {
+	Test    string     `json:"test"`
+	Reason  SkipReason `json:"reason"`
+	Message string     `json:"message,omitempty"`
+}
+
+// WithSkipReport sets the path of the file skips made by SkipBecause are appended to, see SkipBecause.
+// It can be set for all tests with Configure, or by running tests with the -check.skip-report=<path> flag.
+func WithSkipReport(path string) Option {
+	return func(o *options) { o.skipReportPath = path }
+}
+
+// SkipBecause skips the test for the provided reason, with an optional message describing the skip.
+// Options, like WithSkipReport, can be provided along with the message.
+//
+// When a skip report is set, see WithSkipReport, the skip is appended as a JSON line (see SkipRecord) to the report file, allowing CI to track skipped tests.
+// As each package's tests run in their own directory, the path should be absolute to gather every package's skips in one file.
+//
+// If `t` does not provide a Skip method, the skip is logged and the test goroutine is stopped.
+//
+// Example:
+//
+//	func Test_Database(t *testing.T) {
+//		dsn := os.Getenv("DATABASE_DSN")
+//		if dsn == "" {
+//			test.SkipBecause(t, test.SkipMissingDependency, "DATABASE_DSN is not set")
+//		}
+//	}
+func SkipBecause(t TestingT, reason SkipReason, msgAndArgs ...any) {
+	t.Helper()
+
+	record := SkipRecord{Test: testingt.Innermost(t).Name(), Reason: reason}
+
+	opts := optionsOf(t, callOptions(msgAndArgs)...)
+	msgAndArgs = slices.DeleteFunc(slices.Clone(msgAndArgs), func(arg any) bool {
+		_, isOption := arg.(Option)
+		return isOption
+	})
+
+	switch l := len(msgAndArgs); {
+	case l == 1:
+		record.Message = fmt.Sprint(msgAndArgs[0])
+	case l > 1:
+		if format, ok := msgAndArgs[0].(string); ok {
+			record.Message = fmt.Sprintf(format, msgAndArgs[1:]...)
+		} else {
+			record.Message = fmt.Sprintf("%v", msgAndArgs)
+		}
+	}
+
+	if path := opts.skipReportPath; path != "" {
+		if err := appendSkipRecord(path, record); err != nil {
+			t.Logf("krostar/test internal failure: unable to report skip: %v", err)
+		}
+	}
+
+	msg := fmt.Sprintf("Skipped: [%s]", reason)
+	if record.Message != "" {
+		msg += " " + record.Message
+	}
+
+	if s, ok := testingt.AsSkipper(t); ok {
+		s.Skip(msg)
+		return
+	}
+
+	t.Log(msg)
+	runtime.Goexit()
+}
+
+// appendSkipRecord appends the record to the skip report located at path, as a JSON line.
+func appendSkipRecord(path string, record SkipRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("unable to encode skip record: %v", err)
+	}
+
+	_skipReportMutex.Lock()
+	defer _skipReportMutex.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // path is provided by the test author
+	if err != nil {
+		return fmt.Errorf("unable to open skip report %s: %v", path, err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to write skip report %s: %v", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to close skip report %s: %v", path, err)
+	}
+
+	return nil
+}
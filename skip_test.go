@@ -0,0 +1,60 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_SkipBecause(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skips.jsonl")
+	t.Cleanup(Configure(WithSkipReport(path)))
+
+	t.Run("skippable", func(t *testing.T) {
+		var skipped bool
+
+		t.Run("missing database", func(t *testing.T) {
+			t.Cleanup(func() { skipped = t.Skipped() })
+			SkipBecause(t, SkipMissingDependency, "%s is not set", "DATABASE_DSN")
+			t.Error("test should have been skipped")
+		})
+
+		if !skipped {
+			t.Error("expected subtest to be skipped")
+		}
+	})
+
+	t.Run("not skippable", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake(double.FakeWithName("Test_Windows")))
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			SkipBecause(spiedT, SkipPlatform, WithSkipReport(path))
+			t.Error("test goroutine should have been stopped")
+		})
+		wg.Wait()
+
+		spiedT.ExpectLogsToContain(t, "Skipped: [platform]")
+		spiedT.ExpectTestToPass(t)
+	})
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read skip report: %v", err)
+	}
+
+	if expected := `{"test":"Test_SkipBecause/skippable/missing_database","reason":"missing-dependency","message":"DATABASE_DSN is not set"}` + "\n" +
+		`{"test":"Test_Windows","reason":"platform"}` + "\n"; string(raw) != expected {
+		t.Errorf("unexpected skip report:\n%s", raw)
+	}
+}
+
+func Test_appendSkipRecord(t *testing.T) {
+	err := appendSkipRecord(filepath.Join(t.TempDir(), "notexisting", "skips.jsonl"), SkipRecord{Test: "Test_Something", Reason: SkipFlaky})
+	if err == nil {
+		t.Error("expected an error when the report cannot be opened")
+	}
+}
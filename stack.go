@@ -0,0 +1,73 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var _flagEnableStackTraces = flag.Bool("check.stack-traces", false, "Whether to append stack traces to the messages of failed assertions")
+
+// WithStackTraces appends the stack trace of failed assertions to their messages,
+// like the -check.stack-traces flag does for all assertions.
+//
+// The stack trace is trimmed from the frames of krostar/test and of the testing and runtime packages,
+// to only show the frames of the test and its helpers, which helps debugging assertions failing inside nested helpers.
+func WithStackTraces() Option {
+	return func(o *options) { o.stackTraces = true }
+}
+
+// stackTrace returns the stack trace of the caller, trimmed from the frames of krostar/test, testing, and runtime.
+func stackTrace() string {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	var sb strings.Builder
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+
+		if !isLibraryFrame(frame) {
+			_, _ = fmt.Fprintf(&sb, "\n  %s\n      %s:%d", frame.Function, frame.File, frame.Line)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return strings.TrimPrefix(sb.String(), "\n")
+}
+
+// callerLocation returns the location of the first caller outside of krostar/test, like "foo_test.go:42",
+// or an empty string if there is none.
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !isLibraryFrame(frame) {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isLibraryFrame returns whether the frame belongs to krostar/test, outside of test files, or to the testing or runtime packages.
+func isLibraryFrame(frame runtime.Frame) bool {
+	switch {
+	case strings.HasPrefix(frame.Function, "runtime."), strings.HasPrefix(frame.Function, "testing."):
+		return true
+	case strings.HasPrefix(frame.Function, "github.com/krostar/test."), strings.HasPrefix(frame.Function, "github.com/krostar/test/"):
+		return !strings.HasSuffix(frame.File, "_test.go")
+	default:
+		return false
+	}
+}
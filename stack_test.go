@@ -0,0 +1,51 @@
+package test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_WithStackTraces(t *testing.T) {
+	spiedT := double.NewSpy(double.NewFake())
+
+	var stack string
+	formatter := FormatterFunc(func(r AssertionResult) string {
+		stack = r.Stack
+		return DefaultFormatter{}.Format(r)
+	})
+
+	helper := func(t TestingT) { Assert(t, false, WithStackTraces(), WithFormatter(formatter)) }
+	helper(spiedT)
+
+	spiedT.ExpectLogsToContain(t, "Error: literal false\nstack trace:\n  github.com/krostar/test.Test_WithStackTraces.func2\n")
+	spiedT.ExpectLogsToContain(t, "stack_test.go:20\n  github.com/krostar/test.Test_WithStackTraces\n")
+
+	if strings.Contains(stack, "assert.go") || strings.Contains(stack, "testing.tRunner") {
+		t.Errorf("expected library frames to be trimmed, got %s", stack)
+	}
+
+	Assert(spiedT, false, WithFormatter(formatter))
+
+	if stack != "" {
+		t.Errorf("expected no stack trace by default, got %s", stack)
+	}
+}
+
+func Test_isLibraryFrame(t *testing.T) {
+	for frame, expected := range map[runtime.Frame]bool{
+		{Function: "runtime.goexit", File: "/go/src/runtime/asm_amd64.s"}:                     true,
+		{Function: "testing.tRunner", File: "/go/src/testing/testing.go"}:                     true,
+		{Function: "github.com/krostar/test.Assert", File: "/src/test/assert.go"}:             true,
+		{Function: "github.com/krostar/test/check.Compare", File: "/src/test/check/check.go"}: true,
+		{Function: "github.com/krostar/test.Test_Assert", File: "/src/test/assert_test.go"}:   false,
+		{Function: "github.com/krostar/testify.Foo", File: "/src/testify/foo.go"}:             false,
+		{Function: "github.com/foo/bar.Test_Bar", File: "/src/bar/bar_test.go"}:               false,
+	} {
+		if got := isLibraryFrame(frame); got != expected {
+			t.Errorf("expected frame %s to be a library frame=%t", frame.Function, expected)
+		}
+	}
+}
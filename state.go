@@ -0,0 +1,114 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/krostar/test/testingt"
+)
+
+// testState holds what krostar/test needs to remember about a running test across assertions.
+type testState struct {
+	m          sync.Mutex
+	assertions uint // number of assertions made on the test
+
+	annotations      []annotation // annotations appended to assertion messages, see Annotate
+	annotationNextID uint
+
+	failureHooks []func(Failure) // hooks called on failed assertions, see OnFailure
+
+	stats *AssertionStats // statistics of the assertions made on the test, if enabled, see recordAssertion
+}
+
+// _states associates each TestingT to its state, states are removed when tests complete.
+var _states sync.Map //nolint:gochecknoglobals // states must outlive assertions calls, and TestingT cannot hold them
+
+// cleanupsIgnorer is implemented by TestingT whose registered cleanups may never be called, like double.Fake.
+type cleanupsIgnorer interface {
+	CleanupsIgnored() bool
+}
+
+// stateOf returns the state associated to t, creating it on first use.
+// Wrappers of t, like the Collector returned by Collect, share the state of the TestingT they wrap, see stateHolder.
+// If no TestingT of the chain can hold a state, a new state is returned each time.
+func stateOf(t TestingT) *testState {
+	t = stateHolder(t)
+
+	if state, ok := lookupState(t); ok {
+		return state
+	}
+
+	if !canHoldState(t) {
+		return new(testState)
+	}
+
+	state, loaded := _states.LoadOrStore(t, new(testState))
+	if !loaded {
+		t.Cleanup(func() { _states.Delete(t) })
+	}
+
+	return state.(*testState) //nolint:forcetypeassert // only *testState are stored
+}
+
+// lookupState returns the state associated to t, if any, without creating it.
+func lookupState(t TestingT) (*testState, bool) {
+	t = stateHolder(t)
+
+	if !canHoldState(t) {
+		return nil, false
+	}
+
+	state, ok := _states.Load(t)
+	if !ok {
+		return nil, false
+	}
+
+	return state.(*testState), true //nolint:forcetypeassert // only *testState are stored
+}
+
+// stateHolder returns the innermost TestingT of the chain made of t and the TestingT it wraps (see testingt.Unwrap)
+// able to hold a state, or t itself if none of them can.
+func stateHolder(t TestingT) TestingT {
+	holder := t
+	for ; t != nil; t = testingt.Unwrap(t) {
+		if canHoldState(t) {
+			holder = t
+		}
+	}
+	return holder
+}
+
+// canHoldState returns whether a state can be associated to t: it must be usable as a map key,
+// and run its cleanups, for the state to be removed once the test completes.
+func canHoldState(t TestingT) bool {
+	if !reflect.TypeOf(t).Comparable() {
+		return false
+	}
+
+	ignorer, ok := t.(cleanupsIgnorer)
+	return !ok || !ignorer.CleanupsIgnored()
+}
+
+// caseName returns the name identifying the assertion inside a subtest,
+// typically a case of a table test run with t.Run(name, ...), and counts the assertion.
+// The name is made of the name of the subtest (as given to t.Run) and of the index of the assertion in the subtest,
+// like "case_a #2", so that failures of parallel cases remain attributable even when their outputs are interleaved.
+// It returns an empty string if t is not a subtest, or does not provide its name.
+func caseName(t TestingT) string {
+	name := testingt.Innermost(t).Name()
+
+	i := strings.LastIndexByte(name, '/')
+	if i < 0 {
+		return ""
+	}
+
+	state := stateOf(t)
+	state.m.Lock()
+	state.assertions++
+	index := state.assertions
+	state.m.Unlock()
+
+	return fmt.Sprintf("%s #%d", name[i+1:], index)
+}
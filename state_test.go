@@ -0,0 +1,112 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krostar/test/double"
+	"github.com/krostar/test/testingt"
+)
+
+func Test_stateOf(t *testing.T) {
+	t.Run("same state for the same test", func(t *testing.T) {
+		var cleanups []func()
+
+		fakeT := double.NewFake(double.FakeWithRegisterCleanup(func(f func()) { cleanups = append(cleanups, f) }))
+
+		state := stateOf(fakeT)
+		if stateOf(fakeT) != state {
+			t.Error("expected the same state to be returned")
+		}
+
+		if len(cleanups) != 1 {
+			t.Fatalf("expected exactly one cleanup to be registered, got %d", len(cleanups))
+		}
+
+		cleanups[0]()
+
+		if _, ok := _states.Load(fakeT); ok {
+			t.Error("expected state to be removed on cleanup")
+		}
+	})
+
+	t.Run("wrappers share the state of the test they wrap", func(t *testing.T) {
+		fakeT := double.NewFake(double.FakeWithRegisterCleanup(func(func()) {}))
+		state := stateOf(fakeT)
+
+		for name, wrapper := range map[string]TestingT{
+			"aggregated":   AggregateFailures(fakeT, time.Hour),
+			"deduplicated": DeduplicateFailures(fakeT),
+			"collector":    Collect(fakeT),
+			"asserter":     New(fakeT),
+			"goroutine":    Go(fakeT),
+			"nested":       New(Collect(fakeT)),
+		} {
+			if stateOf(wrapper) != state {
+				t.Errorf("%s: expected the state of the wrapped test", name)
+			}
+
+			if _, ok := testingt.AsSkipper(wrapper); ok {
+				t.Errorf("%s: expected fake not to be a skipper", name)
+			}
+		}
+
+		if s, ok := testingt.AsSkipper(Collect(New(t))); !ok || s != t {
+			t.Error("expected the wrapped *testing.T to be a skipper")
+		}
+	})
+
+	t.Run("test ignoring its cleanups", func(t *testing.T) {
+		fakeT := double.NewFake()
+
+		if stateOf(fakeT) == stateOf(fakeT) {
+			t.Error("expected a new state to be returned each time")
+		}
+
+		if _, ok := lookupState(fakeT); ok {
+			t.Error("expected no state to be stored")
+		}
+	})
+
+	t.Run("not comparable test", func(t *testing.T) {
+		type notComparableT struct {
+			TestingT
+			_ []int
+		}
+
+		nct := notComparableT{TestingT: double.NewFake()}
+		if stateOf(nct) == stateOf(nct) {
+			t.Error("expected a new state to be returned each time")
+		}
+	})
+}
+
+func Test_caseName(t *testing.T) {
+	t.Run("subtest", func(t *testing.T) {
+		if prefix := caseName(t); prefix != "subtest #1" {
+			t.Errorf("unexpected prefix %q", prefix)
+		}
+
+		if prefix := caseName(t); prefix != "subtest #2" {
+			t.Errorf("unexpected prefix %q", prefix)
+		}
+	})
+
+	if prefix := caseName(t); prefix != "" {
+		t.Errorf("expected no prefix for top level tests, got %q", prefix)
+	}
+
+	if prefix := caseName(struct{ TestingT }{TestingT: double.NewFake()}); prefix != "" {
+		t.Errorf("expected no prefix for tests without names, got %q", prefix)
+	}
+
+	t.Run("spied", func(t *testing.T) {
+		spiedT := double.NewSpy(t)
+
+		if prefix := caseName(spiedT); prefix != "spied #1" {
+			t.Errorf("unexpected prefix %q", prefix)
+		}
+
+		spiedT.ExpectRecords(t, true) // the name is not asked to the spy, whose records are the ones of the code under test
+	})
+}
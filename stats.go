@@ -0,0 +1,155 @@
+package test
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/krostar/test/testingt"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var (
+	_flagEnableStats = flag.Bool("check.stats", false, "Whether to log a summary of the assertions made by each test when it completes")
+	_flagStatsFile   = flag.String("check.stats-file", "", "Path of the file to append the assertions statistics of each test to, as JSON lines")
+
+	_statsFileMutex sync.Mutex
+)
+
+// WithStats logs a summary of the assertions made by each test when it completes:
+// how many were made, how many failed, and their most used call sites.
+// It can be enabled for all assertions with Configure, or by running tests with the -check.stats flag.
+func WithStats() Option {
+	return func(o *options) { o.stats = true }
+}
+
+// AssertionStats summarizes the assertions made by a test, see WithStats.
+type AssertionStats struct {
+	Test   string      `json:"test"`
+	Total  int         `json:"total"`
+	Failed int         `json:"failed"`
+	Sites  []SiteStats `json:"sites"` // sorted from the most to the least used call site
+}
+
+// SiteStats counts the assertions made from a call site.
+type SiteStats struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Total  int    `json:"total"`
+	Failed int    `json:"failed"`
+}
+
+// statsEnabled returns whether assertions statistics are tracked,
+// which is the case when they are logged (see WithStats) or written to a file (see the -check.stats-file flag).
+func statsEnabled(opts options) bool {
+	return opts.stats || *_flagStatsFile != ""
+}
+
+// recordAssertion counts the assertion made by the caller in the statistics of t, if enabled by the options of the assertion.
+// The statistics are reported once the test completes, with the options of its first assertion, see reportStats.
+func recordAssertion(t TestingT, opts options, callerStackIndex int, passed bool) {
+	if !statsEnabled(opts) {
+		return
+	}
+
+	_, file, line, _ := runtime.Caller(callerStackIndex + 1)
+
+	state := stateOf(t)
+
+	state.m.Lock()
+	defer state.m.Unlock()
+
+	if state.stats == nil {
+		state.stats = &AssertionStats{Test: testingt.Innermost(t).Name()}
+		t.Cleanup(func() { reportStats(t, opts, state) })
+	}
+
+	stats := state.stats
+	stats.Total++
+	if !passed {
+		stats.Failed++
+	}
+
+	i := slices.IndexFunc(stats.Sites, func(site SiteStats) bool { return site.File == file && site.Line == line })
+	if i < 0 {
+		stats.Sites = append(stats.Sites, SiteStats{File: file, Line: line})
+		i = len(stats.Sites) - 1
+	}
+
+	stats.Sites[i].Total++
+	if !passed {
+		stats.Sites[i].Failed++
+	}
+}
+
+// reportStats logs the statistics of the completed test, and appends them to the statistics file, if any.
+//
+// The statistics file holds one JSON object per line, one per test that made assertions.
+// Tests missing from it made no assertion, which helps spotting tests that check nothing.
+func reportStats(t TestingT, opts options, state *testState) {
+	state.m.Lock()
+	stats := *state.stats
+	stats.Sites = slices.Clone(stats.Sites)
+	state.m.Unlock()
+
+	slices.SortStableFunc(stats.Sites, func(a, b SiteStats) int { return cmp.Compare(b.Total, a.Total) })
+
+	if opts.stats {
+		t.Logf("Stats: %s", formatStats(stats))
+	}
+
+	if path := *_flagStatsFile; path != "" {
+		if err := appendStats(path, stats); err != nil {
+			t.Logf("krostar/test internal failure: unable to write assertions statistics: %v", err)
+		}
+	}
+}
+
+// formatStats returns a human-readable summary of the statistics, listing the most used call sites.
+func formatStats(stats AssertionStats) string {
+	const maxSites = 5
+
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%d assertions, %d failed, made from %d call sites", stats.Total, stats.Failed, len(stats.Sites))
+
+	for i, site := range stats.Sites {
+		if i == maxSites {
+			_, _ = fmt.Fprintf(&sb, "\n  - ... (%d more)", len(stats.Sites)-maxSites)
+			break
+		}
+		_, _ = fmt.Fprintf(&sb, "\n  - %s:%d: %d assertions, %d failed", filepath.Base(site.File), site.Line, site.Total, site.Failed)
+	}
+
+	return sb.String()
+}
+
+// appendStats appends the statistics as a JSON line to the file at path.
+func appendStats(path string, stats AssertionStats) error {
+	raw, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("unable to encode statistics: %w", err)
+	}
+
+	_statsFileMutex.Lock()
+	defer _statsFileMutex.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) //nolint:gosec // path is provided by the test author
+	if err != nil {
+		return fmt.Errorf("unable to open statistics file: %w", err)
+	}
+
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to write statistics file: %w", err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Stats(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		Assert(spiedT, true)
+
+		if _, ok := lookupState(spiedT); ok {
+			t.Error("expected no state to be created when statistics are disabled")
+		}
+	})
+
+	t.Run("summary is logged when test completes", func(t *testing.T) {
+		t.Cleanup(Configure(WithStats()))
+
+		var cleanup func()
+
+		spiedT := double.NewSpy(double.NewFake(
+			double.FakeWithName("Test_Foo"),
+			double.FakeWithRegisterCleanup(func(f func()) { cleanup = f }),
+		))
+
+		for i := range 3 {
+			Assert(spiedT, i < 2)
+		}
+		Equal(spiedT, 1, 1)
+
+		if cleanup == nil {
+			t.Fatal("expected statistics to be reported on cleanup")
+		}
+		cleanup()
+
+		spiedT.ExpectLogsToContain(t, "Stats: 4 assertions, 1 failed, made from 2 call sites\n  - stats_test.go:")
+		spiedT.ExpectLogsToContain(t, ": 3 assertions, 1 failed\n  - stats_test.go:")
+	})
+
+	t.Run("statistics are appended to the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stats.jsonl")
+
+		*_flagStatsFile = path
+		t.Cleanup(func() { *_flagStatsFile = "" })
+
+		for _, name := range []string{"Test_Foo", "Test_Bar"} {
+			var cleanup func()
+
+			fakeT := double.NewFake(double.FakeWithName(name), double.FakeWithRegisterCleanup(func(f func()) { cleanup = f }))
+			Assert(fakeT, false)
+			cleanup()
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unable to read statistics file: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected one line per test, got %q", raw)
+		}
+
+		var stats AssertionStats
+		if err := json.Unmarshal([]byte(lines[1]), &stats); err != nil {
+			t.Fatalf("unable to decode statistics: %v", err)
+		}
+
+		if stats.Test != "Test_Bar" || stats.Total != 1 || stats.Failed != 1 || len(stats.Sites) != 1 ||
+			filepath.Base(stats.Sites[0].File) != "stats_test.go" || stats.Sites[0].Total != 1 {
+			t.Errorf("unexpected statistics %+v", stats)
+		}
+	})
+}
+
+func Test_formatStats(t *testing.T) {
+	stats := AssertionStats{Total: 7, Failed: 1}
+	for i := range 7 {
+		stats.Sites = append(stats.Sites, SiteStats{File: "/src/foo_test.go", Line: i + 1, Total: 1})
+	}
+	stats.Sites[0].Failed = 1
+
+	expected := "7 assertions, 1 failed, made from 7 call sites\n" +
+		"  - foo_test.go:1: 1 assertions, 1 failed\n" +
+		"  - foo_test.go:2: 1 assertions, 0 failed\n" +
+		"  - foo_test.go:3: 1 assertions, 0 failed\n" +
+		"  - foo_test.go:4: 1 assertions, 0 failed\n" +
+		"  - foo_test.go:5: 1 assertions, 0 failed\n" +
+		"  - ... (2 more)"
+
+	if got := formatStats(stats); got != expected {
+		t.Errorf("unexpected summary:\n got: %s\nwant: %s", got, expected)
+	}
+}
@@ -0,0 +1,107 @@
+// Package testingt defines the TestingT interface used across krostar/test,
+// along with extension interfaces for the optional capabilities of testing types.
+//
+// TestingT is the set of methods every testing type must provide, covering the common surface of testing.TB.
+// Less common capabilities, like skipping tests or emitting structured output, are defined as extension interfaces,
+// which testing types may not implement.
+// Helpers needing such capabilities should use the As* functions, and
+// gracefully degrade when the capability is not available.
+//
+// Testing types wrapping another one, to change some of its behaviors, should implement Unwrap,
+// for the As* functions to find the capabilities of the testing type they wrap.
+package testingt
+
+import (
+	"context"
+	"io"
+)
+
+// TestingT is an interface for testing types.
+// It mimics the standard library's *testing.T.
+type TestingT interface {
+	Helper()
+	Cleanup(f func())
+
+	Fail()
+	FailNow()
+
+	Log(args ...any)
+	Logf(format string, args ...any)
+
+	Context() context.Context
+
+	Name() string
+	TempDir() string
+	Setenv(key, value string)
+	Chdir(dir string)
+}
+
+// TestingTSkipper is implemented by testing types able to skip tests, like *testing.T.
+type TestingTSkipper interface {
+	TestingT
+
+	Skip(args ...any)
+	SkipNow()
+}
+
+// TestingTAttr is implemented by testing types able to emit attributes of the test,
+// like *testing.T since Go 1.25, which are reported as structured events by test2json.
+type TestingTAttr interface {
+	TestingT
+
+	Attr(key, value string)
+}
+
+// TestingTOutput is implemented by testing types providing a writer to the output of the test,
+// like *testing.T since Go 1.25.
+type TestingTOutput interface {
+	TestingT
+
+	Output() io.Writer
+}
+
+// Unwrap returns the TestingT wrapped by t, if t has an Unwrap() TestingT method.
+// Otherwise, it returns nil.
+func Unwrap(t TestingT) TestingT {
+	u, ok := t.(interface{ Unwrap() TestingT })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
+// Innermost returns the innermost TestingT of the chain made of t and the TestingT it wraps (see Unwrap),
+// or t itself if it wraps nothing. Helpers reading information about the test, like its name,
+// should read it from there, not to go through wrappers recording their calls, like double.Spy.
+func Innermost(t TestingT) TestingT {
+	for u := Unwrap(t); u != nil; u = Unwrap(u) {
+		t = u
+	}
+	return t
+}
+
+// AsSkipper returns the first TestingTSkipper of the chain made of t and the TestingT it wraps (see Unwrap), if any.
+func AsSkipper(t TestingT) (TestingTSkipper, bool) {
+	return as[TestingTSkipper](t)
+}
+
+// AsAttr returns the first TestingTAttr of the chain made of t and the TestingT it wraps (see Unwrap), if any.
+func AsAttr(t TestingT) (TestingTAttr, bool) {
+	return as[TestingTAttr](t)
+}
+
+// AsOutput returns the first TestingTOutput of the chain made of t and the TestingT it wraps (see Unwrap), if any.
+func AsOutput(t TestingT) (TestingTOutput, bool) {
+	return as[TestingTOutput](t)
+}
+
+func as[I TestingT](t TestingT) (I, bool) {
+	for ; t != nil; t = Unwrap(t) {
+		if i, ok := t.(I); ok {
+			return i, true
+		}
+	}
+
+	var zero I
+	return zero, false
+}
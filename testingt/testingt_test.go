@@ -0,0 +1,82 @@
+package testingt
+
+import (
+	"testing"
+)
+
+var (
+	_ TestingT        = (*testing.T)(nil)
+	_ TestingTSkipper = (*testing.T)(nil)
+	_ TestingTAttr    = (*testing.T)(nil)
+	_ TestingTOutput  = (*testing.T)(nil)
+)
+
+func Test_Unwrap(t *testing.T) {
+	if u := Unwrap(wrapperT{TestingT: t}); u != t {
+		t.Errorf("expected the wrapped test to be returned, got %v", u)
+	}
+
+	if u := Unwrap(t); u != nil {
+		t.Errorf("expected nil for tests wrapping nothing, got %v", u)
+	}
+}
+
+func Test_Innermost(t *testing.T) {
+	if i := Innermost(wrapperT{TestingT: wrapperT{TestingT: t}}); i != t {
+		t.Errorf("expected the innermost test to be returned, got %v", i)
+	}
+
+	if i := Innermost(t); i != t {
+		t.Errorf("expected the test itself for tests wrapping nothing, got %v", i)
+	}
+}
+
+func Test_AsSkipper(t *testing.T) {
+	if s, ok := AsSkipper(t); !ok || s != t {
+		t.Error("expected *testing.T to be a skipper")
+	}
+
+	if s, ok := AsSkipper(wrapperT{TestingT: wrapperT{TestingT: t}}); !ok || s != t {
+		t.Error("expected the wrapped *testing.T to be found")
+	}
+
+	if _, ok := AsSkipper(minimalT{}); ok {
+		t.Error("expected fake not to be a skipper")
+	}
+}
+
+func Test_AsAttr(t *testing.T) {
+	if a, ok := AsAttr(t); !ok || a != t {
+		t.Error("expected *testing.T to be an attr")
+	}
+
+	if a, ok := AsAttr(wrapperT{TestingT: wrapperT{TestingT: t}}); !ok || a != t {
+		t.Error("expected the wrapped *testing.T to be found")
+	}
+
+	if _, ok := AsAttr(minimalT{}); ok {
+		t.Error("expected fake not to be an attr")
+	}
+}
+
+func Test_AsOutput(t *testing.T) {
+	if o, ok := AsOutput(t); !ok || o != t {
+		t.Error("expected *testing.T to be an output")
+	}
+
+	if o, ok := AsOutput(wrapperT{TestingT: wrapperT{TestingT: t}}); !ok || o != t {
+		t.Error("expected the wrapped *testing.T to be found")
+	}
+
+	if _, ok := AsOutput(minimalT{}); ok {
+		t.Error("expected fake not to be an output")
+	}
+}
+
+// minimalT only implements TestingT, it cannot be used from a test double as it would create an import cycle.
+type minimalT struct{ TestingT }
+
+// wrapperT wraps a TestingT, hiding its optional capabilities.
+type wrapperT struct{ TestingT }
+
+func (w wrapperT) Unwrap() TestingT { return w.TestingT }
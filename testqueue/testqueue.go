@@ -0,0 +1,62 @@
+// Package testqueue provides helpers to test code producing messages to queues, like Kafka topics.
+//
+// Helpers do not depend on any broker client: consuming messages is delegated to
+// a function wrapping the client of the broker used by the tested code.
+package testqueue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krostar/test"
+)
+
+// Expect repeatedly consumes messages until one of them matches, or until the context expires.
+//
+// The `consume` function must block until a message is available, or until the provided context is canceled.
+// The `match` function returns an error explaining why a message does not match.
+// Messages not matching are discarded, they are reported along with the reason they did not match on failure.
+// If `consume` returns an error while the context is not expired, Expect fails immediately.
+//
+// This is usually used like:
+//
+//	test.Assert(testqueue.Expect(ctx, t, func(ctx context.Context) (*kafka.Message, error) {
+//		return reader.ReadMessage(ctx)
+//	}, func(msg *kafka.Message) error {
+//		if string(msg.Key) != "user-42" {
+//			return fmt.Errorf("unexpected key %s", msg.Key)
+//		}
+//		return nil
+//	}))
+func Expect[M any](ctx context.Context, t test.TestingT, consume func(context.Context) (M, error), match func(M) error) (test.TestingT, bool, string) {
+	startedAt := time.Now()
+
+	var seen []string
+
+	for {
+		msg, err := consume(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return t, false, fmt.Sprintf("no matching message consumed after %s and now context is expired, %s", time.Since(startedAt).String(), describeSeenMessages(seen))
+			}
+			return t, false, fmt.Sprintf("unable to consume message: %v, %s", err, describeSeenMessages(seen))
+		}
+
+		matchErr := match(msg)
+		if matchErr == nil {
+			return t, true, fmt.Sprintf("matching message consumed after %s and %d non-matching messages", time.Since(startedAt).String(), len(seen))
+		}
+
+		seen = append(seen, fmt.Sprintf("%+v: %v", msg, matchErr))
+	}
+}
+
+// describeSeenMessages returns a description of the messages that did not match.
+func describeSeenMessages(seen []string) string {
+	if len(seen) == 0 {
+		return "no message consumed"
+	}
+	return fmt.Sprintf("%d non-matching messages consumed:\n  - %s", len(seen), strings.Join(seen, "\n  - "))
+}
@@ -0,0 +1,73 @@
+package testqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Expect(t *testing.T) {
+	matchEven := func(msg int) error {
+		if msg%2 != 0 {
+			return errors.New("message is odd")
+		}
+		return nil
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		tt, result, msg := Expect(t.Context(), t, newConsumer(1, 3, 4, 5), matchEven)
+		assertExpect(t, tt, result, true, msg, "matching message consumed after", "and 2 non-matching messages")
+	})
+
+	t.Run("context expired", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		tt, result, msg := Expect(ctx, t, newConsumer(1, 3), matchEven)
+		assertExpect(t, tt, result, false, msg,
+			"no matching message consumed after",
+			"and now context is expired, 2 non-matching messages consumed:\n  - 1: message is odd\n  - 3: message is odd",
+		)
+	})
+
+	t.Run("consume failure", func(t *testing.T) {
+		tt, result, msg := Expect(t.Context(), t, func(context.Context) (int, error) { return 0, errors.New("boom") }, matchEven)
+		assertExpect(t, tt, result, false, msg, "unable to consume message: boom, no message consumed")
+	})
+}
+
+// newConsumer returns a consume function returning the provided messages, then blocking until the context is done.
+func newConsumer(msgs ...int) func(context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		if len(msgs) == 0 {
+			<-ctx.Done()
+			return 0, fmt.Errorf("no more messages: %w", ctx.Err())
+		}
+
+		msg := msgs[0]
+		msgs = msgs[1:]
+
+		return msg, nil
+	}
+}
+
+func assertExpect(t *testing.T, tt any, result, expectedResult bool, msg string, msgContains ...string) {
+	t.Helper()
+
+	if tt != t {
+		t.Error("expected Expect to return the same testingT as provided")
+	}
+
+	if result != expectedResult {
+		t.Errorf("expected Expect to return %t, got %t with message %q", expectedResult, result, msg)
+	}
+
+	for _, m := range msgContains {
+		if !strings.Contains(msg, m) {
+			t.Errorf("expected message %q to contain %q", msg, m)
+		}
+	}
+}
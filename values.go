@@ -0,0 +1,71 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/krostar/test/internal/message"
+)
+
+// RuntimeValues holds values to display alongside the message of an assertion, see Values.
+type RuntimeValues struct {
+	values []any
+}
+
+// Values attaches the provided runtime values to the message of the assertion it is given to.
+//
+// The message built from the asserted expression tells what differed, Values tells with which values:
+// each value is displayed after the message, named after the expression it comes from.
+//
+// Example:
+//
+//	test.Assert(t, a == b, test.Values(a, b))
+//
+// -> Error: a is not equal to b; a=42, b=7
+//
+// It can be combined with a custom message, provided before or after it.
+func Values(values ...any) RuntimeValues {
+	return RuntimeValues{values: values}
+}
+
+// extractValues removes the values attached with Values from `msgAndArgs`, and names them.
+// Options, handled by callOptions, are removed as well.
+// `argIndex` is the position of the first element of `msgAndArgs` in the arguments of the assertion call,
+// used to name the values after their expressions.
+func extractValues(callerStackIndex, argIndex int, msgAndArgs []any, analyzeSource bool) ([]any, []NamedValue) {
+	var (
+		rest  = make([]any, 0, len(msgAndArgs))
+		named []NamedValue
+	)
+
+	for i, arg := range msgAndArgs {
+		if _, ok := arg.(Option); ok {
+			continue
+		}
+
+		values, ok := arg.(RuntimeValues)
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+
+		var (
+			names []string
+			err   error
+		)
+		if analyzeSource {
+			names, err = message.CallArgsExpressions(callerStackIndex+1, argIndex+i)
+		}
+		if err != nil || len(names) != len(values.values) {
+			names = make([]string, len(values.values))
+			for j := range names {
+				names[j] = fmt.Sprintf("value#%d", j+1)
+			}
+		}
+
+		for j, value := range values.values {
+			named = append(named, NamedValue{Name: names[j], Value: value})
+		}
+	}
+
+	return rest, named
+}
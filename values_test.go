@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_Values(t *testing.T) {
+	t.Run("values are named after their expressions", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a, b := 42, 7
+		Assert(spiedT, a == b, Values(a, b))
+		spiedT.ExpectLogsToContain(t, "Error: a is not equal to b; a=42, b=7")
+	})
+
+	t.Run("with a custom message", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		name := "bob"
+		Assert(spiedT, name == "alice", "user %d", 3, Values(name))
+		spiedT.ExpectLogsToContain(t, `Error: name is not equal to "alice"; name="bob" [user 3]`)
+	})
+
+	t.Run("lengths make descriptions more precise", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		got := []int{1, 2, 3}
+		Assert(spiedT, len(got) == 2, Values(len(got)))
+		Assert(spiedT, len(got) == 2)
+		spiedT.ExpectLogsToContain(t, "Error: got has length 3, expected 2; len(got)=3", "Error: got does not have length 2")
+	})
+
+	t.Run("on a collector", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		c := Collect(spiedT)
+		got := []int{1}
+		c.Assert(len(got) == 2, Values(len(got)))
+		c.Report()
+		spiedT.ExpectLogsToContain(t, "got has length 1, expected 2; len(got)=1")
+	})
+
+	t.Run("values are not named when expressions are not available", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		values := Values(1, "a")
+		Assert(spiedT, false, values)
+		spiedT.ExpectLogsToContain(t, `Error: literal false; value#1=1, value#2="a"`)
+	})
+}
@@ -0,0 +1,35 @@
+package test
+
+// Verbosity controls the level of details of the messages rendered by the DefaultFormatter.
+type Verbosity string
+
+// Available verbosity levels.
+const (
+	// VerbosityQuiet only renders the description of the asserted expression, like "got is not equal to want".
+	VerbosityQuiet Verbosity = "quiet"
+	// VerbosityNormal renders the description along with its details, like diffs, and the values, custom message
+	// and annotations of the assertion. It is the default.
+	VerbosityNormal Verbosity = "normal"
+	// VerbosityVerbose renders what VerbosityNormal renders, along with the source of the asserted expression
+	// and the location of the assertion.
+	VerbosityVerbose Verbosity = "verbose"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var _flagMsgVerbosity = newEnumFlag("check.verbosity", "Level of details of assertions messages: quiet, normal, or verbose",
+	VerbosityQuiet, VerbosityNormal, VerbosityVerbose,
+)
+
+// WithVerbosity sets the level of details of the messages of assertions,
+// like the -check.verbosity flag does for all assertions.
+func WithVerbosity(verbosity Verbosity) Option {
+	return func(o *options) { o.verbosity = verbosity }
+}
+
+// defaultVerbosity returns the verbosity set by the flag, or VerbosityNormal if the flag is not set.
+func defaultVerbosity() Verbosity {
+	if _flagMsgVerbosity.value != "" {
+		return _flagMsgVerbosity.value
+	}
+	return VerbosityNormal
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_WithVerbosity(t *testing.T) {
+	t.Run("quiet", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+
+		got, want := 1, 2
+		Equal(New(spiedT, WithVerbosity(VerbosityQuiet)), got, want)
+		Assert(spiedT, got == want, "custom message", Values(got), WithVerbosity(VerbosityQuiet))
+
+		spiedT.ExpectLogsToContain(t, "Error: got is not equal to want (-got +want):\nError: got is not equal to want")
+	})
+
+	t.Run("verbose", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		a := New(spiedT, WithVerbosity(VerbosityVerbose))
+
+		got, want := 1, 2
+		a.Assert(got == want, "custom message")
+
+		spiedT.ExpectLogsToContain(t, "Error: got is not equal to want [custom message]\nexpression: got == want\nlocation: verbosity_test.go:26")
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		if v := optionsOf(double.NewFake()).verbosity; v != VerbosityNormal {
+			t.Errorf("expected the verbosity to default to normal, got %q", v)
+		}
+
+		if err := flag.Set("check.verbosity", string(VerbosityVerbose)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(func() { _flagMsgVerbosity.value = "" })
+
+		if v := optionsOf(double.NewFake()).verbosity; v != VerbosityVerbose {
+			t.Errorf("expected the flag to be applied, got %q", v)
+		}
+
+		err := flag.Set("check.verbosity", "loud")
+		if err == nil || err.Error() != "expected one of [quiet normal verbose]" {
+			t.Errorf("expected invalid values to be rejected, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/krostar/test/internal/goroutine"
+)
+
+//nolint:gochecknoglobals // there is no clean way to deal with it, so global it is
+var _flagCheckTimeout = flag.Duration("check.timeout", 0, "Maximum duration of the evaluation of checks by assertions, a zero duration disables the limit")
+
+// WithCheckTimeout aborts the assertions whose checks, like the ones given to AssertAllChecks,
+// take longer than `timeout` to evaluate. It can be enabled for all assertions with Configure,
+// or by running tests with the -check.timeout flag.
+//
+// Without it, a stuck check blocks the test until go test panics on its deadline, and the only
+// hint of the culprit is buried in the dump of every goroutine of the binary.
+// A timed out check fails with a message stating the exceeded duration, followed by the goroutines dump
+// taken at that moment. The check keeps running in the background, as goroutines cannot be stopped.
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.checkTimeout = timeout }
+}
+
+// checkOutcome holds the results of an evaluated check, see runCheck.
+type checkOutcome struct {
+	result    bool
+	msg       string
+	panicked  bool
+	recovered any
+}
+
+// runCheck evaluates the check, and returns its result and message.
+// If the evaluation takes longer than the timeout of the options (see WithCheckTimeout), the check is abandoned,
+// and a failed result is returned along with a message describing the timeout.
+// Panics of the check are propagated, and if the check stops its goroutine (like FailNow does), so does runCheck.
+func runCheck(t TestingT, opts options, check func(t TestingT) (TestingT, bool, string)) (bool, string) {
+	t.Helper()
+
+	if opts.checkTimeout <= 0 {
+		_, result, msg := check(t)
+		return result, msg
+	}
+
+	outcomeCh := make(chan *checkOutcome, 1)
+
+	go func() {
+		var outcome *checkOutcome
+		defer func() { outcomeCh <- outcome }()
+
+		defer func() {
+			if r := recover(); r != nil {
+				outcome = &checkOutcome{panicked: true, recovered: r}
+			}
+		}()
+
+		_, result, msg := check(t)
+		outcome = &checkOutcome{result: result, msg: msg}
+	}()
+
+	timer := time.NewTimer(opts.checkTimeout)
+	defer timer.Stop()
+
+	select {
+	case outcome := <-outcomeCh:
+		switch {
+		case outcome == nil: // check goroutine stopped with runtime.Goexit
+			runtime.Goexit()
+		case outcome.panicked:
+			panic(outcome.recovered)
+		}
+		return outcome.result, outcome.msg
+	case <-timer.C:
+		return false, fmt.Sprintf("assertion exceeded %s, goroutines:\n%s", opts.checkTimeout.String(), goroutine.Dump())
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/krostar/test/double"
+)
+
+func Test_WithCheckTimeout(t *testing.T) {
+	t.Run("check completing in time", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		restore := Configure(WithCheckTimeout(time.Minute))
+		t.Cleanup(restore)
+
+		AssertAllChecks(spiedT, func(t TestingT) (TestingT, bool, string) { return t, false, "boom" })
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: check #1: boom")
+	})
+
+	t.Run("check exceeding the timeout", func(t *testing.T) {
+		spiedT := double.NewSpy(double.NewFake())
+		restore := Configure(WithCheckTimeout(10 * time.Millisecond))
+		t.Cleanup(restore)
+
+		release := make(chan struct{})
+		t.Cleanup(func() { close(release) })
+
+		AssertAllChecks(spiedT, func(t TestingT) (TestingT, bool, string) {
+			<-release
+			return t, true, ""
+		})
+
+		spiedT.ExpectTestToFail(t)
+		spiedT.ExpectLogsToContain(t, "Error: check #1: assertion exceeded 10ms, goroutines:\ngoroutine ")
+		spiedT.ExpectLogsToContain(t, "Test_WithCheckTimeout")
+	})
+}
+
+func Test_runCheck(t *testing.T) {
+	opts := options{checkTimeout: time.Minute}
+
+	t.Run("panics are propagated", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("expected the panic to be propagated, got %v", r)
+			}
+		}()
+
+		runCheck(double.NewFake(), opts, func(TestingT) (TestingT, bool, string) { panic("boom") })
+		t.Error("expected runCheck to panic")
+	})
+
+	t.Run("goroutine stops are propagated", func(t *testing.T) {
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			runCheck(double.NewFake(), opts, func(t TestingT) (TestingT, bool, string) {
+				runtime.Goexit() // like testing.T.FailNow does
+				return t, true, ""
+			})
+			t.Error("expected runCheck to stop the goroutine")
+		}()
+
+		<-done
+	})
+}